@@ -1,15 +1,16 @@
 package art
 
 import (
-	"fmt"
 	"os"
 
 	"github.com/common-nighthawk/go-figure"
+	"github.com/fatih/color"
 	"github.com/gnomegl/gitslurp/v2/internal/utils"
 )
 
 func PrintLogo() {
 	myFigure := figure.NewFigure("gitslurp", "chunky", false)
-	fmt.Fprintf(os.Stderr, "\033[36m%s\033[0m", myFigure.String())
-	fmt.Fprintf(os.Stderr, "              \033[91mv%s by gnomegl\033[0m\n\n", utils.GetVersion())
+	color.New(color.FgCyan).Fprint(os.Stderr, myFigure.String())
+	color.New(color.FgHiRed).Fprintf(os.Stderr, "              v%s by gnomegl", utils.GetVersion())
+	os.Stderr.WriteString("\n\n")
 }
@@ -12,12 +12,17 @@ import (
 	"github.com/fatih/color"
 	"github.com/gnomegl/gitslurp/v2/internal/config"
 	"github.com/gnomegl/gitslurp/v2/internal/github"
+	"github.com/gnomegl/gitslurp/v2/internal/status"
 	"github.com/gnomegl/gitslurp/v2/internal/utils"
 	gh "github.com/google/go-github/v57/github"
 	"github.com/urfave/cli/v2"
 )
 
 func SetupClientPool(c *cli.Context, ctx context.Context, appConfig *config.AppConfig) (*github.ClientPool, error) {
+	if appConfig.AppID != 0 {
+		return setupAppClientPool(ctx, appConfig)
+	}
+
 	var tokens []string
 
 	if appConfig.TokenFile != "" {
@@ -27,7 +32,7 @@ func SetupClientPool(c *cli.Context, ctx context.Context, appConfig *config.AppC
 			return nil, fmt.Errorf("failed to read token file: %v", err)
 		}
 		if c.String("token") != "" {
-			color.Yellow("[!] --token-file takes precedence over --token")
+			status.Yellow("[!] --token-file takes precedence over --token")
 		}
 	} else {
 		token := github.GetToken(c)
@@ -66,9 +71,50 @@ func SetupClientPool(c *cli.Context, ctx context.Context, appConfig *config.AppC
 	}
 
 	if pool.Size() > 1 {
-		color.Green("[+] Token pool initialized with %d tokens", pool.Size())
+		status.Green("[+] Token pool initialized with %d tokens", pool.Size())
+	}
+
+	return pool, nil
+}
+
+// setupAppClientPool authenticates as a GitHub App installation instead of
+// with personal access tokens.
+func setupAppClientPool(ctx context.Context, appConfig *config.AppConfig) (*github.ClientPool, error) {
+	if appConfig.InstallationID == 0 || appConfig.PrivateKey == "" {
+		return nil, fmt.Errorf("--app-id requires --installation-id and --private-key")
+	}
+	if appConfig.TokenFile != "" || appConfig.Token != "" {
+		status.Yellow("[!] GitHub App credentials take precedence over --token/--token-file")
+	}
+
+	key, err := github.ReadAppPrivateKey(appConfig.PrivateKey)
+	if err != nil {
+		return nil, err
 	}
 
+	proxy := appConfig.Proxy
+	if proxy != "" && !strings.Contains(proxy, "://") {
+		proxy = "http://" + proxy
+	}
+
+	source, err := github.NewAppTokenSource(appConfig.AppID, appConfig.InstallationID, key, proxy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create app token source: %v", err)
+	}
+
+	// mint the first token up front so bad credentials fail fast
+	if _, err := source.Token(); err != nil {
+		return nil, fmt.Errorf("GitHub App authentication failed: %v", err)
+	}
+
+	pool, err := github.NewAppClientPool(source, proxy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client pool: %v", err)
+	}
+
+	checkLatestVersion(ctx, pool.GetClient().Client)
+	status.Green("[+] Authenticated as GitHub App installation %d", appConfig.InstallationID)
+
 	return pool, nil
 }
 
@@ -1,7 +1,10 @@
 package cli
 
 import (
+	"time"
+
 	"github.com/gnomegl/gitslurp/v2/internal/art"
+	"github.com/gnomegl/gitslurp/v2/internal/status"
 	"github.com/gnomegl/gitslurp/v2/internal/utils"
 	"github.com/urfave/cli/v2"
 )
@@ -17,21 +20,34 @@ Options:
    {{end}}{{end}}
 `
 
-func NewApp(action cli.ActionFunc) *cli.App {
+// NewApp builds the CLI. action runs the default analysis; spiderAction
+// runs the spider subcommand.
+func NewApp(action, spiderAction cli.ActionFunc) *cli.App {
 	cli.AppHelpTemplate = helpTemplate
-	art.PrintLogo()
+	if !status.Quiet {
+		art.PrintLogo()
+	}
 
 	return &cli.App{
 		Name:    "gitslurp",
 		Usage:   "OSINT tool to analyze GitHub/GitLab/Codeberg user's activity and commit history",
 		Version: "v" + utils.GetVersion(),
-		Flags: []cli.Flag{
+		Flags: append([]cli.Flag{
 			&cli.StringFlag{
 				Name:    "platform",
-				Usage:   "Platform to scan: github, gitlab, codeberg (default: github)",
+				Aliases: []string{"provider"},
+				Usage:   "Platform to scan: github, gitlab, codeberg (default: github); GitHub-only options are ignored on the others",
 				Value:   "github",
 				EnvVars: []string{"GITSLURP_PLATFORM"},
 			},
+			&cli.StringFlag{
+				Name:  "local",
+				Usage: "Analyze a local git clone at this path offline instead of querying an API (target is optional and marks the target's commits)",
+			},
+			&cli.Int64Flag{
+				Name:  "by-id",
+				Usage: "Look the GitHub target up by numeric user ID instead of username, to follow an account across renames",
+			},
 			&cli.StringFlag{
 				Name:    "token",
 				Aliases: []string{"t"},
@@ -43,6 +59,10 @@ func NewApp(action cli.ActionFunc) *cli.App {
 				Aliases: []string{"d"},
 				Usage:   "Show detailed commit information",
 			},
+			&cli.BoolFlag{
+				Name:  "show-target-only",
+				Usage: "Only list the target's own emails, hiding other contributors",
+			},
 			&cli.StringFlag{
 				Name:    "secrets",
 				Aliases: []string{"s"},
@@ -53,6 +73,64 @@ func NewApp(action cli.ActionFunc) *cli.App {
 				Aliases: []string{"i"},
 				Usage:   "Get interesting strings",
 			},
+			&cli.BoolFlag{
+				Name:  "links",
+				Usage: "Collect the links in commit messages and in the lines commits add",
+			},
+			&cli.BoolFlag{
+				Name:  "resolve-links",
+				Usage: "Send a HEAD request to each profile pivot link and show where it leads",
+			},
+			&cli.StringFlag{
+				Name:  "patterns",
+				Usage: "YAML or JSON file of extra secret patterns (name, regex, type: secret|interesting) to scan for",
+			},
+			&cli.Float64Flag{
+				Name:  "min-entropy",
+				Usage: "Minimum Shannon entropy (bits/char) for Generic Secret matches to be reported",
+				Value: 3.5,
+			},
+			&cli.StringFlag{
+				Name:  "baseline",
+				Usage: "JSON file of reviewed findings (commit hash + fingerprint) to suppress",
+			},
+			&cli.BoolFlag{
+				Name:  "write-baseline",
+				Usage: "Write every finding from this run to the --baseline file instead of suppressing",
+			},
+			&cli.BoolFlag{
+				Name:  "verify-secrets",
+				Usage: "Check detected AWS keys against AWS STS and tag findings VALID, INVALID or UNKNOWN",
+			},
+			&cli.BoolFlag{
+				Name:  "redact",
+				Usage: "Mask secret values in all output, keeping only the first and last 4 characters",
+			},
+			&cli.BoolFlag{
+				Name:  "graphql",
+				Usage: "List repository histories over the GraphQL API, several repositories per request, falling back to REST",
+			},
+			&cli.BoolFlag{
+				Name:  "no-cache",
+				Usage: "Don't read or write the on-disk cache of fetched commits",
+			},
+			&cli.DurationFlag{
+				Name:  "cache-ttl",
+				Usage: "How long cached commits are reused before being fetched again (0 keeps them forever)",
+				Value: 30 * 24 * time.Hour,
+			},
+			&cli.BoolFlag{
+				Name:  "scan-vendored",
+				Usage: "Also scan vendor/ and dist/ directories, minified files and source maps",
+			},
+			&cli.BoolFlag{
+				Name:  "include-patches",
+				Usage: "Include the file patches of flagged commits in the output",
+			},
+			&cli.StringFlag{
+				Name:  "patches-dir",
+				Usage: "Also write flagged commit patches to this directory (implies --include-patches)",
+			},
 			&cli.BoolFlag{
 				Name:    "show-stargazers",
 				Aliases: []string{"S"},
@@ -63,15 +141,52 @@ func NewApp(action cli.ActionFunc) *cli.App {
 				Aliases: []string{"f"},
 				Usage:   "Show users who forked the repository",
 			},
+			&cli.BoolFlag{
+				Name:  "no-color",
+				Usage: "Disable colored output (also honors NO_COLOR, and is automatic when stdout is not a terminal)",
+			},
+			&cli.BoolFlag{
+				Name:  "quiet",
+				Usage: "Hide progress bars and status messages; print only results and errors",
+			},
+			&cli.StringFlag{
+				Name:    "output-format",
+				Aliases: []string{"o"},
+				Usage:   "Output format: text, json, ndjson (streamed JSON, same as json), csv, markdown or dot",
+			},
 			&cli.BoolFlag{
 				Name:    "json",
 				Aliases: []string{"j"},
 				Usage:   "Output results in JSON format",
 			},
+			&cli.BoolFlag{
+				Name:  "compact",
+				Usage: "Accepted for compatibility; JSON records are always written one per line",
+			},
 			&cli.BoolFlag{
 				Name:  "csv",
 				Usage: "Output results in CSV format",
 			},
+			&cli.BoolFlag{
+				Name:  "markdown",
+				Usage: "Output results as a Markdown report",
+			},
+			&cli.StringFlag{
+				Name:  "output-file",
+				Usage: "Write the --json, --csv or --markdown results to this file instead of stdout, keeping progress on the terminal",
+			},
+			&cli.StringFlag{
+				Name:  "json-out",
+				Usage: "Also write JSON results to this file, alongside the normal output",
+			},
+			&cli.StringFlag{
+				Name:  "csv-out",
+				Usage: "Also write CSV results to this file, alongside the normal output",
+			},
+			&cli.StringFlag{
+				Name:  "output-dir",
+				Usage: "Directory for written files (event lists, graphs, patches, scan results); created if needed (default: current directory)",
+			},
 			&cli.BoolFlag{
 				Name:    "profile-only",
 				Aliases: []string{"p"},
@@ -80,71 +195,302 @@ func NewApp(action cli.ActionFunc) *cli.App {
 			&cli.BoolFlag{
 				Name:    "quick",
 				Aliases: []string{"q"},
-				Usage:   "Quick mode - fetch ~50 most recent commits per repo",
+				Usage:   "Quick mode - read commits from the user's recent public events instead of their repositories (with --deep, fetch ~50 most recent commits per repo)",
+			},
+			&cli.BoolFlag{
+				Name:  "deep",
+				Usage: "Crawl the full commit history of every repository instead of the 10 most recently pushed",
 			},
 			&cli.BoolFlag{
 				Name:    "timestamp-analysis",
 				Aliases: []string{"T"},
 				Usage:   "Analyze commit timestamps for unusual patterns",
 			},
+			&cli.StringFlag{
+				Name:  "holidays",
+				Usage: "With --timestamp-analysis, report commits on this country's public holidays: US, GB, DE, FR or CA",
+			},
+			&cli.StringFlag{
+				Name:  "since",
+				Usage: "Only include commits on or after this date (YYYY-MM-DD or RFC 3339)",
+			},
+			&cli.StringFlag{
+				Name:  "until",
+				Usage: "Only include commits up to and including this date (YYYY-MM-DD or RFC 3339)",
+			},
+			&cli.StringFlag{
+				Name:  "branch",
+				Usage: "Walk commits on this branch instead of each repository's default branch",
+			},
+			&cli.BoolFlag{
+				Name:  "all-branches",
+				Usage: "Walk commits on every branch, deduplicated by SHA",
+			},
+			&cli.IntFlag{
+				Name:  "max-repos",
+				Usage: "Scan at most this many repositories (0 for no limit)",
+			},
+			&cli.IntFlag{
+				Name:  "max-commits-per-repo",
+				Usage: "Fetch at most this many of the newest commits per repository (0 for no limit)",
+			},
 			&cli.BoolFlag{
 				Name:    "include-forks",
 				Aliases: []string{"F"},
 				Usage:   "Include forked repositories in the scan (default: only owned repos)",
 			},
+			&cli.BoolFlag{
+				Name:  "include-anonymous",
+				Usage: "Keep commits that have an author name but no email, grouped by name",
+			},
+			&cli.BoolFlag{
+				Name:  "include-committers",
+				Usage: "Also list committer emails that differ from the commit's author",
+			},
+			&cli.BoolFlag{
+				Name:  "no-gists",
+				Usage: "Skip fetching and scanning the target's gists",
+			},
+			&cli.BoolFlag{
+				Name:  "scan-issues",
+				Usage: "Also scan the issues, pull requests and comments the target wrote in their repositories (with --secrets or --interesting)",
+			},
+			&cli.BoolFlag{
+				Name:  "strict-org-domain",
+				Usage: "Only count emails on the organization's exact domain or its subdomains as members",
+			},
+			&cli.BoolFlag{
+				Name:  "exclude-merges",
+				Usage: "Leave merge commits (2+ parents) out of contributor commit counts",
+			},
+			&cli.BoolFlag{
+				Name:    "yes",
+				Aliases: []string{"y"},
+				Usage:   "Skip the confirmation asked before analyzing targets with more than 500 public repositories (required for scripts and JSON/CSV output)",
+			},
 			&cli.StringFlag{
-				Name:     "token-file",
-				Usage:    "Path to file with one GitHub token per line",
-				Category: "Spidering:",
+				Name:  "repo-type",
+				Usage: "Which of a user's repositories to scan: owner (only repos they own), member (only repos they collaborate on), all (both)",
+				Value: "all",
+			},
+			&cli.BoolFlag{
+				Name:  "fork-network",
+				Usage: "Compare the user's forks against their upstreams and report the commits they pushed that upstream does not have",
+			},
+			&cli.BoolFlag{
+				Name:  "include-private",
+				Usage: "When the target is the token's own account, also scan its private repositories",
+			},
+			&cli.BoolFlag{
+				Name:  "org-members",
+				Usage: "For organization targets, also list the public members, including those who never committed",
+			},
+			&cli.IntFlag{
+				Name:  "top",
+				Usage: "Only print the N contributors with the most commits in the text view; target and similar accounts always show (JSON/CSV keep everyone)",
+			},
+			&cli.IntFlag{
+				Name:  "hash-length",
+				Usage: "Number of characters of commit hashes to print (0 for full hashes)",
+				Value: 8,
 			},
 			&cli.StringFlag{
-				Name:    "proxy",
-				Aliases: []string{"P"},
-				Usage:   "Proxy URL (user:pass@host:port)",
+				Name:  "filter-domain",
+				Usage: "Only report emails on these comma-separated domains or their subdomains",
 			},
 			&cli.StringFlag{
-				Name:     "proxy-file",
-				Usage:    "Path to file with one proxy per line",
-				Category: "Spidering:",
+				Name:  "exclude-domain",
+				Usage: "Drop emails on these comma-separated domains or their subdomains from the results",
 			},
 			&cli.BoolFlag{
-				Name:     "spider",
-				Usage:    "Build social graph by spidering a user's GitHub relationships",
-				Category: "Spidering:",
+				Name:  "hide-noreply",
+				Usage: "Drop GitHub noreply addresses (users.noreply.github.com, noreply@github.com) from the results",
+			},
+			&cli.StringFlag{
+				Name:  "sort",
+				Usage: "Order of the email results: commits, email, name or recent (latest commit first)",
+				Value: "commits",
 			},
 			&cli.IntFlag{
-				Name:     "depth",
-				Usage:    "Spider depth - how many levels deep to crawl",
-				Value:    1,
-				Category: "Spidering:",
+				Name:  "max-names",
+				Usage: "Maximum names printed per email, most used first (0 for all; JSON/CSV keep every name)",
+				Value: 10,
 			},
 			&cli.IntFlag{
-				Name:     "min-repos",
-				Usage:    "Skip users with fewer than N public repos during spider",
-				Category: "Spidering:",
+				Name:    "repo-concurrency",
+				Aliases: []string{"threads"},
+				Usage:   "Number of repositories processed in parallel (1-32)",
+				Value:   3,
 			},
 			&cli.IntFlag{
-				Name:     "min-followers",
-				Usage:    "Skip users with fewer than N followers during spider",
-				Category: "Spidering:",
+				Name:  "commit-concurrency",
+				Usage: "Number of commit details fetched in parallel within each repository",
+				Value: 4,
 			},
 			&cli.IntFlag{
-				Name:     "max-nodes",
-				Usage:    "Stop spidering after N total nodes in graph",
-				Value:    500,
+				Name:  "gist-concurrency",
+				Usage: "Number of gist contents fetched in parallel",
+				Value: 4,
+			},
+			&cli.IntFlag{
+				Name:  "gist-retries",
+				Usage: "Retries for each gist request on transient errors",
+				Value: 2,
+			},
+			&cli.Int64Flag{
+				Name:  "max-api-calls",
+				Usage: "Stop making GitHub API requests after this many and report partial results (0 = no limit)",
+			},
+			&cli.BoolFlag{
+				Name:  "wait",
+				Usage: "When the GitHub rate limit runs out, wait for it to reset and resume instead of returning partial results",
+			},
+			&cli.DurationFlag{
+				Name:  "max-wait",
+				Usage: "Longest single wait for a rate limit reset, e.g. 15m (implies --wait; 0 = until the reset)",
+			},
+			&cli.DurationFlag{
+				Name:  "timeout",
+				Usage: "Stop the run after this long, e.g. 10m, and report what was found so far (0 = no limit)",
+			},
+			&cli.StringFlag{
+				Name:  "spoof-repo-prefix",
+				Usage: "Name prefix of the temporary repository the email spoofing fallback creates",
+				Value: "temp-spoof",
+			},
+			&cli.DurationFlag{
+				Name:  "spoof-sync-wait",
+				Usage: "Pause before each of up to 5 lookups of the spoofed commit, while GitHub indexes the push",
+				Value: 3 * time.Second,
+			},
+			&cli.Int64Flag{
+				Name:     "app-id",
+				Usage:    "GitHub App ID, to authenticate as an App installation instead of a token",
+				EnvVars:  []string{"GITSLURP_APP_ID"},
+				Category: "GitHub App:",
+			},
+			&cli.Int64Flag{
+				Name:     "installation-id",
+				Usage:    "GitHub App installation ID",
+				EnvVars:  []string{"GITSLURP_INSTALLATION_ID"},
+				Category: "GitHub App:",
+			},
+			&cli.StringFlag{
+				Name:     "private-key",
+				Usage:    "Path to the GitHub App private key (PEM)",
+				EnvVars:  []string{"GITSLURP_APP_PRIVATE_KEY"},
+				Category: "GitHub App:",
+			},
+			&cli.StringFlag{
+				Name:    "token-file",
+				Aliases: []string{"tokens-file"},
+				Usage:   "Path to file with one GitHub token per line; requests rotate across them by remaining rate limit",
+			},
+			&cli.StringFlag{
+				Name:    "proxy",
+				Aliases: []string{"P"},
+				Usage:   "Proxy URL (user:pass@host:port)",
+			},
+			&cli.StringFlag{
+				Name:    "proxy-file",
+				Aliases: []string{"proxies-file"},
+				Usage:   "Path to file with one proxy per line; the Nth proxy carries the Nth token's requests",
+			},
+			&cli.BoolFlag{
+				Name:     "spider",
+				Usage:    "Build social graph by spidering a user's GitHub relationships",
 				Category: "Spidering:",
 			},
 			&cli.StringFlag{
 				Name:     "spider-output",
-				Usage:    "Output file path for spider graph (default: <username>_graph.gexf)",
+				Usage:    "Output file path for spider graph (default: <username>_graph.<format>)",
 				Category: "Spidering:",
 			},
+		}, spiderFlags()...),
+		Commands: []*cli.Command{
+			{
+				Name:      "spider",
+				Usage:     "Build a social graph of a GitHub user's relationships",
+				ArgsUsage: "<username>",
+				Flags: append([]cli.Flag{
+					&cli.IntFlag{
+						Name:  "workers",
+						Usage: "Concurrent users enumerated at a time (0 = 5 per token)",
+					},
+					&cli.StringFlag{
+						Name:  "output",
+						Usage: "Output file path for the graph (default: <username>_graph.<format>)",
+					},
+				}, spiderFlags()...),
+				Action: spiderAction,
+			},
 		},
 		Action:    action,
 		ArgsUsage: "<username|email>",
-		UsageText: "gitslurp [options] <username|email>\n\n   Platform examples:\n     gitslurp torvalds                          # GitHub (default)\n     gitslurp --platform gitlab torvalds         # GitLab\n     gitslurp --platform codeberg wiktor         # Codeberg",
+		UsageText: "gitslurp [options] <username|email>\n   gitslurp [options] spider [spider options] <username>\n\n   Platform examples:\n     gitslurp torvalds                          # GitHub (default)\n     gitslurp --platform gitlab torvalds         # GitLab\n     gitslurp --platform codeberg wiktor         # Codeberg",
 		Authors: []*cli.Author{
 			{Name: "gnomegl"},
 		},
 	}
 }
+
+// spiderFlags are shared by --spider and the spider subcommand.
+func spiderFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.IntFlag{
+			Name:     "depth",
+			Usage:    "Spider depth - how many levels deep to crawl",
+			Value:    1,
+			Category: "Spidering:",
+		},
+		&cli.IntFlag{
+			Name:     "min-repos",
+			Usage:    "Skip users with fewer than N public repos during spider",
+			Category: "Spidering:",
+		},
+		&cli.IntFlag{
+			Name:     "min-followers",
+			Usage:    "Skip users with fewer than N followers during spider",
+			Category: "Spidering:",
+		},
+		&cli.IntFlag{
+			Name:     "max-nodes",
+			Usage:    "Stop spidering after N total nodes in graph",
+			Value:    500,
+			Category: "Spidering:",
+		},
+		&cli.IntFlag{
+			Name:     "committer-pages",
+			Usage:    "Max pages (100 each) of commits, stargazers and watchers fetched per repo during spider (0 = unlimited)",
+			Value:    3,
+			Category: "Spidering:",
+		},
+		&cli.StringFlag{
+			Name:     "graph-format",
+			Usage:    "Spider graph format: gexf, json or graphml",
+			Value:    "gexf",
+			Category: "Spidering:",
+		},
+		&cli.BoolFlag{
+			Name:     "no-bots",
+			Usage:    "Leave bot accounts (logins ending in [bot], dependabot, renovate...) out of the spider graph; --no-bots=false keeps them",
+			Value:    true,
+			Category: "Spidering:",
+		},
+		&cli.StringFlag{
+			Name:     "bot-logins",
+			Usage:    "Comma-separated extra logins treated as bots by --no-bots",
+			Category: "Spidering:",
+		},
+		&cli.BoolFlag{
+			Name:     "resume",
+			Usage:    "Continue an interrupted spider from the checkpoint saved after each completed depth",
+			Category: "Spidering:",
+		},
+		&cli.BoolFlag{
+			Name:     "collapse-edges",
+			Usage:    "Merge all relationship types between two users into one edge weighted by their total",
+			Category: "Spidering:",
+		},
+	}
+}
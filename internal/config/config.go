@@ -3,39 +3,120 @@ package config
 import (
 	"fmt"
 	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
 	"strings"
+	"time"
 
+	"github.com/gnomegl/gitslurp/v2/internal/scanner"
+	"github.com/gnomegl/gitslurp/v2/internal/spider"
+	"github.com/gnomegl/gitslurp/v2/internal/utils"
 	"github.com/urfave/cli/v2"
 )
 
+// spoofPrefixPattern limits --spoof-repo-prefix to characters GitHub allows in
+// repository names.
+var spoofPrefixPattern = regexp.MustCompile(`^[A-Za-z0-9._-]+$`)
+
 type AppConfig struct {
 	ShowDetails       bool
 	CheckSecrets      bool
 	SecretsScope      string
 	ShowTargetOnly    bool
 	ShowInteresting   bool
+	MinEntropy        float64
+	CustomPatterns    []scanner.CustomPattern
+	VerifySecrets     bool
+	Redact            bool
+	Baseline          string
+	BaselineFindings  *scanner.Baseline
+	WriteBaseline     bool
+	IncludePatches    bool
+	ScanVendored      bool
+	PatchesDir        string
+	OutputDir         string
 	ProfileOnly       bool
 	ShowStargazers    bool
 	ShowForkers       bool
 	QuickMode         bool
+	Deep              bool
 	TimestampAnalysis bool
+	Holidays          string
 	IncludeForks      bool
+	IncludeAnonymous  bool
+	IncludeCommitters bool
+	NoGists           bool
+	ScanIssues        bool
+	FindLinks         bool
+	ResolveLinks      bool
+	StrictOrgDomain   bool
+	ExcludeMerges     bool
+	Since             time.Time
+	Until             time.Time
+	CacheDir          string
+	CacheTTL          time.Duration
+	GraphQL           bool
+	MaxRepos          int
+	MaxCommitsPerRepo int
+	Branch            string
+	AllBranches       bool
+	ForkNetwork       bool
+	OrgMembers        bool
+	IncludePrivate    bool
+	RepoType          string
+	AssumeYes         bool
+	SpoofRepoPrefix   string
+	SpoofSyncWait     time.Duration
+	TopContributors   int
+	HashLength        int
+	MaxNames          int
+	SortBy            string
+	FilterDomains     []string
+	ExcludeDomains    []string
+	HideNoreply       bool
+
+	RepoConcurrency   int
+	CommitConcurrency int
+	GistConcurrency   int
+	GistRetries       int
+	MaxAPICalls       int64
+	WaitOnRateLimit   bool
+	MaxWait           time.Duration
+	Timeout           time.Duration
 
-	SpiderMode   bool
-	SpiderDepth  int
-	MinRepos     int
-	MinFollowers int
-	MaxNodes     int
-	SpiderOutput string
+	SpiderMode     bool
+	SpiderDepth    int
+	MinRepos       int
+	MinFollowers   int
+	MaxNodes       int
+	SpiderOutput   string
+	CommitterPages int
+	GraphFormat    string
+	CollapseEdges  bool
+	SkipBots       bool
+	BotLogins      []string
+	Resume         bool
+	SpiderWorkers  int
 
 	OutputFormat string
+	JSONOut      string
+	CSVOut       string
+	OutputFile   string
+	Quiet        bool
 	Target       string
+	ByID         int64
 	Platform     string
+	LocalPath    string
 	Token        string
 
 	TokenFile string
 	Proxy     string
 	ProxyFile string
+
+	AppID          int64
+	InstallationID int64
+	PrivateKey     string
 }
 
 // valid scopes for --secrets flag
@@ -55,6 +136,38 @@ func isSecretsScope(val string) bool {
 	return true
 }
 
+// parseDomainList splits a comma-separated domain list, accepting entries
+// written as "@acme.com" too.
+func parseDomainList(value string) []string {
+	var domains []string
+	for _, d := range strings.Split(value, ",") {
+		d = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(d), "@"))
+		if d != "" {
+			domains = append(domains, d)
+		}
+	}
+	return domains
+}
+
+// splitList splits a comma-separated flag value, dropping empty entries.
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// OutputFormats are the values accepted by --output-format. ndjson names
+// the streamed JSON output and is the same as json.
+var OutputFormats = []string{"text", "json", "ndjson", "csv", "markdown", "dot"}
+
+// MaxRepoConcurrency caps --repo-concurrency (--threads). Past this GitHub's
+// secondary rate limit slows a crawl down more than extra workers speed it up.
+const MaxRepoConcurrency = 32
+
 // NormalizeArgs preprocesses os.Args to handle -s/--secrets with optional value.
 // If -s is followed by a non-scope argument (i.e. a username), we insert "target"
 // as the default value so the username isn't consumed as the flag value.
@@ -94,16 +207,56 @@ func findTarget() (string, error) {
 	// known flags that take values
 	flagsWithValues := map[string]bool{
 		"-t": true, "--token": true,
-		"--token-file": true,
+		"--token-file": true, "--tokens-file": true,
+		"--app-id": true, "--installation-id": true, "--private-key": true,
 		"-P": true, "--proxy": true,
-		"--proxy-file": true,
-		"--depth":          true,
-		"--min-repos":      true,
-		"--min-followers":  true,
-		"--max-nodes":      true,
-		"--spider-output":  true,
-		"--platform":       true,
-		"-s": true, "--secrets": true,
+		"--proxy-file": true, "--proxies-file": true,
+		"--depth":                true,
+		"--min-repos":            true,
+		"--min-followers":        true,
+		"--max-nodes":            true,
+		"--spider-output":        true,
+		"--committer-pages":      true,
+		"--graph-format":         true,
+		"--bot-logins":           true,
+		"--platform":             true,
+		"--provider":             true,
+		"--local":                true,
+		"--patches-dir":          true,
+		"--output-dir":           true,
+		"--json-out":             true,
+		"--csv-out":              true,
+		"--output-file":          true,
+		"--repo-concurrency":     true,
+		"--threads":              true,
+		"--holidays":             true,
+		"--commit-concurrency":   true,
+		"--gist-concurrency":     true,
+		"--gist-retries":         true,
+		"--max-api-calls":        true,
+		"--max-wait":             true,
+		"--timeout":              true,
+		"--spoof-repo-prefix":    true,
+		"--spoof-sync-wait":      true,
+		"--by-id":                true,
+		"--top":                  true,
+		"--hash-length":          true,
+		"--min-entropy":          true,
+		"--patterns":             true,
+		"--baseline":             true,
+		"--since":                true,
+		"--until":                true,
+		"--cache-ttl":            true,
+		"--max-repos":            true,
+		"--max-commits-per-repo": true,
+		"--max-names":            true,
+		"--sort":                 true,
+		"--filter-domain":        true,
+		"--exclude-domain":       true,
+		"--branch":               true,
+		"--repo-type":            true,
+		"-s":                     true, "--secrets": true,
+		"-o": true, "--output-format": true,
 	}
 
 	for i := 0; i < len(args); i++ {
@@ -133,9 +286,68 @@ func findTarget() (string, error) {
 	return targets[0], nil
 }
 
+// ParseSpiderCommand builds the config for `gitslurp spider <username>`.
+// Authentication and output flags given before the subcommand still apply.
+func ParseSpiderCommand(c *cli.Context) (*AppConfig, error) {
+	if c.NArg() != 1 {
+		return nil, cli.Exit("Error: spider takes exactly one username", 1)
+	}
+
+	graphFormat := strings.ToLower(strings.TrimSpace(c.String("graph-format")))
+	if !slices.Contains(spider.GraphFormats, graphFormat) {
+		return nil, fmt.Errorf("unknown --graph-format %q (valid: %s)", c.String("graph-format"), strings.Join(spider.GraphFormats, ", "))
+	}
+
+	return &AppConfig{
+		SpiderMode:     true,
+		SpiderDepth:    c.Int("depth"),
+		MinRepos:       c.Int("min-repos"),
+		MinFollowers:   c.Int("min-followers"),
+		MaxNodes:       c.Int("max-nodes"),
+		SpiderOutput:   c.String("output"),
+		SpiderWorkers:  c.Int("workers"),
+		CommitterPages: c.Int("committer-pages"),
+		GraphFormat:    graphFormat,
+		CollapseEdges:  c.Bool("collapse-edges"),
+		SkipBots:       c.Bool("no-bots"),
+		BotLogins:      splitList(c.String("bot-logins")),
+		Resume:         c.Bool("resume"),
+
+		OutputDir:       c.String("output-dir"),
+		OutputFormat:    "text",
+		Quiet:           c.Bool("quiet"),
+		MaxAPICalls:     c.Int64("max-api-calls"),
+		WaitOnRateLimit: c.Bool("wait") || c.Duration("max-wait") > 0,
+		MaxWait:         c.Duration("max-wait"),
+		Timeout:         c.Duration("timeout"),
+		Target:          c.Args().First(),
+		Platform:        "github",
+		Token:           c.String("token"),
+
+		TokenFile: c.String("token-file"),
+		Proxy:     c.String("proxy"),
+		ProxyFile: c.String("proxy-file"),
+
+		AppID:          c.Int64("app-id"),
+		InstallationID: c.Int64("installation-id"),
+		PrivateKey:     c.String("private-key"),
+	}, nil
+}
+
 func ParseConfig(c *cli.Context) (*AppConfig, error) {
 	target, err := findTarget()
-	if err != nil {
+	if id := c.Int64("by-id"); id != 0 {
+		switch {
+		case id < 0:
+			return nil, fmt.Errorf("--by-id must be a positive user ID, got %d", id)
+		case target != "":
+			return nil, fmt.Errorf("--by-id replaces the username argument; give one or the other")
+		case !strings.EqualFold(c.String("platform"), "github") || c.String("local") != "":
+			return nil, fmt.Errorf("--by-id only works with GitHub targets")
+		}
+		err = nil
+	}
+	if err != nil && c.String("local") == "" {
 		if len(os.Args) <= 1 {
 			return nil, cli.ShowAppHelp(c)
 		}
@@ -147,6 +359,36 @@ func ParseConfig(c *cli.Context) (*AppConfig, error) {
 		outputFormat = "json"
 	} else if c.Bool("csv") {
 		outputFormat = "csv"
+	} else if c.Bool("markdown") {
+		outputFormat = "markdown"
+	}
+	if format := strings.ToLower(strings.TrimSpace(c.String("output-format"))); format != "" {
+		if !slices.Contains(OutputFormats, format) {
+			return nil, fmt.Errorf("unknown --output-format %q (valid: %s)", c.String("output-format"), strings.Join(OutputFormats, ", "))
+		}
+		if format == "ndjson" {
+			format = "json"
+		}
+		if outputFormat != "text" && outputFormat != format {
+			return nil, fmt.Errorf("--output-format %s conflicts with --%s", format, outputFormat)
+		}
+		outputFormat = format
+	}
+
+	if c.String("output-file") != "" && outputFormat == "text" {
+		return nil, fmt.Errorf("--output-file needs --json, --csv or --markdown")
+	}
+
+	graphFormat := strings.ToLower(strings.TrimSpace(c.String("graph-format")))
+	if !slices.Contains(spider.GraphFormats, graphFormat) {
+		return nil, fmt.Errorf("unknown --graph-format %q (valid: %s)", c.String("graph-format"), strings.Join(spider.GraphFormats, ", "))
+	}
+
+	sortBy := strings.ToLower(strings.TrimSpace(c.String("sort")))
+	switch sortBy {
+	case "commits", "email", "name", "recent":
+	default:
+		return nil, fmt.Errorf("unknown --sort %q (valid: commits, email, name, recent)", c.String("sort"))
 	}
 
 	secretsVal := c.String("secrets")
@@ -163,34 +405,190 @@ func ParseConfig(c *cli.Context) (*AppConfig, error) {
 		return nil, fmt.Errorf("unsupported platform: %q (valid: github, gitlab, codeberg)", platformVal)
 	}
 
+	var customPatterns []scanner.CustomPattern
+	if path := c.String("patterns"); path != "" {
+		customPatterns, err = scanner.LoadPatterns(path)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	since, err := parseDateFlag("since", c.String("since"), false)
+	if err != nil {
+		return nil, err
+	}
+	until, err := parseDateFlag("until", c.String("until"), true)
+	if err != nil {
+		return nil, err
+	}
+	if !since.IsZero() && !until.IsZero() && !since.Before(until) {
+		return nil, fmt.Errorf("--since (%s) must be before --until (%s)", c.String("since"), c.String("until"))
+	}
+
+	holidays, err := utils.ParseHolidayCountry(c.String("holidays"))
+	if err != nil {
+		return nil, err
+	}
+
+	if n := c.Int("repo-concurrency"); n < 1 || n > MaxRepoConcurrency {
+		return nil, fmt.Errorf("--repo-concurrency/--threads must be between 1 and %d, got %d", MaxRepoConcurrency, n)
+	}
+
+	if prefix := c.String("spoof-repo-prefix"); !spoofPrefixPattern.MatchString(prefix) {
+		return nil, fmt.Errorf("--spoof-repo-prefix %q must contain only letters, digits, '.', '-' and '_'", prefix)
+	}
+
+	if c.String("branch") != "" && c.Bool("all-branches") {
+		return nil, fmt.Errorf("--branch and --all-branches cannot be combined")
+	}
+
+	// --write-baseline replaces the file, so it is not applied on that run
+	var baseline *scanner.Baseline
+	if path := c.String("baseline"); path != "" && !c.Bool("write-baseline") {
+		baseline, err = scanner.LoadBaseline(path)
+		if err != nil {
+			return nil, err
+		}
+	} else if path == "" && c.Bool("write-baseline") {
+		return nil, fmt.Errorf("--write-baseline requires --baseline FILE")
+	}
+
+	repoType := strings.ToLower(c.String("repo-type"))
+	switch repoType {
+	case "owner", "member", "all":
+	default:
+		return nil, fmt.Errorf("unsupported repo type: %q (valid: owner, member, all)", c.String("repo-type"))
+	}
+
 	return &AppConfig{
 		ShowDetails:       c.Bool("details"),
 		CheckSecrets:      checkSecrets,
 		SecretsScope:      secretsVal,
-		ShowTargetOnly:    false,
+		ShowTargetOnly:    c.Bool("show-target-only"),
 		ShowInteresting:   c.Bool("interesting"),
+		MinEntropy:        c.Float64("min-entropy"),
+		CustomPatterns:    customPatterns,
+		VerifySecrets:     c.Bool("verify-secrets"),
+		Redact:            c.Bool("redact"),
+		Baseline:          c.String("baseline"),
+		BaselineFindings:  baseline,
+		WriteBaseline:     c.Bool("write-baseline"),
+		IncludePatches:    c.Bool("include-patches") || c.String("patches-dir") != "",
+		PatchesDir:        c.String("patches-dir"),
+		ScanVendored:      c.Bool("scan-vendored"),
+		OutputDir:         c.String("output-dir"),
 		ProfileOnly:       c.Bool("profile-only"),
 		ShowStargazers:    c.Bool("show-stargazers"),
 		ShowForkers:       c.Bool("show-forkers"),
 		QuickMode:         c.Bool("quick"),
+		Deep:              c.Bool("deep"),
 		TimestampAnalysis: c.Bool("timestamp-analysis"),
+		Holidays:          holidays,
 		IncludeForks:      c.Bool("include-forks"),
+		IncludeAnonymous:  c.Bool("include-anonymous"),
+		IncludeCommitters: c.Bool("include-committers"),
+		NoGists:           c.Bool("no-gists"),
+		ScanIssues:        c.Bool("scan-issues"),
+		FindLinks:         c.Bool("links"),
+		ResolveLinks:      c.Bool("resolve-links"),
+		StrictOrgDomain:   c.Bool("strict-org-domain"),
+		ExcludeMerges:     c.Bool("exclude-merges"),
+		Since:             since,
+		Until:             until,
+		CacheDir:          cacheDir(c.Bool("no-cache")),
+		CacheTTL:          c.Duration("cache-ttl"),
+		GraphQL:           c.Bool("graphql"),
+		MaxRepos:          c.Int("max-repos"),
+		MaxCommitsPerRepo: c.Int("max-commits-per-repo"),
+		Branch:            c.String("branch"),
+		AllBranches:       c.Bool("all-branches"),
+		ForkNetwork:       c.Bool("fork-network"),
+		OrgMembers:        c.Bool("org-members"),
+		IncludePrivate:    c.Bool("include-private"),
+		RepoType:          repoType,
+		AssumeYes:         c.Bool("yes"),
+		SpoofRepoPrefix:   c.String("spoof-repo-prefix"),
+		SpoofSyncWait:     c.Duration("spoof-sync-wait"),
+		TopContributors:   c.Int("top"),
+		HashLength:        c.Int("hash-length"),
+		MaxNames:          c.Int("max-names"),
+		SortBy:            sortBy,
+		FilterDomains:     parseDomainList(c.String("filter-domain")),
+		ExcludeDomains:    parseDomainList(c.String("exclude-domain")),
+		HideNoreply:       c.Bool("hide-noreply"),
+
+		RepoConcurrency:   c.Int("repo-concurrency"),
+		CommitConcurrency: c.Int("commit-concurrency"),
+		GistConcurrency:   c.Int("gist-concurrency"),
+		GistRetries:       c.Int("gist-retries"),
+		MaxAPICalls:       c.Int64("max-api-calls"),
+		WaitOnRateLimit:   c.Bool("wait") || c.Duration("max-wait") > 0,
+		MaxWait:           c.Duration("max-wait"),
+		Timeout:           c.Duration("timeout"),
 
-		SpiderMode:   c.Bool("spider"),
-		SpiderDepth:  c.Int("depth"),
-		MinRepos:     c.Int("min-repos"),
-		MinFollowers: c.Int("min-followers"),
-		MaxNodes:     c.Int("max-nodes"),
-		SpiderOutput: c.String("spider-output"),
+		SpiderMode:     c.Bool("spider"),
+		SpiderDepth:    c.Int("depth"),
+		MinRepos:       c.Int("min-repos"),
+		MinFollowers:   c.Int("min-followers"),
+		MaxNodes:       c.Int("max-nodes"),
+		SpiderOutput:   c.String("spider-output"),
+		CommitterPages: c.Int("committer-pages"),
+		GraphFormat:    graphFormat,
+		CollapseEdges:  c.Bool("collapse-edges"),
+		SkipBots:       c.Bool("no-bots"),
+		BotLogins:      splitList(c.String("bot-logins")),
+		Resume:         c.Bool("resume"),
 
 		OutputFormat: outputFormat,
+		JSONOut:      c.String("json-out"),
+		CSVOut:       c.String("csv-out"),
+		OutputFile:   c.String("output-file"),
+		Quiet:        c.Bool("quiet"),
 		Target:       target,
+		ByID:         c.Int64("by-id"),
 
 		Platform:  c.String("platform"),
+		LocalPath: c.String("local"),
 		Token:     c.String("token"),
 
 		TokenFile: c.String("token-file"),
 		Proxy:     c.String("proxy"),
 		ProxyFile: c.String("proxy-file"),
+
+		AppID:          c.Int64("app-id"),
+		InstallationID: c.Int64("installation-id"),
+		PrivateKey:     c.String("private-key"),
 	}, nil
 }
+
+// parseDateFlag reads a --since/--until value as YYYY-MM-DD (UTC) or RFC 3339.
+// A bare --until date covers that whole day, so the bound is the next
+// midnight.
+func parseDateFlag(name, value string, endOfDay bool) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		if endOfDay {
+			t = t.AddDate(0, 0, 1)
+		}
+		return t, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid --%s date %q: use YYYY-MM-DD or RFC 3339 (e.g. 2023-01-01T00:00:00Z)", name, value)
+}
+
+// cacheDir is where fetched commits are cached, or "" when caching is off or
+// the platform has no user cache directory.
+func cacheDir(disabled bool) string {
+	if disabled {
+		return ""
+	}
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "gitslurp")
+}
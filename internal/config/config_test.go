@@ -0,0 +1,93 @@
+package config
+
+import (
+	"os"
+	"testing"
+
+	appcli "github.com/gnomegl/gitslurp/v2/internal/cli"
+	"github.com/gnomegl/gitslurp/v2/internal/status"
+	"github.com/urfave/cli/v2"
+)
+
+// TestFindTargetSkipsFlagValues checks every value-taking flag of the main
+// command, so a flag missing from flagsWithValues shows up here rather than
+// as its value being taken for the target.
+func TestFindTargetSkipsFlagValues(t *testing.T) {
+	status.Quiet = true
+	defer func() { status.Quiet = false }()
+	oldArgs := os.Args
+	defer func() { os.Args = oldArgs }()
+
+	for _, flag := range appcli.NewApp(nil, nil).Flags {
+		if _, ok := flag.(*cli.BoolFlag); ok {
+			continue
+		}
+		for _, name := range flag.Names() {
+			arg := "--" + name
+			if len(name) == 1 {
+				arg = "-" + name
+			}
+			t.Run(arg, func(t *testing.T) {
+				os.Args = []string{"gitslurp", arg, "value", "octocat"}
+				target, err := findTarget()
+				if err != nil {
+					t.Fatal(err)
+				}
+				if target != "octocat" {
+					t.Errorf("target = %q, want octocat", target)
+				}
+			})
+		}
+	}
+}
+
+// parseArgs runs the main command on args and returns its parsed config.
+func parseArgs(t *testing.T, args ...string) (*AppConfig, error) {
+	t.Helper()
+	status.Quiet = true
+	defer func() { status.Quiet = false }()
+	oldArgs := os.Args
+	defer func() { os.Args = oldArgs }()
+	os.Args = append([]string{"gitslurp"}, args...)
+
+	var cfg *AppConfig
+	var parseErr error
+	app := appcli.NewApp(func(c *cli.Context) error {
+		cfg, parseErr = ParseConfig(c)
+		return nil
+	}, nil)
+	if err := app.Run(os.Args); err != nil {
+		t.Fatal(err)
+	}
+	return cfg, parseErr
+}
+
+func TestRepoConcurrencyFlag(t *testing.T) {
+	tests := []struct {
+		args []string
+		want int // 0 when the value is rejected
+	}{
+		{[]string{"octocat"}, 3},
+		{[]string{"--threads", "8", "octocat"}, 8},
+		{[]string{"--repo-concurrency", "16", "octocat"}, 16},
+		{[]string{"--threads", "32", "octocat"}, 32},
+		{[]string{"--threads", "0", "octocat"}, 0},
+		{[]string{"--threads", "33", "octocat"}, 0},
+	}
+	for _, tt := range tests {
+		cfg, err := parseArgs(t, tt.args...)
+		if tt.want == 0 {
+			if err == nil {
+				t.Errorf("%v accepted, want a range error", tt.args)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%v: %v", tt.args, err)
+			continue
+		}
+		if cfg.RepoConcurrency != tt.want {
+			t.Errorf("%v: RepoConcurrency = %d, want %d", tt.args, cfg.RepoConcurrency, tt.want)
+		}
+	}
+}
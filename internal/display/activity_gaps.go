@@ -0,0 +1,68 @@
+package display
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/gnomegl/gitslurp/v2/internal/models"
+)
+
+// activityGapThreshold is the shortest stretch without target commits that is
+// reported as dormancy.
+const activityGapThreshold = 90 * 24 * time.Hour
+
+// ActivityGap is a dormant period between two consecutive target commits.
+type ActivityGap struct {
+	From time.Time // last commit before the gap
+	To   time.Time // first commit after it
+}
+
+func (g ActivityGap) Days() int {
+	return int(g.To.Sub(g.From).Hours() / 24)
+}
+
+// findActivityGaps walks the target's commits in AuthorDate order and returns
+// every gap longer than activityGapThreshold, oldest first, along with the
+// first and last commit dates.
+func findActivityGaps(emails map[string]*models.EmailDetails, userIdentifiers map[string]bool) (first, last time.Time, gaps []ActivityGap) {
+	var dates []time.Time
+	for email, details := range emails {
+		if !isTargetIdentity(email, details, userIdentifiers) {
+			continue
+		}
+		for _, commits := range details.Commits {
+			for _, commit := range commits {
+				if !commit.AuthorDate.IsZero() {
+					dates = append(dates, commit.AuthorDate.UTC())
+				}
+			}
+		}
+	}
+	if len(dates) == 0 {
+		return first, last, nil
+	}
+
+	sort.Slice(dates, func(i, j int) bool { return dates[i].Before(dates[j]) })
+	for i := 1; i < len(dates); i++ {
+		if dates[i].Sub(dates[i-1]) > activityGapThreshold {
+			gaps = append(gaps, ActivityGap{From: dates[i-1], To: dates[i]})
+		}
+	}
+	return dates[0], dates[len(dates)-1], gaps
+}
+
+func displayActivityGaps(emails map[string]*models.EmailDetails, userIdentifiers map[string]bool) {
+	first, last, gaps := findActivityGaps(emails, userIdentifiers)
+	if len(gaps) == 0 {
+		return
+	}
+
+	fmt.Println()
+	fmt.Printf("%s %s to %s, %d inactive periods longer than %d days\n", color.WhiteString("Activity gaps:"),
+		first.Format("2006-01-02"), last.Format("2006-01-02"), len(gaps), int(activityGapThreshold.Hours()/24))
+	for _, g := range gaps {
+		color.Yellow("  %s -> %s (%s days dormant)", g.From.Format("2006-01-02"), g.To.Format("2006-01-02"), formatCount(g.Days()))
+	}
+}
@@ -0,0 +1,210 @@
+package display
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/fatih/color"
+	"github.com/gnomegl/gitslurp/v2/internal/github"
+	"github.com/gnomegl/gitslurp/v2/internal/models"
+)
+
+const (
+	// names at least this similar (1 - edit distance / length) count
+	minNameSimilarity = 0.75
+	// shared local-part prefixes shorter than this are too common to count
+	minLocalPrefix = 4
+	// candidates below this confidence are not shown
+	minAlternateConfidence = 0.4
+	maxAlternatesShown     = 10
+)
+
+// AlternateAccount is a non-target email that looks like the target's.
+type AlternateAccount struct {
+	Email      string
+	Confidence float64 // 0 to 1
+	Reasons    []string
+}
+
+// findAlternateAccounts scores the emails not attributed to the target by
+// how close their names are to the target's, how long a prefix their local
+// part shares with the target's addresses and login, and how many of their
+// repositories the target also committed to. Shared repositories only add
+// to a name or local-part match; on their own they describe every
+// collaborator.
+func findAlternateAccounts(ctx *Context, emails map[string]*models.EmailDetails) []AlternateAccount {
+	if ctx.IsOrg {
+		return nil
+	}
+
+	targetRepos := make(map[string]bool)
+	targetNames := make(map[string]string) // normalized -> as written
+	targetLocals := make(map[string]bool)
+	if login := foldName(ctx.KnownUsername); login != "" {
+		targetLocals[login] = true
+	}
+	if ctx.User != nil && ctx.User.GetName() != "" {
+		targetNames[foldName(ctx.User.GetName())] = ctx.User.GetName()
+	}
+	for email, details := range emails {
+		if !isTargetIdentity(email, details, ctx.UserIdentifiers) {
+			continue
+		}
+		for repo := range details.Commits {
+			targetRepos[repo] = true
+		}
+		for name := range details.Names {
+			if n := foldName(name); n != "" {
+				targetNames[n] = name
+			}
+		}
+		if local := foldName(emailLocalPart(email)); local != "" {
+			targetLocals[local] = true
+		}
+	}
+	if len(targetNames) == 0 && len(targetLocals) == 0 {
+		return nil
+	}
+
+	var alternates []AlternateAccount
+	for email, details := range emails {
+		if isTargetIdentity(email, details, ctx.UserIdentifiers) || machineAccountReason(email, details) != "" {
+			continue
+		}
+
+		var reasons []string
+		nameScore := 0.0
+		for name := range details.Names {
+			n := foldName(name)
+			if utf8.RuneCountInString(n) < minLocalPrefix {
+				continue
+			}
+			for tn, written := range targetNames {
+				if s := similarity(n, tn); s >= minNameSimilarity && s > nameScore {
+					nameScore = s
+					reasons = append(reasons[:0], fmt.Sprintf("name %q resembles %q", name, written))
+				}
+			}
+		}
+
+		prefixScore := 0.0
+		local := foldName(emailLocalPart(email))
+		for tl := range targetLocals {
+			n := commonPrefixLen(local, tl)
+			shorter := min(utf8.RuneCountInString(local), utf8.RuneCountInString(tl))
+			if n < minLocalPrefix || 2*n < shorter {
+				continue
+			}
+			if s := float64(n) / float64(shorter); s > prefixScore {
+				prefixScore = s
+			}
+		}
+		if prefixScore > 0 {
+			reasons = append(reasons, fmt.Sprintf("local part resembles the target's (%.0f%% shared prefix)", prefixScore*100))
+		}
+		if nameScore == 0 && prefixScore == 0 {
+			continue
+		}
+
+		repoScore := 0.0
+		shared := 0
+		for repo := range details.Commits {
+			if targetRepos[repo] {
+				shared++
+			}
+		}
+		if len(details.Commits) > 0 && shared > 0 {
+			repoScore = float64(shared) / float64(len(details.Commits))
+			reasons = append(reasons, fmt.Sprintf("%d of %d repositories shared with the target", shared, len(details.Commits)))
+		}
+
+		confidence := 0.45*nameScore + 0.35*prefixScore + 0.2*repoScore
+		if confidence < minAlternateConfidence {
+			continue
+		}
+		alternates = append(alternates, AlternateAccount{Email: email, Confidence: confidence, Reasons: reasons})
+	}
+
+	sort.Slice(alternates, func(i, j int) bool {
+		if alternates[i].Confidence != alternates[j].Confidence {
+			return alternates[i].Confidence > alternates[j].Confidence
+		}
+		return alternates[i].Email < alternates[j].Email
+	})
+	return alternates
+}
+
+// emailLocalPart returns the part before the @, without a +tag. For GitHub
+// noreply addresses it is the login.
+func emailLocalPart(email string) string {
+	if login, _, ok := github.ResolveNoreplyEmail(email); ok {
+		return login
+	}
+	local, _, _ := strings.Cut(email, "@")
+	local, _, _ = strings.Cut(local, "+")
+	return local
+}
+
+// similarity is 1 minus the edit distance over the longer length.
+func similarity(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	longer := max(len(ra), len(rb))
+	if longer == 0 {
+		return 0
+	}
+	return 1 - float64(levenshtein(ra, rb))/float64(longer)
+}
+
+func levenshtein(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(min(prev[j]+1, cur[j-1]+1), prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
+
+func commonPrefixLen(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	n := 0
+	for n < len(ra) && n < len(rb) && ra[n] == rb[n] {
+		n++
+	}
+	return n
+}
+
+func displayAlternateAccounts(ctx *Context) {
+	alternates := findAlternateAccounts(ctx, ctx.Emails)
+	if len(alternates) == 0 {
+		return
+	}
+
+	fmt.Println()
+	headerColor.Print("POSSIBLE ALTERNATE ACCOUNTS")
+	fmt.Println(" (not attributed to the target, but similar)")
+	fmt.Println(strings.Repeat("-", 60))
+
+	for i, a := range alternates {
+		if i >= maxAlternatesShown {
+			fmt.Printf("... and %s more\n", formatCount(len(alternates)-maxAlternatesShown))
+			break
+		}
+		color.Yellow("%3.0f%%  %s", a.Confidence*100, a.Email)
+		for _, reason := range a.Reasons {
+			fmt.Printf("      %s\n", reason)
+		}
+	}
+}
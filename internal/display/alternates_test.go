@@ -0,0 +1,86 @@
+package display
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/gnomegl/gitslurp/v2/internal/models"
+)
+
+func TestFindAlternateAccounts(t *testing.T) {
+	person := func(name string, repos ...string) *models.EmailDetails {
+		d := &models.EmailDetails{Names: map[string]struct{}{name: {}}, Commits: make(map[string][]models.CommitInfo)}
+		for _, repo := range repos {
+			d.Commits[repo] = []models.CommitInfo{{RepoName: repo}}
+			d.CommitCount++
+		}
+		return d
+	}
+
+	tests := []struct {
+		name       string
+		email      string
+		details    *models.EmailDetails
+		confidence float64 // 0 when not reported
+	}{
+		{"same name and a shared repository", "jane.doe@gmail.com", person("Jane Doe", "jdoe/a"), 0.65},
+		{"same name alone", "jane.doe@gmail.com", person("Jane Doe", "other/x"), 0.45},
+		{"same name with accents", "jane@proton.me", person("Jané Doé", "other/x"), 0.45},
+		{"close name and half the repositories", "jd@gmail.com", person("Jane Doh", "jdoe/a", "other/x"), 0.45*6/7 + 0.1},
+		{"close name alone", "jd@gmail.com", person("Jane Doh", "other/x"), 0},
+		{"local part prefix and a shared repository", "jdoe.personal@proton.me", person("JD", "jdoe/b"), 0.55},
+		{"noreply login prefix and a shared repository", "12+jdoe-alt@users.noreply.github.com", person("JD", "jdoe/a"), 0.55},
+		{"local part prefix alone", "jdoe.personal@proton.me", person("JD", "other/x"), 0},
+		{"short shared prefix", "jdo@proton.me", person("JD", "jdoe/a"), 0},
+		{"collaborator on the same repositories", "bob@work.com", person("Bob Smith", "jdoe/a", "jdoe/b"), 0},
+		{"machine account with the target's name", "ci@work.com", person("Jane Doe", "jdoe/a"), 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			emails := map[string]*models.EmailDetails{
+				"jdoe@work.com": person("Jane Doe", "jdoe/a", "jdoe/b"),
+				tt.email:        tt.details,
+			}
+			ctx := &Context{KnownUsername: "jdoe", UserIdentifiers: map[string]bool{"jdoe@work.com": true}}
+
+			got := findAlternateAccounts(ctx, emails)
+			if tt.confidence == 0 {
+				if len(got) != 0 {
+					t.Errorf("reported %+v, want nothing", got)
+				}
+				return
+			}
+			if len(got) != 1 || got[0].Email != tt.email {
+				t.Fatalf("alternates = %+v, want only %s", got, tt.email)
+			}
+			if math.Abs(got[0].Confidence-tt.confidence) > 1e-9 {
+				t.Errorf("confidence = %.4f, want %.4f (%v)", got[0].Confidence, tt.confidence, got[0].Reasons)
+			}
+
+			ctx.IsOrg = true
+			if got := findAlternateAccounts(ctx, emails); got != nil {
+				t.Errorf("organization targets got alternates %+v", got)
+			}
+		})
+	}
+}
+
+func TestFindAlternateAccountsOrder(t *testing.T) {
+	emails := map[string]*models.EmailDetails{
+		"jdoe@work.com":      {Names: map[string]struct{}{"Jane Doe": {}}, Commits: map[string][]models.CommitInfo{"jdoe/a": {{}}}},
+		"jane@gmail.com":     {Names: map[string]struct{}{"Jane Doe": {}}, Commits: map[string][]models.CommitInfo{"jdoe/a": {{}}}},
+		"jane.doe@proton.me": {Names: map[string]struct{}{"Jane Doe": {}}, Commits: map[string][]models.CommitInfo{"other/x": {{}}}},
+		"doe@yahoo.com":      {Names: map[string]struct{}{"Jane Doe": {}}, Commits: map[string][]models.CommitInfo{"other/y": {{}}}},
+	}
+	ctx := &Context{KnownUsername: "jdoe", UserIdentifiers: map[string]bool{"jdoe@work.com": true}}
+
+	var got []string
+	for _, a := range findAlternateAccounts(ctx, emails) {
+		got = append(got, a.Email)
+	}
+	want := []string{"jane@gmail.com", "doe@yahoo.com", "jane.doe@proton.me"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("alternates = %v, want %v (by confidence, then email)", got, want)
+	}
+}
@@ -0,0 +1,108 @@
+package display
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/gnomegl/gitslurp/v2/internal/github"
+	"github.com/gnomegl/gitslurp/v2/internal/models"
+	gh "github.com/google/go-github/v57/github"
+)
+
+const noreplyDomain = "@users.noreply.github.com"
+
+type AuthorshipMismatch struct {
+	Repo          string
+	Hash          string
+	URL           string
+	ClaimedEmail  string
+	ResolvedLogin string
+	Reason        string
+}
+
+// findAuthorshipMismatches flags commits whose git author email disagrees
+// with the account GitHub attributed them to. A noreply address naming a
+// different login is always suspect. When the target's own verified emails
+// are known, commits attributed to the target from any other address are
+// flagged too.
+func findAuthorshipMismatches(emails map[string]*models.EmailDetails, targetLogin string, accountEmails []*gh.UserEmail) []AuthorshipMismatch {
+	verified := make(map[string]bool)
+	for _, e := range accountEmails {
+		if e.GetVerified() {
+			verified[strings.ToLower(e.GetEmail())] = true
+		}
+	}
+
+	var mismatches []AuthorshipMismatch
+	for _, details := range emails {
+		for repoName, commits := range details.Commits {
+			for _, commit := range commits {
+				if commit.AuthorLogin == "" || commit.AuthorEmail == "" {
+					continue
+				}
+
+				reason := ""
+				if implied, _, _ := github.ResolveNoreplyEmail(commit.AuthorEmail); implied != "" && !strings.EqualFold(implied, commit.AuthorLogin) {
+					reason = fmt.Sprintf("noreply address belongs to %s", implied)
+				} else if len(verified) > 0 && strings.EqualFold(commit.AuthorLogin, targetLogin) &&
+					implied == "" && !verified[strings.ToLower(commit.AuthorEmail)] {
+					reason = fmt.Sprintf("email is not a verified address of %s", targetLogin)
+				}
+				if reason == "" {
+					continue
+				}
+
+				mismatches = append(mismatches, AuthorshipMismatch{
+					Repo:          repoLabel(repoName, commits),
+					Hash:          commit.Hash,
+					URL:           commit.URL,
+					ClaimedEmail:  commit.AuthorEmail,
+					ResolvedLogin: commit.AuthorLogin,
+					Reason:        reason,
+				})
+			}
+		}
+	}
+
+	sort.Slice(mismatches, func(i, j int) bool {
+		if mismatches[i].Repo != mismatches[j].Repo {
+			return mismatches[i].Repo < mismatches[j].Repo
+		}
+		return mismatches[i].Hash < mismatches[j].Hash
+	})
+	return mismatches
+}
+
+func targetLoginOf(ctx *Context) string {
+	if ctx.User != nil && ctx.User.GetLogin() != "" {
+		return ctx.User.GetLogin()
+	}
+	return ctx.KnownUsername
+}
+
+func displayAuthorshipMismatches(ctx *Context) {
+	mismatches := findAuthorshipMismatches(ctx.Emails, targetLoginOf(ctx), ctx.AccountEmails)
+	if len(mismatches) == 0 {
+		return
+	}
+
+	fmt.Println()
+	headerColor.Print("AUTHORSHIP MISMATCHES")
+	fmt.Println(" (possible spoofed commits)")
+	fmt.Println(strings.Repeat("-", 60))
+
+	for i, m := range mismatches {
+		if i >= 20 {
+			fmt.Printf("... and %s more\n", formatCount(len(mismatches)-20))
+			break
+		}
+		color.Red("%s %s", shortHash(m.Hash, ctx.Cfg.HashLength), m.Repo)
+		fmt.Printf("  Claimed email: %s\n", m.ClaimedEmail)
+		fmt.Printf("  Resolved account: %s (%s)\n", m.ResolvedLogin, m.Reason)
+		if m.URL != "" {
+			fmt.Printf("  %s\n", m.URL)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package display
+
+import (
+	"github.com/gnomegl/gitslurp/v2/internal/models"
+	"github.com/gnomegl/gitslurp/v2/internal/scanner"
+)
+
+// BaselineFindings collects every finding in emails as baseline entries for
+// --write-baseline, once per commit and fingerprint.
+func BaselineFindings(emails map[string]*models.EmailDetails) *scanner.Baseline {
+	b := &scanner.Baseline{}
+	seen := make(map[string]bool)
+
+	for _, details := range emails {
+		for _, commits := range details.Commits {
+			for _, commit := range commits {
+				if commit.Hash == "" {
+					continue
+				}
+				for _, finding := range commit.Secrets {
+					fingerprint := scanner.Fingerprint(finding.Name, finding.Value)
+					if seen[commit.Hash+fingerprint] {
+						continue
+					}
+					seen[commit.Hash+fingerprint] = true
+
+					b.Findings = append(b.Findings, scanner.BaselineEntry{
+						Commit:      commit.Hash,
+						Fingerprint: fingerprint,
+						Name:        finding.Name,
+						Location:    finding.Location,
+					})
+				}
+			}
+		}
+	}
+
+	return b
+}
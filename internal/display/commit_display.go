@@ -21,7 +21,7 @@ func (cd *CommitDisplayer) DisplayForEntry(entry EmailEntry, isTargetUser bool)
 			continue
 		}
 
-		color.Cyan("  %s", repoName)
+		color.Cyan("  %s", repoLabel(repoName, commits))
 
 		shown := 0
 		for i := range commits {
@@ -38,7 +38,7 @@ func (cd *CommitDisplayer) DisplayForEntry(entry EmailEntry, isTargetUser bool)
 				break
 			}
 
-			fmt.Printf("    %s %s\n", commit.Hash[:min(8, len(commit.Hash))], commit.AuthorDate.Format("2006-01-02 15:04"))
+			fmt.Printf("    %s %s\n", shortHash(commit.Hash, cd.ctx.Cfg.HashLength), commit.AuthorDate.Format("2006-01-02 15:04"))
 
 			if cd.ctx.ShowDetails {
 				msg := commit.Message
@@ -56,6 +56,11 @@ func (cd *CommitDisplayer) DisplayForEntry(entry EmailEntry, isTargetUser bool)
 			if len(commit.Secrets) > 0 {
 				cd.displaySecrets(commit.Secrets)
 			}
+			if cd.ctx.Cfg.FindLinks {
+				for _, link := range commit.Links {
+					color.Blue("      LINK: %s", link)
+				}
+			}
 
 			shown++
 		}
@@ -73,7 +78,7 @@ func (cd *CommitDisplayer) shouldShowRepo(commits []models.CommitInfo) bool {
 	}
 
 	for _, commit := range commits {
-		if len(commit.Secrets) > 0 && (cd.ctx.CheckSecrets || cd.ctx.Cfg.ShowInteresting) {
+		if cd.shouldShowCommit(commit) {
 			return true
 		}
 	}
@@ -83,15 +88,16 @@ func (cd *CommitDisplayer) shouldShowRepo(commits []models.CommitInfo) bool {
 
 func (cd *CommitDisplayer) shouldShowCommit(commit models.CommitInfo) bool {
 	return cd.ctx.ShowDetails ||
-		(len(commit.Secrets) > 0 && (cd.ctx.CheckSecrets || cd.ctx.Cfg.ShowInteresting))
+		(len(commit.Secrets) > 0 && (cd.ctx.CheckSecrets || cd.ctx.Cfg.ShowInteresting)) ||
+		(len(commit.Links) > 0 && cd.ctx.Cfg.FindLinks)
 }
 
-func (cd *CommitDisplayer) displaySecrets(secrets []string) {
+func (cd *CommitDisplayer) displaySecrets(secrets []models.SecretFinding) {
 	for _, secret := range secrets {
-		if secret == "" {
+		if secret.Name == "" && secret.Value == "" {
 			continue
 		}
-		displaySecretLine(secret)
+		displaySecretLine(secret, cd.ctx.Cfg.Redact)
 	}
 }
 
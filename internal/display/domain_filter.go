@@ -0,0 +1,59 @@
+package display
+
+import (
+	"strings"
+
+	"github.com/gnomegl/gitslurp/v2/internal/github"
+	"github.com/gnomegl/gitslurp/v2/internal/models"
+)
+
+// filterEmails drops the emails --filter-domain leaves out and those
+// --exclude-domain or --hide-noreply remove, so they do not show up in any
+// output or count. It returns emails as is when no filter is set.
+func filterEmails(emails map[string]*models.EmailDetails, cfg *github.Config) map[string]*models.EmailDetails {
+	if !hasEmailFilter(cfg) {
+		return emails
+	}
+
+	kept := make(map[string]*models.EmailDetails, len(emails))
+	for email, details := range emails {
+		if keepEmail(email, cfg) {
+			kept[email] = details
+		}
+	}
+	return kept
+}
+
+func hasEmailFilter(cfg *github.Config) bool {
+	return cfg != nil && (len(cfg.FilterDomains) > 0 || len(cfg.ExcludeDomains) > 0 || cfg.HideNoreply)
+}
+
+func keepEmail(email string, cfg *github.Config) bool {
+	if !hasEmailFilter(cfg) {
+		return true
+	}
+	if cfg.HideNoreply && isNoreplyEmail(email) {
+		return false
+	}
+	if inDomains(email, cfg.ExcludeDomains) {
+		return false
+	}
+	return len(cfg.FilterDomains) == 0 || inDomains(email, cfg.FilterDomains)
+}
+
+// isNoreplyEmail matches GitHub's per-user noreply addresses and the address
+// it commits web edits and merges with.
+func isNoreplyEmail(email string) bool {
+	email = strings.ToLower(email)
+	return email == "noreply@github.com" || strings.HasSuffix(email, noreplyDomain)
+}
+
+// inDomains reports whether email is on one of domains or a subdomain of it.
+func inDomains(email string, domains []string) bool {
+	for _, domain := range domains {
+		if isOrganizationEmail(email, domain, true) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,112 @@
+package display
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/gnomegl/gitslurp/v2/internal/github"
+	"github.com/gnomegl/gitslurp/v2/internal/models"
+)
+
+// jsonResults runs Results with JSON output and returns the reported
+// contributor count and the emails of the email records, sorted.
+func jsonResults(t *testing.T, addresses []string, cfg *github.Config) (int, []string) {
+	t.Helper()
+	emails := make(map[string]*models.EmailDetails)
+	for i, email := range addresses {
+		emails[email] = &models.EmailDetails{
+			Names:       map[string]struct{}{fmt.Sprintf("Person %d", i): {}},
+			CommitCount: 1,
+			Commits:     map[string][]models.CommitInfo{"owner/repo": {{Hash: fmt.Sprint(i), AuthorEmail: email}}},
+		}
+	}
+
+	var buf bytes.Buffer
+	Results(emails, false, false, "", "octo", nil, nil, false, false, cfg, "json", &buf)
+
+	total := -1
+	var got []string
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var record struct {
+			Email             string `json:"email"`
+			TotalContributors *int   `json:"total_contributors"`
+		}
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Fatalf("bad record %q: %v", line, err)
+		}
+		switch {
+		case record.TotalContributors != nil:
+			total = *record.TotalContributors
+		case record.Email != "":
+			got = append(got, record.Email)
+		}
+	}
+	sort.Strings(got)
+	return total, got
+}
+
+func TestFilterDomain(t *testing.T) {
+	addresses := []string{"dev@acme.com", "ops@eng.acme.io", "dev@acme.de", "me@gmail.com", "x@notacme.com"}
+	tests := []struct {
+		name    string
+		domains []string
+		want    []string
+	}{
+		{"no filter", nil, []string{"dev@acme.com", "dev@acme.de", "me@gmail.com", "ops@eng.acme.io", "x@notacme.com"}},
+		{"exact domain", []string{"acme.com"}, []string{"dev@acme.com"}},
+		{"subdomains match", []string{"acme.io"}, []string{"ops@eng.acme.io"}},
+		{"several domains", []string{"acme.com", "acme.io"}, []string{"dev@acme.com", "ops@eng.acme.io"}},
+		{"case insensitive", []string{"ACME.com"}, []string{"dev@acme.com"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := github.DefaultConfig()
+			cfg.FilterDomains = tt.domains
+
+			total, got := jsonResults(t, addresses, &cfg)
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("emails = %v, want %v", got, tt.want)
+			}
+			if total != len(tt.want) {
+				t.Errorf("total_contributors = %d, want %d", total, len(tt.want))
+			}
+		})
+	}
+}
+
+func TestExcludeDomain(t *testing.T) {
+	addresses := []string{"dev@acme.com", "123+dev@users.noreply.github.com", "noreply@github.com", "ana@build.example.org", "me@gmail.com"}
+	tests := []struct {
+		name        string
+		exclude     []string
+		hideNoreply bool
+		filter      []string
+		want        []string
+	}{
+		{"nothing hidden", nil, false, nil, []string{"123+dev@users.noreply.github.com", "ana@build.example.org", "dev@acme.com", "me@gmail.com", "noreply@github.com"}},
+		{"--hide-noreply", nil, true, nil, []string{"ana@build.example.org", "dev@acme.com", "me@gmail.com"}},
+		{"excluded domain and subdomains", []string{"example.org", "gmail.com"}, false, nil, []string{"123+dev@users.noreply.github.com", "dev@acme.com", "noreply@github.com"}},
+		{"both", []string{"gmail.com"}, true, nil, []string{"ana@build.example.org", "dev@acme.com"}},
+		{"exclusion wins over --filter-domain", []string{"build.example.org"}, false, []string{"example.org", "acme.com"}, []string{"dev@acme.com"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := github.DefaultConfig()
+			cfg.ExcludeDomains = tt.exclude
+			cfg.HideNoreply = tt.hideNoreply
+			cfg.FilterDomains = tt.filter
+
+			total, got := jsonResults(t, addresses, &cfg)
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("emails = %v, want %v", got, tt.want)
+			}
+			if total != len(tt.want) {
+				t.Errorf("total_contributors = %d, want %d", total, len(tt.want))
+			}
+		})
+	}
+}
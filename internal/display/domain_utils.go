@@ -25,6 +25,31 @@ func extractDomainFromWebsite(website string) string {
 	return domain
 }
 
+// public suffixes made of two labels, under which the registrable name is the
+// third label from the right
+var twoLevelTLDs = map[string]bool{
+	"co.uk": true, "ac.uk": true, "gov.uk": true, "org.uk": true, "me.uk": true, "ltd.uk": true, "plc.uk": true, "net.uk": true,
+	"co.jp": true, "ne.jp": true, "or.jp": true, "ac.jp": true, "go.jp": true,
+	"co.nz": true, "net.nz": true, "org.nz": true, "ac.nz": true, "govt.nz": true,
+	"co.za": true, "org.za": true, "ac.za": true, "gov.za": true,
+	"co.in": true, "net.in": true, "org.in": true, "ac.in": true, "gov.in": true,
+	"co.kr": true, "or.kr": true, "ac.kr": true, "go.kr": true,
+	"co.il": true, "org.il": true, "ac.il": true,
+	"co.id": true, "or.id": true, "ac.id": true,
+	"co.th": true, "ac.th": true, "in.th": true,
+	"co.ke": true, "or.ke": true,
+	"com.au": true, "net.au": true, "org.au": true, "edu.au": true, "gov.au": true,
+	"com.br": true, "net.br": true, "org.br": true, "gov.br": true,
+	"com.cn": true, "net.cn": true, "org.cn": true, "edu.cn": true, "gov.cn": true,
+	"com.mx": true, "org.mx": true, "gob.mx": true, "edu.mx": true,
+	"com.ar": true, "com.co": true, "com.pe": true, "com.ve": true, "com.uy": true,
+	"com.tr": true, "gov.tr": true, "edu.tr": true,
+	"com.sg": true, "edu.sg": true, "gov.sg": true, "com.my": true, "com.ph": true,
+	"com.hk": true, "edu.hk": true, "com.tw": true, "edu.tw": true,
+	"com.pk": true, "com.bd": true, "com.vn": true, "com.ua": true, "com.pl": true,
+	"com.eg": true, "com.sa": true, "com.ng": true, "com.gh": true,
+}
+
 func extractBaseDomain(domain string) string {
 	domain = strings.ToLower(domain)
 
@@ -33,12 +58,6 @@ func extractBaseDomain(domain string) string {
 		return domain
 	}
 
-	twoLevelTLDs := map[string]bool{
-		"co.uk": true, "co.jp": true, "co.nz": true, "co.za": true,
-		"com.au": true, "com.br": true, "com.cn": true, "com.mx": true,
-		"ac.uk": true, "gov.uk": true, "org.uk": true,
-	}
-
 	if len(parts) >= 3 {
 		lastTwo := parts[len(parts)-2] + "." + parts[len(parts)-1]
 		if twoLevelTLDs[lastTwo] {
@@ -51,7 +70,10 @@ func extractBaseDomain(domain string) string {
 	return parts[len(parts)-2]
 }
 
-func isOrganizationEmail(email, orgDomain string) bool {
+// isOrganizationEmail matches an email against the org's website domain. By
+// default any domain sharing the org's registrable name counts (acme.de for
+// acme.com); strict mode only accepts the domain itself and its subdomains.
+func isOrganizationEmail(email, orgDomain string, strict bool) bool {
 	if orgDomain == "" || email == "" {
 		return false
 	}
@@ -68,6 +90,10 @@ func isOrganizationEmail(email, orgDomain string) bool {
 		return true
 	}
 
+	if strict {
+		return strings.HasSuffix(emailDomain, "."+orgDomain)
+	}
+
 	emailBase := extractBaseDomain(emailDomain)
 	orgBase := extractBaseDomain(orgDomain)
 
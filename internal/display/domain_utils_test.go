@@ -0,0 +1,53 @@
+package display
+
+import "testing"
+
+func TestIsOrganizationEmail(t *testing.T) {
+	tests := []struct {
+		name   string
+		email  string
+		org    string
+		loose  bool
+		strict bool
+	}{
+		{"exact domain", "dev@acme.com", "acme.com", true, true},
+		{"case differs", "Dev@ACME.com", "acme.com", true, true},
+		{"subdomain", "dev@eng.acme.com", "acme.com", true, true},
+		{"other TLD, same name", "dev@acme.de", "acme.com", true, false},
+		{"country two-level TLD", "dev@acme.co.uk", "acme.com", true, false},
+		{"subdomain under a country TLD", "dev@eng.acme.com.au", "acme.com.au", true, true},
+		{"same country TLD, other name", "dev@other.co.uk", "acme.co.uk", false, false},
+		{"near miss name", "dev@acmecorp.com", "acme.com", false, false},
+		{"suffix without a dot", "dev@notacme.com", "acme.com", false, false},
+		{"no org domain", "dev@acme.com", "", false, false},
+		{"not an email", "acme.com", "acme.com", false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isOrganizationEmail(tt.email, tt.org, false); got != tt.loose {
+				t.Errorf("isOrganizationEmail(%q, %q) = %v, want %v", tt.email, tt.org, got, tt.loose)
+			}
+			if got := isOrganizationEmail(tt.email, tt.org, true); got != tt.strict {
+				t.Errorf("strict isOrganizationEmail(%q, %q) = %v, want %v", tt.email, tt.org, got, tt.strict)
+			}
+		})
+	}
+}
+
+func TestExtractBaseDomain(t *testing.T) {
+	tests := map[string]string{
+		"acme.com":         "acme",
+		"eng.acme.com":     "acme",
+		"acme.co.uk":       "acme",
+		"eng.acme.com.au":  "acme",
+		"acme.com.br":      "acme",
+		"acme.co.jp":       "acme",
+		"localhost":        "localhost",
+		"mail.example.org": "example",
+	}
+	for domain, want := range tests {
+		if got := extractBaseDomain(domain); got != want {
+			t.Errorf("extractBaseDomain(%q) = %q, want %q", domain, got, want)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package display
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// outputDOT writes a Graphviz graph of which emails committed to which
+// repositories, one edge per pair labeled with the commit count. Target
+// emails are filled, so alternate identities sharing their repositories
+// stand out when rendered.
+func outputDOT(w io.Writer, ctx *Context, matcher *UserMatcher) {
+	type edge struct {
+		email, repo string
+		commits     int
+	}
+	var edges []edge
+	repos := make(map[string]string) // key -> label
+
+	fmt.Fprintln(w, "graph gitslurp {")
+	fmt.Fprintln(w, "  rankdir=LR;")
+	fmt.Fprintln(w, "  node [fontname=\"Helvetica\", fontsize=10];")
+	fmt.Fprintln(w)
+
+	for _, entry := range sortEmails(ctx.Emails, "email") {
+		isTarget := matcher.IsTargetUser(entry.Email, entry.Details)
+		if ctx.ShowTargetOnly && !isTarget {
+			continue
+		}
+		style := ""
+		if isTarget {
+			style = ", style=filled, fillcolor=\"#f4a261\""
+		}
+		fmt.Fprintf(w, "  %s [label=%s, shape=ellipse%s];\n", dotID("email", entry.Email), dotQuote(entry.Email), style)
+
+		for repo, commits := range entry.Details.Commits {
+			if len(commits) == 0 {
+				continue
+			}
+			if _, ok := repos[repo]; !ok {
+				repos[repo] = repoLabel(repo, commits)
+			}
+			edges = append(edges, edge{entry.Email, repo, len(commits)})
+		}
+	}
+
+	names := make([]string, 0, len(repos))
+	for repo := range repos {
+		names = append(names, repo)
+	}
+	sort.Strings(names)
+	fmt.Fprintln(w)
+	for _, repo := range names {
+		fmt.Fprintf(w, "  %s [label=%s, shape=box];\n", dotID("repo", repo), dotQuote(repos[repo]))
+	}
+
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].email != edges[j].email {
+			return edges[i].email < edges[j].email
+		}
+		return edges[i].repo < edges[j].repo
+	})
+	fmt.Fprintln(w)
+	for _, e := range edges {
+		fmt.Fprintf(w, "  %s -- %s [label=\"%d\"];\n", dotID("email", e.email), dotID("repo", e.repo), e.commits)
+	}
+	fmt.Fprintln(w, "}")
+}
+
+// dotID quotes a node ID, prefixed by its kind so an email and a repository
+// never share one.
+func dotID(kind, name string) string {
+	return dotQuote(kind + ":" + name)
+}
+
+func dotQuote(s string) string {
+	return `"` + dotEscaper.Replace(s) + `"`
+}
+
+var dotEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", " ", "\r", "")
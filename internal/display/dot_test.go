@@ -0,0 +1,69 @@
+package display
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gnomegl/gitslurp/v2/internal/github"
+	"github.com/gnomegl/gitslurp/v2/internal/models"
+)
+
+func TestDOTGolden(t *testing.T) {
+	commits := func(repo string, n int) []models.CommitInfo {
+		c := make([]models.CommitInfo, n)
+		for i := range c {
+			c[i] = models.CommitInfo{RepoName: repo}
+		}
+		return c
+	}
+	emails := map[string]*models.EmailDetails{
+		"octo@example.org": {
+			Names:       map[string]struct{}{"Octo Cat": {}},
+			CommitCount: 5,
+			Commits:     map[string][]models.CommitInfo{"octo/tool": commits("Octo/Tool", 3), "octo/site": commits("octo/site", 2)},
+		},
+		"octo.cat@personal.dev": {
+			Names:       map[string]struct{}{"Octo": {}},
+			CommitCount: 1,
+			Commits:     map[string][]models.CommitInfo{"octo/tool": commits("Octo/Tool", 1)},
+		},
+		`"quoted"@example.org`: {
+			Names:       map[string]struct{}{"Quoted": {}},
+			CommitCount: 1,
+			Commits:     map[string][]models.CommitInfo{"other/lib": commits("other/lib", 1), "empty/repo": nil},
+		},
+	}
+
+	tests := []struct {
+		golden     string
+		targetOnly bool
+	}{
+		{"graph.dot", false},
+		{"graph_target_only.dot", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.golden, func(t *testing.T) {
+			cfg := github.DefaultConfig()
+			ctx := &Context{Emails: emails, KnownUsername: "octo", ShowTargetOnly: tt.targetOnly, Cfg: &cfg}
+
+			var buf bytes.Buffer
+			outputDOT(&buf, ctx, NewUserMatcher("octo", "octo@example.org", nil))
+
+			golden := filepath.Join("testdata", tt.golden)
+			if *update {
+				if err := os.WriteFile(golden, buf.Bytes(), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(buf.Bytes(), want) {
+				t.Errorf("DOT graph differs from %s (rerun with -update after checking):\n%s", golden, buf.String())
+			}
+		})
+	}
+}
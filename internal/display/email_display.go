@@ -5,6 +5,7 @@ import (
 	"io"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/gnomegl/gitslurp/v2/internal/github"
@@ -14,37 +15,76 @@ import (
 
 type ColorPrinter struct {
 	isTarget bool
+	maxNames int
 }
 
-func (cp *ColorPrinter) PrintEmail(email string, names []string, commitCount int, isTarget bool, isSimilar bool, isOrgEmployee bool) {
-	nameStr := strings.Join(names, ", ")
+func (cp *ColorPrinter) PrintEmail(email string, names []string, commitCount int, active string, isTarget bool, isSimilar bool, isOrgEmployee bool) {
+	nameStr := joinNames(names, cp.maxNames)
 
 	if isTarget {
-		color.Green("[TARGET] %s (%d commits)", email, commitCount)
+		color.Green("[TARGET] %s (%s commits%s)", email, formatCount(commitCount), active)
 		if nameStr != "" {
 			fmt.Printf("  Names: %s\n", nameStr)
 		}
 	} else if isSimilar {
-		color.Yellow("[SIMILAR] %s (%d commits)", email, commitCount)
+		color.Yellow("[SIMILAR] %s (%s commits%s)", email, formatCount(commitCount), active)
 		if nameStr != "" {
 			fmt.Printf("  Names: %s\n", nameStr)
 		}
 	} else if isOrgEmployee {
-		color.Yellow("%s (%d commits)", email, commitCount)
+		color.Yellow("%s (%s commits%s)", email, formatCount(commitCount), active)
 		if nameStr != "" {
 			fmt.Printf("  Names: %s\n", nameStr)
 		}
 	} else {
-		color.White("%s (%d commits)", email, commitCount)
+		color.White("%s (%s commits%s)", email, formatCount(commitCount), active)
 		if nameStr != "" {
 			fmt.Printf("  Names: %s\n", nameStr)
 		}
 	}
 }
 
+// PrintMachine lists a shared CI/build identity. Its names are capped at 5
+// even without --max-names since such addresses can collect dozens of them.
+func (cp *ColorPrinter) PrintMachine(email string, names []string, commitCount int, reason string) {
+	color.HiBlack("[SHARED] %s (%s commits, %s)", email, formatCount(commitCount), reason)
+	limit := 5
+	if cp.maxNames > 0 && cp.maxNames < limit {
+		limit = cp.maxNames
+	}
+	if len(names) > 0 {
+		fmt.Printf("  Names: %s\n", joinNames(names, limit))
+	}
+}
+
+// activeSpan is the ", active 2019–2023" suffix for an email's commit count,
+// from its first and last commit years; empty when no commit is dated.
+func activeSpan(details *models.EmailDetails) string {
+	first, last := details.FirstCommit(), details.LastCommit()
+	if first.IsZero() {
+		return ""
+	}
+	if first.Year() == last.Year() {
+		return fmt.Sprintf(", active %d", first.Year())
+	}
+	return fmt.Sprintf(", active %d–%d", first.Year(), last.Year())
+}
+
+// printCommitterNote marks an email found as a co-author, or as the committer
+// (--include-committers), of commits someone else authored.
+func printCommitterNote(email string, details *models.EmailDetails) {
+	if n := details.CoAuthorCommits(email); n > 0 {
+		fmt.Printf("  Co-author on %s commits\n", formatCount(n))
+	}
+	if n := details.CommitterCommits(email); n > 0 {
+		fmt.Printf("  Committer on %s commits authored by others\n", formatCount(n))
+	}
+}
+
 func Results(emails map[string]*models.EmailDetails, showDetails bool, checkSecrets bool,
-	lookupEmail string, knownUsername string, user *gh.User, showTargetOnly bool, isOrg bool, cfg *github.Config, outputFormat string, w io.Writer) {
+	lookupEmail string, knownUsername string, user *gh.User, accountEmails []*gh.UserEmail, showTargetOnly bool, isOrg bool, cfg *github.Config, outputFormat string, w io.Writer) {
 
+	emails = filterEmails(emails, cfg)
 	matcher := NewUserMatcher(knownUsername, lookupEmail, user)
 	matcher.targetNames = extractTargetUserNames(emails, matcher.identifiers)
 
@@ -60,6 +100,7 @@ func Results(emails map[string]*models.EmailDetails, showDetails bool, checkSecr
 		LookupEmail:     lookupEmail,
 		KnownUsername:   knownUsername,
 		User:            user,
+		AccountEmails:   accountEmails,
 		ShowTargetOnly:  showTargetOnly,
 		IsOrg:           isOrg,
 		Cfg:             cfg,
@@ -73,6 +114,10 @@ func Results(emails map[string]*models.EmailDetails, showDetails bool, checkSecr
 		outputJSON(w, ctx, matcher)
 	case "csv":
 		outputCSV(w, ctx, matcher)
+	case "markdown":
+		outputMarkdown(w, ctx, matcher)
+	case "dot":
+		outputDOT(w, ctx, matcher)
 	default:
 		displayEmailDomains(ctx)
 		result := processEmails(ctx, matcher)
@@ -94,6 +139,9 @@ func StreamResults(streamChan <-chan StreamUpdate, showDetails bool, checkSecret
 
 	seenEmails := make(map[string]bool)
 	printer := &ColorPrinter{}
+	if cfg != nil {
+		printer.maxNames = cfg.MaxNames
+	}
 
 	for update := range streamChan {
 		if seenEmails[update.Email] {
@@ -102,13 +150,14 @@ func StreamResults(streamChan <-chan StreamUpdate, showDetails bool, checkSecret
 		seenEmails[update.Email] = true
 
 		isTargetUser := matcher.IsTargetUser(update.Email, update.Details)
-		isOrgEmployee := isOrg && isOrganizationEmail(update.Email, orgDomain)
+		isOrgEmployee := isOrg && isOrganizationEmail(update.Email, orgDomain, cfg.StrictOrgDomain)
 		if showTargetOnly && !isTargetUser {
 			continue
 		}
 
 		names := extractNames(update.Details)
-		printer.PrintEmail(update.Email, names, update.Details.CommitCount, isTargetUser, false, isOrgEmployee)
+		printer.PrintEmail(update.Email, names, update.Details.CommitCount, activeSpan(update.Details), isTargetUser, false, isOrgEmployee)
+		printCommitterNote(update.Email, update.Details)
 		fmt.Println()
 	}
 }
@@ -147,38 +196,96 @@ func displayEmailDomains(ctx *Context) {
 		limit = len(sorted)
 	}
 	for _, entry := range sorted[:limit] {
-		fmt.Printf("  %s %d contributors\n", color.WhiteString(entry.domain+":"), entry.count)
+		fmt.Printf("  %s %s contributors\n", color.WhiteString(entry.domain+":"), formatCount(entry.count))
 	}
 	fmt.Println()
 }
 
 func processEmails(ctx *Context, matcher *UserMatcher) *EmailProcessResult {
-	sortedEmails := sortEmailsByCommitCount(ctx.Emails)
+	sortedEmails := sortEmails(ctx.Emails, ctx.Cfg.SortBy)
 	result := &EmailProcessResult{
 		targetAccounts:    make(map[string][]string),
 		similarAccounts:   make(map[string][]string),
 		orgMembers:        make(map[string][]string),
 		similarOrgMembers: make(map[string][]string),
+		machineAccounts:   make(map[string]string),
 	}
 
-	printer := &ColorPrinter{}
+	printer := &ColorPrinter{maxNames: ctx.Cfg.MaxNames}
 	opts := &DisplayOptions{
 		ShowDetails:     ctx.ShowDetails,
 		CheckSecrets:    ctx.CheckSecrets,
 		ShowInteresting: ctx.Cfg.ShowInteresting,
 		ShowTargetOnly:  ctx.ShowTargetOnly,
+		FindLinks:       ctx.Cfg.FindLinks,
+	}
+
+	// --top caps how many other contributors are printed; target and similar
+	// accounts are always shown. With another --sort the ones kept are still
+	// those with the most commits, picked up front.
+	var topOthers map[string]bool
+	if ctx.Cfg.TopContributors > 0 && ctx.Cfg.SortBy != "" && ctx.Cfg.SortBy != "commits" {
+		topOthers = make(map[string]bool)
+		for _, entry := range sortEmails(ctx.Emails, "commits") {
+			if len(topOthers) >= ctx.Cfg.TopContributors {
+				break
+			}
+			isTargetUser, machineReason := matcher.classify(entry.Email, entry.Details)
+			if machineReason == "" && (isTargetUser || matcher.HasMatchingNames(extractNames(entry.Details))) {
+				continue
+			}
+			topOthers[entry.Email] = true
+		}
+	}
+
+	shownOthers, suppressed := 0, 0
+	overTop := func(email string) bool {
+		if ctx.Cfg.TopContributors <= 0 {
+			return false
+		}
+		if topOthers != nil {
+			if !topOthers[email] {
+				suppressed++
+				return true
+			}
+			return false
+		}
+		if shownOthers >= ctx.Cfg.TopContributors {
+			suppressed++
+			return true
+		}
+		shownOthers++
+		return false
 	}
 
 	for _, entry := range sortedEmails {
-		isTargetUser := matcher.IsTargetUser(entry.Email, entry.Details)
-		isOrgEmployee := ctx.IsOrg && isOrganizationEmail(entry.Email, ctx.OrgDomain)
-		result.totalContributors++
+		isTargetUser, machineReason := matcher.classify(entry.Email, entry.Details)
+		isOrgEmployee := ctx.IsOrg && isOrganizationEmail(entry.Email, ctx.OrgDomain, ctx.Cfg.StrictOrgDomain)
+		// shared machine accounts are listed apart and not counted as people
+		if machineReason == "" {
+			result.totalContributors++
+		}
 
 		if opts.ShowTargetOnly && !isTargetUser {
 			continue
 		}
 
 		names := extractNames(entry.Details)
+
+		if machineReason != "" {
+			result.machineAccounts[entry.Email] = machineReason
+			if overTop(entry.Email) {
+				continue
+			}
+			printer.PrintMachine(entry.Email, names, entry.Details.CommitCount, machineReason)
+			printCommitterNote(entry.Email, entry.Details)
+			if shouldShowCommitDetails(opts) {
+				displayCommitDetails(entry, false, ctx)
+			}
+			fmt.Println()
+			continue
+		}
+
 		hasSimilarNames := matcher.HasMatchingNames(names)
 
 		isSimilar := false
@@ -197,7 +304,12 @@ func processEmails(ctx *Context, matcher *UserMatcher) *EmailProcessResult {
 			isSimilar = true
 		}
 
-		printer.PrintEmail(entry.Email, names, entry.Details.CommitCount, isTargetUser, isSimilar, isOrgEmployee)
+		if !isTargetUser && !isSimilar && overTop(entry.Email) {
+			continue
+		}
+
+		printer.PrintEmail(entry.Email, names, entry.Details.CommitCount, activeSpan(entry.Details), isTargetUser, isSimilar, isOrgEmployee)
+		printCommitterNote(entry.Email, entry.Details)
 
 		if shouldShowCommitDetails(opts) {
 			displayCommitDetails(entry, isTargetUser, ctx)
@@ -206,31 +318,99 @@ func processEmails(ctx *Context, matcher *UserMatcher) *EmailProcessResult {
 		fmt.Println()
 	}
 
+	if suppressed > 0 {
+		color.White("... and %s more contributors not shown (--top %d)", formatCount(suppressed), ctx.Cfg.TopContributors)
+		fmt.Println()
+	}
+
 	return result
 }
 
 func shouldShowCommitDetails(opts *DisplayOptions) bool {
-	return opts.ShowDetails || opts.CheckSecrets || opts.ShowInteresting
+	return opts.ShowDetails || opts.CheckSecrets || opts.ShowInteresting || opts.FindLinks
 }
 
 func displayResults(ctx *Context, result *EmailProcessResult) {
-	displayRepositoryStats(ctx.Emails, ctx.UserIdentifiers)
+	displayActivitySparkline(ctx.Emails, ctx.UserIdentifiers)
+	displayRepositoryStats(ctx.Emails, ctx.UserIdentifiers, ctx.Cfg.MaxNames)
+	displayReusedMessages(ctx.Emails)
+	displayAuthorshipMismatches(ctx)
+	displayLinkedAccounts(ctx)
+	displayAlternateAccounts(ctx)
+
+	if ctx.CheckSecrets || ctx.Cfg.ShowInteresting {
+		displayAffectedRepos(ctx.Emails)
+	}
 
 	if ctx.Cfg.TimestampAnalysis {
-		displayTimestampAnalysis(ctx.Emails, ctx.UserIdentifiers)
+		displayTimestampAnalysis(ctx.Emails, ctx.UserIdentifiers, ctx.Cfg.HashLength)
+	}
+
+	// emails linked to a listed member are shown under that member instead
+	publicMembers := orgMemberEmails(ctx.Cfg.OrgMembers, ctx.Emails)
+	for _, addrs := range publicMembers {
+		for _, email := range addrs {
+			delete(result.orgMembers, email)
+		}
 	}
 
-	displaySummary(result.targetAccounts, result.similarAccounts, result.orgMembers, result.similarOrgMembers, ctx.IsOrg, ctx.OrgDomain, result.totalCommits, result.totalContributors)
+	displaySummary(result.targetAccounts, result.similarAccounts, result.orgMembers, result.similarOrgMembers, publicMembers, result.machineAccounts, ctx.IsOrg, ctx.OrgDomain, result.totalCommits, result.totalContributors, ctx.Cfg.MaxNames)
 }
 
-func sortEmailsByCommitCount(emails map[string]*models.EmailDetails) []EmailEntry {
+// sortEmails orders the results by commit count (the default), address,
+// most used name, or latest commit. Ties fall back to commit count, then
+// address, so the order is stable between runs.
+func sortEmails(emails map[string]*models.EmailDetails, by string) []EmailEntry {
 	var sortedEmails []EmailEntry
 	for email, details := range emails {
 		sortedEmails = append(sortedEmails, EmailEntry{email, details})
 	}
 
+	byCount := func(a, b EmailEntry) bool {
+		if a.Details.CommitCount != b.Details.CommitCount {
+			return a.Details.CommitCount > b.Details.CommitCount
+		}
+		return a.Email < b.Email
+	}
+
+	var less func(a, b EmailEntry) bool
+	switch by {
+	case "email":
+		less = func(a, b EmailEntry) bool {
+			return strings.ToLower(a.Email) < strings.ToLower(b.Email)
+		}
+	case "name":
+		names := make(map[string]string, len(sortedEmails))
+		for _, entry := range sortedEmails {
+			if n := extractNames(entry.Details); len(n) > 0 {
+				names[entry.Email] = strings.ToLower(n[0])
+			}
+		}
+		less = func(a, b EmailEntry) bool {
+			na, nb := names[a.Email], names[b.Email]
+			if na != nb {
+				// nameless emails go last
+				return nb == "" || (na != "" && na < nb)
+			}
+			return byCount(a, b)
+		}
+	case "recent":
+		last := make(map[string]time.Time, len(sortedEmails))
+		for _, entry := range sortedEmails {
+			last[entry.Email] = entry.Details.LastCommit()
+		}
+		less = func(a, b EmailEntry) bool {
+			if !last[a.Email].Equal(last[b.Email]) {
+				return last[a.Email].After(last[b.Email])
+			}
+			return byCount(a, b)
+		}
+	default:
+		less = byCount
+	}
+
 	sort.Slice(sortedEmails, func(i, j int) bool {
-		return sortedEmails[i].Details.CommitCount > sortedEmails[j].Details.CommitCount
+		return less(sortedEmails[i], sortedEmails[j])
 	})
 
 	return sortedEmails
@@ -0,0 +1,48 @@
+package display
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/gnomegl/gitslurp/v2/internal/models"
+)
+
+func TestSortEmails(t *testing.T) {
+	entry := func(name string, commits int, day int) *models.EmailDetails {
+		d := &models.EmailDetails{Names: map[string]struct{}{}, Commits: map[string][]models.CommitInfo{}, CommitCount: commits}
+		if name != "" {
+			d.Names[name] = struct{}{}
+		}
+		d.Commits["owner/repo"] = []models.CommitInfo{{AuthorDate: time.Date(2024, 1, day, 0, 0, 0, 0, time.UTC)}}
+		return d
+	}
+	emails := map[string]*models.EmailDetails{
+		"carol@example.org": entry("Carol", 5, 3),
+		"Alice@example.org": entry("alice", 2, 2),
+		"bob@example.org":   entry("Bob", 9, 10),
+		"dave@example.org":  entry("", 5, 1),
+	}
+
+	tests := []struct {
+		by   string
+		want []string
+	}{
+		{"", []string{"bob@example.org", "carol@example.org", "dave@example.org", "Alice@example.org"}},
+		{"commits", []string{"bob@example.org", "carol@example.org", "dave@example.org", "Alice@example.org"}},
+		{"email", []string{"Alice@example.org", "bob@example.org", "carol@example.org", "dave@example.org"}},
+		{"name", []string{"Alice@example.org", "bob@example.org", "carol@example.org", "dave@example.org"}},
+		{"recent", []string{"bob@example.org", "carol@example.org", "Alice@example.org", "dave@example.org"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.by, func(t *testing.T) {
+			var got []string
+			for _, e := range sortEmails(emails, tt.by) {
+				got = append(got, e.Email)
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("sortEmails(%q) = %v, want %v", tt.by, got, tt.want)
+			}
+		})
+	}
+}
@@ -5,35 +5,44 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"math"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/gnomegl/gitslurp/v2/internal/github"
+	"github.com/gnomegl/gitslurp/v2/internal/models"
 	gh "github.com/google/go-github/v57/github"
 )
 
 func outputJSON(w io.Writer, ctx *Context, matcher *UserMatcher) {
-	sortedEmails := sortEmailsByCommitCount(ctx.Emails)
+	sortedEmails := sortEmails(ctx.Emails, ctx.Cfg.SortBy)
 	encoder := json.NewEncoder(w)
 
-	totalCommits := 0
+	totalCommits, totalContributors := 0, 0
 	for _, entry := range sortedEmails {
-		isTarget := matcher.IsTargetUser(entry.Email, entry.Details)
+		isTarget, machineReason := matcher.classify(entry.Email, entry.Details)
 		if isTarget {
 			totalCommits += entry.Details.CommitCount
 		}
+		// shared machine accounts are not people
+		if machineReason == "" {
+			totalContributors++
+		}
 	}
 
 	meta := NDJSONMeta{
 		Target:            ctx.KnownUsername,
 		IsOrg:             ctx.IsOrg,
 		TotalCommits:      totalCommits,
-		TotalContributors: len(sortedEmails),
+		TotalContributors: totalContributors,
 	}
 
 	if ctx.User != nil {
 		meta.User = &JSONUser{
 			Login:       ctx.User.GetLogin(),
+			ID:          ctx.User.GetID(),
 			Name:        ctx.User.GetName(),
 			Email:       ctx.User.GetEmail(),
 			Company:     ctx.User.GetCompany(),
@@ -45,28 +54,35 @@ func outputJSON(w io.Writer, ctx *Context, matcher *UserMatcher) {
 			Following:   ctx.User.GetFollowing(),
 			PublicRepos: ctx.User.GetPublicRepos(),
 		}
+		meta.User.AccountEmails = jsonAccountEmails(ctx.AccountEmails)
 	}
 
 	encoder.Encode(meta)
 
 	for _, entry := range sortedEmails {
-		isTarget := matcher.IsTargetUser(entry.Email, entry.Details)
+		isTarget, machineReason := matcher.classify(entry.Email, entry.Details)
 
 		if ctx.ShowTargetOnly && !isTarget {
 			continue
 		}
 
 		jsonEntry := JSONEmailEntry{
-			Email:        entry.Email,
-			Names:        extractNames(entry.Details),
-			CommitCount:  entry.Details.CommitCount,
-			IsTarget:     isTarget,
-			Repositories: make([]JSONRepo, 0),
+			Email:            entry.Email,
+			Names:            extractNames(entry.Details),
+			CommitCount:      entry.Details.CommitCount,
+			FirstSeen:        timeOrNil(entry.Details.FirstCommit()),
+			LastSeen:         timeOrNil(entry.Details.LastCommit()),
+			CoAuthorCommits:  entry.Details.CoAuthorCommits(entry.Email),
+			CommitterCommits: entry.Details.CommitterCommits(entry.Email),
+			IsTarget:         isTarget,
+			IsMachine:        machineReason != "",
+			MachineNote:      machineReason,
+			Repositories:     make([]JSONRepo, 0),
 		}
 
 		for repoName, commits := range entry.Details.Commits {
 			jsonRepo := JSONRepo{
-				Name:    repoName,
+				Name:    repoLabel(repoName, commits),
 				Commits: make([]JSONCommit, 0),
 			}
 
@@ -78,9 +94,18 @@ func outputJSON(w io.Writer, ctx *Context, matcher *UserMatcher) {
 					AuthorName:     commit.AuthorName,
 					AuthorEmail:    commit.AuthorEmail,
 					AuthorDate:     commit.AuthorDate,
+					Source:         commitSource(commit),
+					IsOwnRepo:      commit.IsOwnRepo,
+					IsFork:         commit.IsFork,
+					IsExternal:     commit.IsExternal,
+					Visibility:     commit.RepoVisibility,
+					Verification:   jsonVerification(commit.Verification),
 					CommitterName:  commit.CommitterName,
 					CommitterEmail: commit.CommitterEmail,
-					Secrets:        commit.Secrets,
+					CoAuthors:      jsonCoAuthors(commit.CoAuthors),
+					Secrets:        jsonSecrets(commit.Secrets, ctx.Cfg.Redact),
+					Patches:        jsonPatches(commit, ctx.Cfg.Redact),
+					Links:          commit.Links,
 				}
 				jsonRepo.Commits = append(jsonRepo.Commits, jsonCommit)
 			}
@@ -90,10 +115,92 @@ func outputJSON(w io.Writer, ctx *Context, matcher *UserMatcher) {
 
 		encoder.Encode(jsonEntry)
 	}
+
+	WriteJSONAnalysis(w, ctx)
+}
+
+// WriteJSONAnalysis appends the analysis record to an NDJSON stream. It is
+// skipped when there is nothing to report.
+func WriteJSONAnalysis(w io.Writer, ctx *Context) {
+	emails := filterEmails(ctx.Emails, ctx.Cfg)
+	analysis := NDJSONAnalysis{}
+	for _, r := range findReusedMessages(emails) {
+		analysis.ReusedMessages = append(analysis.ReusedMessages, JSONReusedMessage{
+			Message:    r.Message,
+			Identities: r.Identities,
+			Repos:      r.Repos,
+		})
+	}
+
+	if start, counts := monthlyActivity(emails, ctx.UserIdentifiers); len(counts) > 0 {
+		analysis.MonthlyActivity = &JSONMonthlyActivity{
+			Start:  start.Format("2006-01"),
+			Counts: counts,
+		}
+	}
+
+	if ctx.Cfg != nil && ctx.Cfg.TimestampAnalysis {
+		analysis.Activity = activityAnalysis(emails, ctx.UserIdentifiers)
+	}
+
+	if ctx.Cfg != nil {
+		for login, addrs := range orgMemberEmails(ctx.Cfg.OrgMembers, emails) {
+			analysis.OrgMembers = append(analysis.OrgMembers, JSONOrgMember{Login: login, Emails: addrs})
+		}
+		sort.Slice(analysis.OrgMembers, func(i, j int) bool {
+			return strings.ToLower(analysis.OrgMembers[i].Login) < strings.ToLower(analysis.OrgMembers[j].Login)
+		})
+		for _, link := range ctx.Cfg.PivotLinks {
+			analysis.PivotLinks = append(analysis.PivotLinks, JSONPivotLink{URL: link.URL, Sources: link.Sources, Status: link.Status, Resolved: link.Resolved})
+		}
+	}
+
+	for _, a := range linkedAccounts(ctx, emails) {
+		analysis.LinkedAccounts = append(analysis.LinkedAccounts, JSONLinkedAccount{Service: a.Service, Handle: a.Handle, Sources: a.Sources})
+	}
+
+	for _, a := range findAlternateAccounts(ctx, emails) {
+		analysis.Alternates = append(analysis.Alternates, JSONAlternate{Email: a.Email, Confidence: math.Round(a.Confidence*100) / 100, Reasons: a.Reasons})
+	}
+
+	_, _, gaps := findActivityGaps(emails, ctx.UserIdentifiers)
+	for _, g := range gaps {
+		analysis.ActivityGaps = append(analysis.ActivityGaps, JSONActivityGap{
+			From: g.From.Format("2006-01-02"),
+			To:   g.To.Format("2006-01-02"),
+			Days: g.Days(),
+		})
+	}
+
+	for _, r := range rankAffectedRepos(emails) {
+		analysis.AffectedRepos = append(analysis.AffectedRepos, JSONAffectedRepo{
+			Repository:  r.Repo,
+			Score:       r.Score,
+			Secrets:     r.Secrets,
+			Interesting: r.Interesting,
+			TopPattern:  r.TopPattern,
+		})
+	}
+
+	for _, m := range findAuthorshipMismatches(emails, targetLoginOf(ctx), ctx.AccountEmails) {
+		analysis.Mismatches = append(analysis.Mismatches, JSONMismatch{
+			Repository:    m.Repo,
+			Hash:          m.Hash,
+			URL:           m.URL,
+			ClaimedEmail:  m.ClaimedEmail,
+			ResolvedLogin: m.ResolvedLogin,
+			Reason:        m.Reason,
+		})
+	}
+
+	if analysis.empty() {
+		return
+	}
+	json.NewEncoder(w).Encode(analysis)
 }
 
 func outputCSV(w io.Writer, ctx *Context, matcher *UserMatcher) {
-	sortedEmails := sortEmailsByCommitCount(ctx.Emails)
+	sortedEmails := sortEmails(ctx.Emails, ctx.Cfg.SortBy)
 
 	writer := csv.NewWriter(w)
 	defer writer.Flush()
@@ -112,6 +219,17 @@ func outputCSV(w io.Writer, ctx *Context, matcher *UserMatcher) {
 		"committer_name",
 		"committer_email",
 		"secrets_found",
+		"source",
+		"is_own_repo",
+		"is_fork",
+		"is_external",
+		"repo_visibility",
+		"verified",
+		"verification_reason",
+		"signer_key_id",
+		"first_seen",
+		"last_seen",
+		"links",
 	}
 
 	if err := writer.Write(headers); err != nil {
@@ -131,12 +249,18 @@ func outputCSV(w io.Writer, ctx *Context, matcher *UserMatcher) {
 		if isTarget {
 			isTargetStr = "true"
 		}
+		firstSeen, lastSeen := csvTime(entry.Details.FirstCommit()), csvTime(entry.Details.LastCommit())
 
 		for repoName, commits := range entry.Details.Commits {
 			for _, commit := range commits {
 				secretsStr := ""
 				if len(commit.Secrets) > 0 {
-					secretsStr = strings.Join(commit.Secrets, " | ")
+					findings := exportFindings(commit.Secrets, ctx.Cfg.Redact)
+					parts := make([]string, len(findings))
+					for i, f := range findings {
+						parts[i] = f.String()
+					}
+					secretsStr = strings.Join(parts, " | ")
 				}
 
 				row := []string{
@@ -144,7 +268,7 @@ func outputCSV(w io.Writer, ctx *Context, matcher *UserMatcher) {
 					names,
 					isTargetStr,
 					fmt.Sprintf("%d", entry.Details.CommitCount),
-					repoName,
+					repoLabel(repoName, commits),
 					commit.Hash,
 					commit.URL,
 					commit.AuthorName,
@@ -153,7 +277,14 @@ func outputCSV(w io.Writer, ctx *Context, matcher *UserMatcher) {
 					commit.CommitterName,
 					commit.CommitterEmail,
 					secretsStr,
+					commitSource(commit),
+					strconv.FormatBool(commit.IsOwnRepo),
+					strconv.FormatBool(commit.IsFork),
+					strconv.FormatBool(commit.IsExternal),
+					commit.RepoVisibility,
 				}
+				row = append(row, csvVerification(commit.Verification)...)
+				row = append(row, firstSeen, lastSeen, strings.Join(commit.Links, " | "))
 
 				if err := writer.Write(row); err != nil {
 					fmt.Fprintf(w, "Error writing CSV row: %v\n", err)
@@ -164,7 +295,7 @@ func outputCSV(w io.Writer, ctx *Context, matcher *UserMatcher) {
 	}
 }
 
-func StreamJSON(w io.Writer, knownUsername string, lookupEmail string, user *gh.User, isOrg bool, showTargetOnly bool, updateChan <-chan github.EmailUpdate) {
+func StreamJSON(w io.Writer, knownUsername string, lookupEmail string, user *gh.User, accountEmails []*gh.UserEmail, isOrg bool, showTargetOnly bool, cfg *github.Config, updateChan <-chan github.EmailUpdate) {
 	matcher := NewUserMatcher(knownUsername, lookupEmail, user)
 	encoder := json.NewEncoder(w)
 
@@ -175,6 +306,7 @@ func StreamJSON(w io.Writer, knownUsername string, lookupEmail string, user *gh.
 	if user != nil {
 		meta.User = &JSONUser{
 			Login:       user.GetLogin(),
+			ID:          user.GetID(),
 			Name:        user.GetName(),
 			Email:       user.GetEmail(),
 			Company:     user.GetCompany(),
@@ -186,26 +318,37 @@ func StreamJSON(w io.Writer, knownUsername string, lookupEmail string, user *gh.
 			Following:   user.GetFollowing(),
 			PublicRepos: user.GetPublicRepos(),
 		}
+		meta.User.AccountEmails = jsonAccountEmails(accountEmails)
 	}
 	encoder.Encode(meta)
 
+	redact := cfg != nil && cfg.Redact
 	for update := range updateChan {
-		isTarget := matcher.IsTargetUser(update.Email, update.Details)
+		if !keepEmail(update.Email, cfg) {
+			continue
+		}
+		isTarget, machineReason := matcher.classify(update.Email, update.Details)
 		if showTargetOnly && !isTarget {
 			continue
 		}
 
 		jsonEntry := JSONEmailEntry{
-			Email:        update.Email,
-			Names:        extractNames(update.Details),
-			CommitCount:  update.Details.CommitCount,
-			IsTarget:     isTarget,
-			Repositories: make([]JSONRepo, 0),
+			Email:            update.Email,
+			Names:            extractNames(update.Details),
+			CommitCount:      update.Details.CommitCount,
+			FirstSeen:        timeOrNil(update.Details.FirstCommit()),
+			LastSeen:         timeOrNil(update.Details.LastCommit()),
+			CoAuthorCommits:  update.Details.CoAuthorCommits(update.Email),
+			CommitterCommits: update.Details.CommitterCommits(update.Email),
+			IsTarget:         isTarget,
+			IsMachine:        machineReason != "",
+			MachineNote:      machineReason,
+			Repositories:     make([]JSONRepo, 0),
 		}
 
 		for repoName, commits := range update.Details.Commits {
 			jsonRepo := JSONRepo{
-				Name:    repoName,
+				Name:    repoLabel(repoName, commits),
 				Commits: make([]JSONCommit, 0),
 			}
 			for _, commit := range commits {
@@ -216,9 +359,18 @@ func StreamJSON(w io.Writer, knownUsername string, lookupEmail string, user *gh.
 					AuthorName:     commit.AuthorName,
 					AuthorEmail:    commit.AuthorEmail,
 					AuthorDate:     commit.AuthorDate,
+					Source:         commitSource(commit),
+					IsOwnRepo:      commit.IsOwnRepo,
+					IsFork:         commit.IsFork,
+					IsExternal:     commit.IsExternal,
+					Visibility:     commit.RepoVisibility,
+					Verification:   jsonVerification(commit.Verification),
 					CommitterName:  commit.CommitterName,
 					CommitterEmail: commit.CommitterEmail,
-					Secrets:        commit.Secrets,
+					CoAuthors:      jsonCoAuthors(commit.CoAuthors),
+					Secrets:        jsonSecrets(commit.Secrets, redact),
+					Patches:        jsonPatches(commit, redact),
+					Links:          commit.Links,
 				})
 			}
 			jsonEntry.Repositories = append(jsonEntry.Repositories, jsonRepo)
@@ -227,3 +379,119 @@ func StreamJSON(w io.Writer, knownUsername string, lookupEmail string, user *gh.
 		encoder.Encode(jsonEntry)
 	}
 }
+
+// commitSource buckets a commit as "own" (target's repos), "org" (repos of an
+// org the target belongs to) or "external" (anyone else's).
+func commitSource(commit models.CommitInfo) string {
+	switch {
+	case commit.IsOrgRepo:
+		return "org"
+	case commit.IsExternal:
+		return "external"
+	default:
+		return "own"
+	}
+}
+
+// timeOrNil leaves unknown dates out of the JSON.
+func timeOrNil(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+func csvTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+func jsonCoAuthors(coAuthors []models.CoAuthor) []JSONCoAuthor {
+	var out []JSONCoAuthor
+	for _, co := range coAuthors {
+		out = append(out, JSONCoAuthor{Name: co.Name, Email: co.Email})
+	}
+	return out
+}
+
+// jsonVerification is nil when the API returned no verification data.
+func jsonVerification(v *models.Verification) *JSONVerification {
+	if v == nil {
+		return nil
+	}
+	return &JSONVerification{
+		Verified:    v.Verified,
+		Reason:      v.Reason,
+		SignerKeyID: v.SignerKeyID,
+	}
+}
+
+// csvVerification leaves all three columns empty when verification data is
+// unknown, so "false" always means GitHub checked and rejected the commit.
+func csvVerification(v *models.Verification) []string {
+	if v == nil {
+		return []string{"", "", ""}
+	}
+	return []string{strconv.FormatBool(v.Verified), v.Reason, v.SignerKeyID}
+}
+
+func jsonAccountEmails(accountEmails []*gh.UserEmail) []JSONAccountEmail {
+	var out []JSONAccountEmail
+	for _, e := range accountEmails {
+		out = append(out, JSONAccountEmail{
+			Email:      e.GetEmail(),
+			Source:     "account",
+			Primary:    e.GetPrimary(),
+			Verified:   e.GetVerified(),
+			Visibility: e.GetVisibility(),
+		})
+	}
+	return out
+}
+
+func jsonPatches(commit models.CommitInfo, redact bool) []JSONPatch {
+	if len(commit.Patches) == 0 {
+		return nil
+	}
+	out := make([]JSONPatch, 0, len(commit.Patches))
+	for _, p := range commit.Patches {
+		patch := p.Patch
+		if redact {
+			patch = redactPatch(patch, commit)
+		}
+		out = append(out, JSONPatch{Filename: p.Filename, Patch: patch})
+	}
+	return out
+}
+
+func exportFindings(findings []models.SecretFinding, redact bool) []models.SecretFinding {
+	if redact {
+		return redactFindings(findings)
+	}
+	return findings
+}
+
+func jsonSecrets(findings []models.SecretFinding, redact bool) []JSONSecret {
+	if len(findings) == 0 {
+		return nil
+	}
+	out := make([]JSONSecret, 0, len(findings))
+	for _, f := range exportFindings(findings, redact) {
+		kind := "secret"
+		if f.Interesting {
+			kind = "interesting"
+		}
+		out = append(out, JSONSecret{
+			Name:     f.Name,
+			Type:     kind,
+			Value:    f.Value,
+			Location: f.Location,
+			Line:     f.LineNumber,
+			Context:  f.Context,
+			Verdict:  f.Verdict,
+		})
+	}
+	return out
+}
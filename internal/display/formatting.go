@@ -1,8 +1,86 @@
 package display
 
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gnomegl/gitslurp/v2/internal/models"
+)
+
 func min(a, b int) int {
 	if a < b {
 		return a
 	}
 	return b
 }
+
+// repoLabel is how the repository under Commits key is shown: the owner/name
+// spelling its commits were recorded with, since keys are lowercased.
+func repoLabel(key string, commits []models.CommitInfo) string {
+	for _, commit := range commits {
+		if commit.RepoName != "" && strings.EqualFold(commit.RepoName, key) {
+			return commit.RepoName
+		}
+	}
+	return key
+}
+
+// formatCount renders n with thousands separators (1234567 -> "1,234,567").
+// Display only; JSON and CSV keep raw numbers.
+func formatCount(n int) string {
+	s := strconv.Itoa(n)
+	sign := ""
+	if n < 0 {
+		sign, s = "-", s[1:]
+	}
+	if len(s) <= 3 {
+		return sign + s
+	}
+
+	out := make([]byte, 0, len(s)+len(s)/3)
+	lead := len(s) % 3
+	if lead > 0 {
+		out = append(out, s[:lead]...)
+	}
+	for i := lead; i < len(s); i += 3 {
+		if len(out) > 0 {
+			out = append(out, ',')
+		}
+		out = append(out, s[i:i+3]...)
+	}
+	return sign + string(out)
+}
+
+// formatPercent renders a percentage with one decimal so every display rounds
+// the same way.
+func formatPercent(pct float64) string {
+	return fmt.Sprintf("%.1f%%", pct)
+}
+
+// percentOf returns part as a percentage of total, or 0 when total is 0.
+func percentOf(part, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(part) / float64(total) * 100
+}
+
+// shortHash truncates a commit hash to n characters for display. Hashes
+// shorter than n (or empty, as on synthetic gist/event entries) are returned
+// unchanged; n <= 0 keeps the full hash.
+func shortHash(hash string, n int) string {
+	if n <= 0 || len(hash) <= n {
+		return hash
+	}
+	return hash[:n]
+}
+
+// joinNames renders at most max names with a "+K more" marker; max <= 0
+// shows them all.
+func joinNames(names []string, max int) string {
+	if max > 0 && len(names) > max {
+		return strings.Join(names[:max], ", ") + fmt.Sprintf(", +%d more", len(names)-max)
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,41 @@
+package display
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gnomegl/gitslurp/v2/internal/models"
+)
+
+func TestShortHash(t *testing.T) {
+	tests := []struct {
+		hash string
+		n    int
+		want string
+	}{
+		{"0123456789abcdef", 8, "01234567"},
+		{"0123456789abcdef", 12, "0123456789ab"},
+		{"0123456789abcdef", 0, "0123456789abcdef"},
+		{"0123456789abcdef", -1, "0123456789abcdef"},
+		{"abc", 8, "abc"},
+		{"", 8, ""},
+	}
+	for _, tt := range tests {
+		if got := shortHash(tt.hash, tt.n); got != tt.want {
+			t.Errorf("shortHash(%q, %d) = %q, want %q", tt.hash, tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestSuspiciousPatternsShortHashes(t *testing.T) {
+	var commits []models.CommitInfo
+	for _, hash := range []string{"", "ab", "0123456789abcdef"} {
+		commits = append(commits, models.CommitInfo{
+			Hash:              hash,
+			AuthorDate:        time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+			TimestampAnalysis: &models.TimestampAnalysis{IsUnusualHour: true},
+		})
+	}
+	// synthetic gist and event entries have short or empty hashes
+	displaySuspiciousPatterns(commits, 8)
+}
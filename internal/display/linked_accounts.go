@@ -0,0 +1,90 @@
+package display
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/gnomegl/gitslurp/v2/internal/models"
+	"github.com/gnomegl/gitslurp/v2/internal/scanner"
+)
+
+// LinkedAccount is an account on another service tied to the target, with
+// the places it was named.
+type LinkedAccount struct {
+	Service string
+	Handle  string
+	Sources []string // profile, website, bio, readme, commits
+}
+
+// linkedAccounts collects the handles named in the target's profile fields,
+// pivot links and commit messages, merging repeats across sources.
+func linkedAccounts(ctx *Context, emails map[string]*models.EmailDetails) []LinkedAccount {
+	var accounts []LinkedAccount
+	index := make(map[string]int)
+	add := func(h scanner.Handle, source string) {
+		key := h.Service + "\x00" + strings.ToLower(strings.TrimPrefix(h.Name, "@"))
+		if i, ok := index[key]; ok {
+			for _, s := range accounts[i].Sources {
+				if s == source {
+					return
+				}
+			}
+			accounts[i].Sources = append(accounts[i].Sources, source)
+			return
+		}
+		index[key] = len(accounts)
+		accounts = append(accounts, LinkedAccount{Service: h.Service, Handle: h.Name, Sources: []string{source}})
+	}
+
+	if user := ctx.User; user != nil {
+		if user.GetTwitterUsername() != "" {
+			add(scanner.Handle{Service: "Twitter", Name: user.GetTwitterUsername()}, "profile")
+		}
+		for _, h := range scanner.FindHandles(user.GetBlog()) {
+			add(h, "website")
+		}
+		for _, h := range scanner.FindHandles(user.GetBio()) {
+			add(h, "bio")
+		}
+	}
+	if ctx.Cfg != nil {
+		for _, link := range ctx.Cfg.PivotLinks {
+			for _, h := range scanner.FindHandles(link.URL) {
+				for _, source := range link.Sources {
+					add(h, source)
+				}
+			}
+		}
+	}
+
+	for email, details := range emails {
+		if !isTargetIdentity(email, details, ctx.UserIdentifiers) {
+			continue
+		}
+		for _, commits := range details.Commits {
+			for _, commit := range commits {
+				for _, h := range scanner.FindHandles(commit.Message) {
+					add(h, "commits")
+				}
+			}
+		}
+	}
+	return accounts
+}
+
+func displayLinkedAccounts(ctx *Context) {
+	accounts := linkedAccounts(ctx, ctx.Emails)
+	if len(accounts) == 0 {
+		return
+	}
+
+	fmt.Println()
+	headerColor.Print("LINKED ACCOUNTS")
+	fmt.Println(" (handles on other services)")
+	fmt.Println(strings.Repeat("-", 60))
+
+	for _, a := range accounts {
+		fmt.Printf("  %-10s %s %s\n", a.Service, a.Handle, color.HiBlackString("(%s)", strings.Join(a.Sources, ", ")))
+	}
+}
@@ -0,0 +1,57 @@
+package display
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/fatih/color"
+	"github.com/gnomegl/gitslurp/v2/internal/github"
+	"github.com/gnomegl/gitslurp/v2/internal/models"
+)
+
+func TestLinksShown(t *testing.T) {
+	defer func(out io.Writer) { color.Output = out }(color.Output)
+
+	details := &models.EmailDetails{
+		Names:       map[string]struct{}{"Octo": {}},
+		CommitCount: 1,
+		Commits: map[string][]models.CommitInfo{
+			"octo/blog": {{Hash: "abc123", RepoName: "octo/blog", AuthorEmail: "octo@octo.dev",
+				Links: []string{"https://mirror.octo.dev", "https://octo.dev/post"}}},
+		},
+	}
+
+	tests := []struct {
+		findLinks bool
+		want      bool
+	}{
+		{false, false},
+		{true, true},
+	}
+	for _, tt := range tests {
+		cfg := github.DefaultConfig()
+		cfg.FindLinks = tt.findLinks
+		ctx := &Context{Emails: map[string]*models.EmailDetails{"octo@octo.dev": details}, Cfg: &cfg}
+
+		var console bytes.Buffer
+		color.Output = &console
+		NewCommitDisplayer(ctx).DisplayForEntry(EmailEntry{Email: "octo@octo.dev", Details: details}, true)
+		for _, link := range details.Commits["octo/blog"][0].Links {
+			if got := strings.Contains(console.String(), "LINK: "+link); got != tt.want {
+				t.Errorf("FindLinks %v: %s shown %v, want %v:\n%s", tt.findLinks, link, got, tt.want, console.String())
+			}
+		}
+
+		var jsonOut, csvOut bytes.Buffer
+		outputJSON(&jsonOut, ctx, NewUserMatcher("octo", "", nil))
+		outputCSV(&csvOut, ctx, NewUserMatcher("octo", "", nil))
+		if !strings.Contains(jsonOut.String(), `"links":["https://mirror.octo.dev","https://octo.dev/post"]`) {
+			t.Errorf("JSON export is missing the links:\n%s", jsonOut.String())
+		}
+		if !strings.Contains(csvOut.String(), "https://mirror.octo.dev | https://octo.dev/post") {
+			t.Errorf("CSV export is missing the links:\n%s", csvOut.String())
+		}
+	}
+}
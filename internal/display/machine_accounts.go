@@ -0,0 +1,56 @@
+package display
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gnomegl/gitslurp/v2/internal/models"
+)
+
+// an email used under at least this many author names is treated as a shared
+// machine rather than a person
+const machineNameThreshold = 6
+
+var machineLocalParts = []string{
+	"jenkins", "ci", "build", "builder", "buildbot", "builds",
+	"bot", "travis", "circleci", "gitlab-ci", "teamcity", "bamboo",
+	"deploy", "deployer", "autobuild", "automation", "release",
+	"root", "admin", "git", "svn", "cvs", "nobody",
+}
+
+// machineAccountReason explains why an email looks like a shared CI/build
+// identity, or returns "" for what appears to be a person.
+func machineAccountReason(email string, details *models.EmailDetails) string {
+	local, domain, _ := strings.Cut(strings.ToLower(email), "@")
+
+	if domain == "localhost" || strings.HasSuffix(domain, ".localdomain") || strings.HasSuffix(domain, ".local") {
+		return "local machine address"
+	}
+
+	for _, prefix := range machineLocalParts {
+		if local == prefix || strings.HasPrefix(local, prefix+"-") || strings.HasPrefix(local, prefix+".") ||
+			strings.HasPrefix(local, prefix+"_") || strings.HasPrefix(local, prefix+"+") {
+			return fmt.Sprintf("build/CI address (%s@)", prefix)
+		}
+	}
+
+	if details != nil && len(details.Names) >= machineNameThreshold {
+		return fmt.Sprintf("used by %s different names", formatCount(len(details.Names)))
+	}
+
+	return ""
+}
+
+// classify reports whether an email belongs to the target and, if it looks like
+// a shared machine, why. A machine address only counts as the target when the
+// address itself is one of the target's, never through a shared author name.
+func (m *UserMatcher) classify(email string, details *models.EmailDetails) (bool, string) {
+	reason := machineAccountReason(email, details)
+	if reason != "" {
+		if m.identifiers[email] {
+			return true, ""
+		}
+		return false, reason
+	}
+	return m.IsTargetUser(email, details), ""
+}
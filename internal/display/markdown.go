@@ -0,0 +1,281 @@
+package display
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gnomegl/gitslurp/v2/internal/models"
+)
+
+// outputMarkdown writes the results as a GitHub-flavored Markdown report:
+// profile, emails, external contributions and findings, one table each.
+func outputMarkdown(w io.Writer, ctx *Context, matcher *UserMatcher) {
+	sortedEmails := sortEmails(ctx.Emails, ctx.Cfg.SortBy)
+	if ctx.ShowTargetOnly {
+		var kept []EmailEntry
+		for _, entry := range sortedEmails {
+			if matcher.IsTargetUser(entry.Email, entry.Details) {
+				kept = append(kept, entry)
+			}
+		}
+		sortedEmails = kept
+	}
+
+	fmt.Fprintf(w, "# gitslurp report: %s\n\n", mdCell(ctx.KnownUsername))
+	writeMarkdownProfile(w, ctx)
+	writeMarkdownEmails(w, sortedEmails, matcher)
+	writeMarkdownExternal(w, sortedEmails)
+	writeMarkdownSecrets(w, sortedEmails, ctx)
+	writeMarkdownLinks(w, sortedEmails)
+}
+
+func writeMarkdownProfile(w io.Writer, ctx *Context) {
+	user := ctx.User
+	if user == nil {
+		return
+	}
+
+	fmt.Fprintln(w, "## Profile")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "| Field | Value |")
+	fmt.Fprintln(w, "| --- | --- |")
+	field := func(label, value string) {
+		if value != "" {
+			fmt.Fprintf(w, "| %s | %s |\n", label, mdCell(value))
+		}
+	}
+	kind := "User"
+	if ctx.IsOrg {
+		kind = "Organization"
+	}
+	login := user.GetLogin()
+	if user.GetHTMLURL() != "" {
+		login = fmt.Sprintf("[%s](%s)", login, user.GetHTMLURL())
+	}
+	field(kind, login)
+	if user.GetID() != 0 {
+		field("ID", fmt.Sprintf("%d", user.GetID()))
+	}
+	field("Name", user.GetName())
+	field("Email", user.GetEmail())
+	field("Company", user.GetCompany())
+	field("Location", user.GetLocation())
+	field("Bio", user.GetBio())
+	field("Website", user.GetBlog())
+	if user.GetTwitterUsername() != "" {
+		field("Twitter", "@"+user.GetTwitterUsername())
+	}
+	field("Public repos", formatCount(user.GetPublicRepos()))
+	if !ctx.IsOrg {
+		field("Followers", formatCount(user.GetFollowers()))
+		field("Following", formatCount(user.GetFollowing()))
+	}
+	if !user.GetCreatedAt().Time.IsZero() {
+		field("Created", user.GetCreatedAt().Time.Format("2006-01-02"))
+	}
+	for _, e := range ctx.AccountEmails {
+		field("Account email", e.GetEmail())
+	}
+	for _, link := range ctx.Cfg.PivotLinks {
+		value := fmt.Sprintf("%s (%s)", link.URL, strings.Join(link.Sources, ", "))
+		if link.Status != "" {
+			value += " [" + link.Status + "]"
+		}
+		field("Pivot link", value)
+	}
+	for _, a := range linkedAccounts(ctx, ctx.Emails) {
+		field("Linked account", fmt.Sprintf("%s %s (%s)", a.Service, a.Handle, strings.Join(a.Sources, ", ")))
+	}
+	fmt.Fprintln(w)
+}
+
+func writeMarkdownEmails(w io.Writer, entries []EmailEntry, matcher *UserMatcher) {
+	fmt.Fprintln(w, "## Emails")
+	fmt.Fprintln(w)
+	if len(entries) == 0 {
+		fmt.Fprintln(w, "No emails found.")
+		fmt.Fprintln(w)
+		return
+	}
+
+	fmt.Fprintln(w, "| Email | Commits | First seen | Last seen | Names | Notes |")
+	fmt.Fprintln(w, "| --- | ---: | --- | --- | --- | --- |")
+	for _, entry := range entries {
+		isTarget, machineReason := matcher.classify(entry.Email, entry.Details)
+		note := ""
+		switch {
+		case isTarget:
+			note = "target"
+		case machineReason != "":
+			note = "shared: " + machineReason
+		}
+		extra := func(format string, n int) {
+			if n == 0 {
+				return
+			}
+			if note != "" {
+				note += "; "
+			}
+			note += fmt.Sprintf(format, n)
+		}
+		extra("co-author on %d commits", entry.Details.CoAuthorCommits(entry.Email))
+		extra("committer on %d commits by others", entry.Details.CommitterCommits(entry.Email))
+		fmt.Fprintf(w, "| %s | %d | %s | %s | %s | %s |\n", mdCell(entry.Email), entry.Details.CommitCount,
+			mdDate(entry.Details.FirstCommit()), mdDate(entry.Details.LastCommit()),
+			mdCell(strings.Join(extractNames(entry.Details), ", ")), mdCell(note))
+	}
+	fmt.Fprintln(w)
+}
+
+// writeMarkdownExternal lists commits made to repositories outside the
+// target's own, grouped by repository and email.
+func writeMarkdownExternal(w io.Writer, entries []EmailEntry) {
+	type row struct {
+		repo, email string
+		commits     int
+	}
+	var rows []row
+	for _, entry := range entries {
+		for repo, commits := range entry.Details.Commits {
+			n := 0
+			for _, commit := range commits {
+				if commit.IsExternal {
+					n++
+				}
+			}
+			if n > 0 {
+				rows = append(rows, row{repoLabel(repo, commits), entry.Email, n})
+			}
+		}
+	}
+	if len(rows) == 0 {
+		return
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].repo != rows[j].repo {
+			return rows[i].repo < rows[j].repo
+		}
+		return rows[i].email < rows[j].email
+	})
+
+	fmt.Fprintln(w, "## External contributions")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "| Repository | Email | Commits |")
+	fmt.Fprintln(w, "| --- | --- | ---: |")
+	for _, r := range rows {
+		fmt.Fprintf(w, "| %s | %s | %d |\n", mdCell(r.repo), mdCell(r.email), r.commits)
+	}
+	fmt.Fprintln(w)
+}
+
+func writeMarkdownSecrets(w io.Writer, entries []EmailEntry, ctx *Context) {
+	type row struct {
+		commit  models.CommitInfo
+		finding models.SecretFinding
+	}
+	var rows []row
+	for _, entry := range entries {
+		for _, commits := range entry.Details.Commits {
+			for _, commit := range commits {
+				for _, f := range exportFindings(commit.Secrets, ctx.Cfg.Redact) {
+					rows = append(rows, row{commit, f})
+				}
+			}
+		}
+	}
+	if len(rows) == 0 {
+		return
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		if rows[i].commit.RepoName != rows[j].commit.RepoName {
+			return rows[i].commit.RepoName < rows[j].commit.RepoName
+		}
+		return rows[i].commit.Hash < rows[j].commit.Hash
+	})
+
+	fmt.Fprintln(w, "## Secrets")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "| Repository | Commit | Type | Finding | Value | Location |")
+	fmt.Fprintln(w, "| --- | --- | --- | --- | --- | --- |")
+	for _, r := range rows {
+		commit := shortHash(r.commit.Hash, ctx.Cfg.HashLength)
+		if r.commit.URL != "" {
+			commit = fmt.Sprintf("[%s](%s)", commit, r.commit.URL)
+		}
+		kind := "secret"
+		if r.finding.Interesting {
+			kind = "interesting"
+		}
+		location := r.finding.Location
+		if location != "" && r.finding.LineNumber > 0 {
+			location = fmt.Sprintf("%s:%d", location, r.finding.LineNumber)
+		}
+		fmt.Fprintf(w, "| %s | %s | %s | %s | `%s` | %s |\n", mdCell(r.commit.RepoName), commit, kind,
+			mdCell(r.finding.Name), strings.ReplaceAll(mdCell(r.finding.Value), "`", "'"), mdCell(location))
+	}
+	fmt.Fprintln(w)
+}
+
+// writeMarkdownLinks lists each distinct link collected with --links, with
+// the repositories it appeared in and how many commits mention it.
+func writeMarkdownLinks(w io.Writer, entries []EmailEntry) {
+	type usage struct {
+		repos   map[string]bool
+		commits int
+	}
+	byLink := make(map[string]*usage)
+	for _, entry := range entries {
+		for repo, commits := range entry.Details.Commits {
+			for _, commit := range commits {
+				for _, link := range commit.Links {
+					u, ok := byLink[link]
+					if !ok {
+						u = &usage{repos: make(map[string]bool)}
+						byLink[link] = u
+					}
+					u.repos[repoLabel(repo, commits)] = true
+					u.commits++
+				}
+			}
+		}
+	}
+	if len(byLink) == 0 {
+		return
+	}
+	links := make([]string, 0, len(byLink))
+	for link := range byLink {
+		links = append(links, link)
+	}
+	sort.Strings(links)
+
+	fmt.Fprintln(w, "## Links")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "| Link | Repositories | Commits |")
+	fmt.Fprintln(w, "| --- | --- | ---: |")
+	for _, link := range links {
+		repos := make([]string, 0, len(byLink[link].repos))
+		for repo := range byLink[link].repos {
+			repos = append(repos, repo)
+		}
+		sort.Strings(repos)
+		fmt.Fprintf(w, "| %s | %s | %d |\n", mdCell(link), mdCell(strings.Join(repos, ", ")), byLink[link].commits)
+	}
+	fmt.Fprintln(w)
+}
+
+func mdDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format("2006-01-02")
+}
+
+// mdCell makes a value safe inside a table cell.
+func mdCell(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	s = strings.ReplaceAll(s, "\r", "")
+	return strings.ReplaceAll(s, "\n", " ")
+}
@@ -0,0 +1,147 @@
+package display
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/gnomegl/gitslurp/v2/internal/models"
+)
+
+// messages shorter than this (after normalization) are too generic to link
+// identities
+const minReusedMessageLength = 20
+
+var genericMessages = map[string]bool{
+	"initial commit":         true,
+	"first commit":           true,
+	"update readme":          true,
+	"update readme.md":       true,
+	"create readme.md":       true,
+	"add files via upload":   true,
+	"update .gitignore":      true,
+	"initial commit of code": true,
+}
+
+type ReusedMessage struct {
+	Message    string
+	Identities []string
+	Repos      []string
+}
+
+// normalizeMessage reduces a commit message to its subject line, lowercased
+// with whitespace collapsed, so trivial formatting differences still match.
+func normalizeMessage(msg string) string {
+	if idx := strings.IndexByte(msg, '\n'); idx >= 0 {
+		msg = msg[:idx]
+	}
+	return strings.ToLower(strings.Join(strings.Fields(msg), " "))
+}
+
+func isGenericMessage(normalized string) bool {
+	if len(normalized) < minReusedMessageLength {
+		return true
+	}
+	if strings.HasPrefix(normalized, "merge ") || strings.HasPrefix(normalized, "revert ") {
+		return true
+	}
+	if len(strings.Fields(normalized)) < 3 {
+		return true
+	}
+	return genericMessages[normalized]
+}
+
+// findReusedMessages returns distinctive commit messages that appear under
+// more than one identity, most widely shared first.
+func findReusedMessages(emails map[string]*models.EmailDetails) []ReusedMessage {
+	type usage struct {
+		original   string
+		identities map[string]bool
+		repos      map[string]bool
+	}
+	byMessage := make(map[string]*usage)
+
+	for email, details := range emails {
+		for repoName, commits := range details.Commits {
+			for _, commit := range commits {
+				key := normalizeMessage(commit.Message)
+				if isGenericMessage(key) {
+					continue
+				}
+				u, ok := byMessage[key]
+				if !ok {
+					subject := commit.Message
+					if idx := strings.IndexByte(subject, '\n'); idx >= 0 {
+						subject = subject[:idx]
+					}
+					u = &usage{
+						original:   strings.TrimSpace(subject),
+						identities: make(map[string]bool),
+						repos:      make(map[string]bool),
+					}
+					byMessage[key] = u
+				}
+				u.identities[email] = true
+				u.repos[repoLabel(repoName, commits)] = true
+			}
+		}
+	}
+
+	var reused []ReusedMessage
+	for _, u := range byMessage {
+		if len(u.identities) < 2 {
+			continue
+		}
+		reused = append(reused, ReusedMessage{
+			Message:    u.original,
+			Identities: sortedSet(u.identities),
+			Repos:      sortedSet(u.repos),
+		})
+	}
+
+	sort.Slice(reused, func(i, j int) bool {
+		if len(reused[i].Identities) != len(reused[j].Identities) {
+			return len(reused[i].Identities) > len(reused[j].Identities)
+		}
+		return reused[i].Message < reused[j].Message
+	})
+
+	return reused
+}
+
+func sortedSet(m map[string]bool) []string {
+	out := make([]string, 0, len(m))
+	for k := range m {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func displayReusedMessages(emails map[string]*models.EmailDetails) {
+	reused := findReusedMessages(emails)
+	if len(reused) == 0 {
+		return
+	}
+
+	fmt.Println()
+	headerColor.Print("REUSED COMMIT MESSAGES")
+	fmt.Println(" (same message under different identities)")
+	fmt.Println(strings.Repeat("-", 60))
+
+	for i, r := range reused {
+		if i >= 10 {
+			fmt.Printf("... and %s more\n", formatCount(len(reused)-10))
+			break
+		}
+		msg := r.Message
+		if len(msg) > 60 {
+			msg = msg[:60] + "..."
+		}
+		color.Yellow("%q", msg)
+		for _, identity := range r.Identities {
+			fmt.Printf("  %s\n", identity)
+		}
+	}
+}
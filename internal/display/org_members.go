@@ -0,0 +1,37 @@
+package display
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/gnomegl/gitslurp/v2/internal/models"
+)
+
+// orgMemberEmails maps each public member of an org target (--org-members) to
+// the commit emails GitHub attributed to that login. Members who never
+// committed map to no emails.
+func orgMemberEmails(members []string, emails map[string]*models.EmailDetails) map[string][]string {
+	linked := make(map[string][]string, len(members))
+	byLogin := make(map[string]string, len(members))
+	for _, login := range members {
+		linked[login] = nil
+		byLogin[strings.ToLower(login)] = login
+	}
+
+	for email, details := range emails {
+		seen := make(map[string]bool)
+		for _, commits := range details.Commits {
+			for _, commit := range commits {
+				login, ok := byLogin[strings.ToLower(commit.AuthorLogin)]
+				if ok && !seen[login] {
+					seen[login] = true
+					linked[login] = append(linked[login], email)
+				}
+			}
+		}
+	}
+	for _, addrs := range linked {
+		sort.Strings(addrs)
+	}
+	return linked
+}
@@ -0,0 +1,63 @@
+package display
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/gnomegl/gitslurp/v2/internal/models"
+)
+
+func TestOrgMemberEmails(t *testing.T) {
+	commitsBy := func(logins ...string) *models.EmailDetails {
+		d := &models.EmailDetails{Commits: make(map[string][]models.CommitInfo)}
+		for i, login := range logins {
+			repo := "acme/api"
+			if i%2 == 1 {
+				repo = "acme/web"
+			}
+			d.Commits[repo] = append(d.Commits[repo], models.CommitInfo{AuthorLogin: login})
+		}
+		return d
+	}
+	emails := map[string]*models.EmailDetails{
+		"ana@acme.com":       commitsBy("ana", "ana", "ana"),
+		"ana@personal.dev":   commitsBy("Ana"),
+		"bo@acme.com":        commitsBy("bo"),
+		"shared@acme.com":    commitsBy("bo", "cy"),
+		"outsider@gmail.com": commitsBy("outsider"),
+		"unlinked@acme.com":  commitsBy(""),
+	}
+
+	tests := []struct {
+		name    string
+		members []string
+		want    map[string][]string
+	}{
+		{"no members listed", nil, map[string][]string{}},
+		{
+			name:    "members with and without commits",
+			members: []string{"ana", "bo", "dee"},
+			want: map[string][]string{
+				"ana": {"ana@acme.com", "ana@personal.dev"},
+				"bo":  {"bo@acme.com", "shared@acme.com"},
+				"dee": nil,
+			},
+		},
+		{
+			name:    "logins match case-insensitively",
+			members: []string{"ANA", "Cy"},
+			want: map[string][]string{
+				"ANA": {"ana@acme.com", "ana@personal.dev"},
+				"Cy":  {"shared@acme.com"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := orgMemberEmails(tt.members, emails)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("orgMemberEmails = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
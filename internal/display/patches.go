@@ -0,0 +1,59 @@
+package display
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gnomegl/gitslurp/v2/internal/models"
+)
+
+// WritePatches saves the patches of every flagged commit under dir, one file
+// per commit at <dir>/<owner>_<repo>/<hash>.patch. It returns how many files
+// were written. With redact, flagged values are masked in the saved patches.
+func WritePatches(dir string, emails map[string]*models.EmailDetails, redact bool) (int, error) {
+	written := 0
+	seen := make(map[string]bool)
+
+	for _, details := range emails {
+		for repoName, commits := range details.Commits {
+			for _, commit := range commits {
+				if len(commit.Patches) == 0 || commit.Hash == "" {
+					continue
+				}
+
+				repoDir := filepath.Join(dir, strings.ReplaceAll(repoLabel(repoName, commits), "/", "_"))
+				path := filepath.Join(repoDir, commit.Hash+".patch")
+				if seen[path] {
+					continue
+				}
+				seen[path] = true
+
+				if err := os.MkdirAll(repoDir, 0755); err != nil {
+					return written, fmt.Errorf("failed to create patch directory %s: %v", repoDir, err)
+				}
+
+				var content strings.Builder
+				for _, p := range commit.Patches {
+					fmt.Fprintf(&content, "--- %s\n+++ %s\n", p.Filename, p.Filename)
+					patch := p.Patch
+					if redact {
+						patch = redactPatch(patch, commit)
+					}
+					content.WriteString(patch)
+					if !strings.HasSuffix(patch, "\n") {
+						content.WriteString("\n")
+					}
+				}
+
+				if err := os.WriteFile(path, []byte(content.String()), 0644); err != nil {
+					return written, fmt.Errorf("failed to write file %s: %v", path, err)
+				}
+				written++
+			}
+		}
+	}
+
+	return written, nil
+}
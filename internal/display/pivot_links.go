@@ -0,0 +1,37 @@
+package display
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/gnomegl/gitslurp/v2/internal/github"
+)
+
+// PivotLinks prints the external links from the profile's website, bio and
+// README, with their HEAD status when --resolve-links checked them.
+func PivotLinks(links []github.PivotLink) {
+	if len(links) == 0 {
+		return
+	}
+
+	fmt.Println(color.WhiteString("Pivot Links:"))
+	for _, link := range links {
+		fmt.Printf("  %s %s%s\n", link.URL, color.HiBlackString("(%s)", strings.Join(link.Sources, ", ")), pivotStatus(link))
+	}
+	fmt.Println()
+}
+
+func pivotStatus(link github.PivotLink) string {
+	if link.Status == "" {
+		return ""
+	}
+	status := link.Status
+	if link.Resolved != "" {
+		status += " -> " + link.Resolved
+	}
+	if strings.HasPrefix(link.Status, "2") || strings.HasPrefix(link.Status, "3") {
+		return " " + color.GreenString("[%s]", status)
+	}
+	return " " + color.YellowString("[%s]", status)
+}
@@ -2,6 +2,7 @@ package display
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/fatih/color"
 	gh "github.com/google/go-github/v57/github"
@@ -9,7 +10,9 @@ import (
 
 var headerColor = color.New(color.Bold, color.FgCyan)
 
-func UserInfo(user *gh.User, isOrg bool) {
+// UserInfo prints the identity card. accountEmails are the addresses the API
+// reports for the account itself, shown apart from commit-derived emails.
+func UserInfo(user *gh.User, isOrg bool, accountEmails []*gh.UserEmail) {
 	if user == nil {
 		return
 	}
@@ -21,8 +24,29 @@ func UserInfo(user *gh.User, isOrg bool) {
 		headerColor.Printf("USER: %s\n", user.GetLogin())
 	}
 
+	if user.GetID() != 0 {
+		printField("ID", fmt.Sprintf("%d", user.GetID()))
+	}
 	printField("Name", user.GetName())
 	printField("Email", user.GetEmail())
+	if len(accountEmails) > 0 {
+		fmt.Println(color.WhiteString("Account emails:"))
+		for _, e := range accountEmails {
+			var tags []string
+			if e.GetPrimary() {
+				tags = append(tags, "primary")
+			}
+			if e.GetVerified() {
+				tags = append(tags, "verified")
+			} else {
+				tags = append(tags, "unverified")
+			}
+			if e.GetVisibility() != "" {
+				tags = append(tags, e.GetVisibility())
+			}
+			fmt.Printf("  %s (%s)\n", e.GetEmail(), strings.Join(tags, ", "))
+		}
+	}
 	printField("Company", user.GetCompany())
 	printField("Location", user.GetLocation())
 	printField("Bio", user.GetBio())
@@ -34,14 +58,14 @@ func UserInfo(user *gh.User, isOrg bool) {
 	fmt.Println()
 	if isOrg {
 		if user.GetPublicRepos() > 0 {
-			fmt.Printf("%s %d\n", color.WhiteString("Repos:"), user.GetPublicRepos())
+			fmt.Printf("%s %s\n", color.WhiteString("Repos:"), formatCount(user.GetPublicRepos()))
 		}
 	} else {
-		fmt.Printf("%s %d  %s %d  %s %d  %s %d\n",
-			color.WhiteString("Repos:"), user.GetPublicRepos(),
-			color.WhiteString("Gists:"), user.GetPublicGists(),
-			color.WhiteString("Followers:"), user.GetFollowers(),
-			color.WhiteString("Following:"), user.GetFollowing())
+		fmt.Printf("%s %s  %s %s  %s %s  %s %s\n",
+			color.WhiteString("Repos:"), formatCount(user.GetPublicRepos()),
+			color.WhiteString("Gists:"), formatCount(user.GetPublicGists()),
+			color.WhiteString("Followers:"), formatCount(user.GetFollowers()),
+			color.WhiteString("Following:"), formatCount(user.GetFollowing()))
 	}
 
 	if !user.GetCreatedAt().Time.IsZero() || !user.GetUpdatedAt().Time.IsZero() {
@@ -0,0 +1,50 @@
+package display
+
+import (
+	"strings"
+
+	"github.com/gnomegl/gitslurp/v2/internal/models"
+)
+
+// redactValue keeps the first and last 4 characters of a secret value
+// (--redact). Values too short to keep both ends are masked entirely.
+func redactValue(value string) string {
+	runes := []rune(value)
+	if len(runes) <= 8 {
+		return strings.Repeat("*", len(runes))
+	}
+	return string(runes[:4]) + "..." + string(runes[len(runes)-4:])
+}
+
+// redactFinding masks the finding's value, including where it appears in
+// its context lines.
+func redactFinding(f models.SecretFinding) models.SecretFinding {
+	if f.Value == "" {
+		return f
+	}
+	f.Context = strings.ReplaceAll(f.Context, f.Value, redactValue(f.Value))
+	f.Value = redactValue(f.Value)
+	return f
+}
+
+func redactFindings(findings []models.SecretFinding) []models.SecretFinding {
+	if len(findings) == 0 {
+		return findings
+	}
+	out := make([]models.SecretFinding, len(findings))
+	for i, f := range findings {
+		out[i] = redactFinding(f)
+	}
+	return out
+}
+
+// redactPatch masks every value flagged in the commit wherever it appears in
+// one of its patches.
+func redactPatch(patch string, commit models.CommitInfo) string {
+	for _, finding := range commit.Secrets {
+		if finding.Value != "" {
+			patch = strings.ReplaceAll(patch, finding.Value, redactValue(finding.Value))
+		}
+	}
+	return patch
+}
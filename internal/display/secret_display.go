@@ -1,11 +1,17 @@
 package display
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/fatih/color"
+	"github.com/gnomegl/gitslurp/v2/internal/models"
 )
 
+// maxContextLineWidth truncates context lines, which may come from minified
+// files.
+const maxContextLineWidth = 160
+
 type SecretDisplayer struct {
 	secretsShown  map[string]bool
 	patternsShown map[string]bool
@@ -18,10 +24,26 @@ func NewSecretDisplayer() *SecretDisplayer {
 	}
 }
 
-func displaySecretLine(secret string) {
-	if strings.HasPrefix(secret, "INTERESTING:") || strings.HasPrefix(secret, "PATTERN:") {
-		color.Yellow("      PATTERN: %s", secret)
+func displaySecretLine(secret models.SecretFinding, redact bool) {
+	if redact {
+		secret = redactFinding(secret)
+	}
+	if secret.Interesting {
+		color.Yellow("      PATTERN: %s", secret.String())
 	} else {
-		color.Red("      SECRET: %s", secret)
+		color.Red("      SECRET: %s", secret.String())
+	}
+	displaySecretContext(secret.Context)
+}
+
+func displaySecretContext(context string) {
+	if context == "" {
+		return
+	}
+	for _, line := range strings.Split(context, "\n") {
+		if len(line) > maxContextLineWidth {
+			line = line[:maxContextLineWidth] + "..."
+		}
+		fmt.Printf("        %s\n", color.HiBlackString("| %s", line))
 	}
 }
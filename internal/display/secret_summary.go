@@ -0,0 +1,101 @@
+package display
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/gnomegl/gitslurp/v2/internal/models"
+	"github.com/gnomegl/gitslurp/v2/internal/scanner"
+)
+
+type AffectedRepo struct {
+	Repo        string
+	Score       int
+	Secrets     int
+	Interesting int
+	TopPattern  string
+}
+
+// rankAffectedRepos scores every repository with findings by the summed
+// severity of its distinct findings, highest first.
+func rankAffectedRepos(emails map[string]*models.EmailDetails) []AffectedRepo {
+	seen := make(map[string]map[string]bool)
+	byRepo := make(map[string]*AffectedRepo)
+	topWeight := make(map[string]int)
+
+	for _, details := range emails {
+		for repoName, commits := range details.Commits {
+			for _, commit := range commits {
+				for _, finding := range commit.Secrets {
+					if seen[repoName] == nil {
+						seen[repoName] = make(map[string]bool)
+					}
+					key := finding.Name + "\x00" + finding.Value + "\x00" + finding.Location
+					if seen[repoName][key] {
+						continue
+					}
+					seen[repoName][key] = true
+
+					repo, ok := byRepo[repoName]
+					if !ok {
+						repo = &AffectedRepo{Repo: repoLabel(repoName, commits)}
+						byRepo[repoName] = repo
+					}
+
+					name, interesting := finding.Name, finding.Interesting
+					weight := scanner.Severity(name, interesting)
+					repo.Score += weight
+					if interesting {
+						repo.Interesting++
+					} else {
+						repo.Secrets++
+					}
+					if weight > topWeight[repoName] {
+						topWeight[repoName] = weight
+						repo.TopPattern = name
+					}
+				}
+			}
+		}
+	}
+
+	ranked := make([]AffectedRepo, 0, len(byRepo))
+	for _, repo := range byRepo {
+		ranked = append(ranked, *repo)
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].Score != ranked[j].Score {
+			return ranked[i].Score > ranked[j].Score
+		}
+		return ranked[i].Repo < ranked[j].Repo
+	})
+	return ranked
+}
+
+func displayAffectedRepos(emails map[string]*models.EmailDetails) {
+	ranked := rankAffectedRepos(emails)
+	if len(ranked) == 0 {
+		return
+	}
+
+	fmt.Println()
+	headerColor.Print("AFFECTED REPOSITORIES")
+	fmt.Println(" (ranked by finding severity)")
+	fmt.Println(strings.Repeat("-", 60))
+
+	for i, repo := range ranked {
+		if i >= 10 {
+			fmt.Printf("... and %s more repositories with findings\n", formatCount(len(ranked)-10))
+			break
+		}
+		line := fmt.Sprintf("%2d. %s  score %s: %s secrets, %s patterns", i+1, repo.Repo,
+			formatCount(repo.Score), formatCount(repo.Secrets), formatCount(repo.Interesting))
+		if repo.Secrets > 0 {
+			color.Red("%s (worst: %s)", line, repo.TopPattern)
+		} else {
+			color.Yellow("%s", line)
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package display
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/gnomegl/gitslurp/v2/internal/models"
+	"golang.org/x/term"
+)
+
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// monthlyActivity buckets the target's commits by AuthorDate month, from the
+// first active month to the last. Months without commits are kept as zeros.
+func monthlyActivity(emails map[string]*models.EmailDetails, userIdentifiers map[string]bool) (time.Time, []int) {
+	byMonth := make(map[time.Time]int)
+	var first, last time.Time
+
+	for email, details := range emails {
+		if !isTargetIdentity(email, details, userIdentifiers) {
+			continue
+		}
+		for _, commits := range details.Commits {
+			for _, commit := range commits {
+				if commit.AuthorDate.IsZero() {
+					continue
+				}
+				d := commit.AuthorDate.UTC()
+				month := time.Date(d.Year(), d.Month(), 1, 0, 0, 0, 0, time.UTC)
+				byMonth[month]++
+				if first.IsZero() || month.Before(first) {
+					first = month
+				}
+				if month.After(last) {
+					last = month
+				}
+			}
+		}
+	}
+
+	if first.IsZero() {
+		return first, nil
+	}
+
+	var counts []int
+	for m := first; !m.After(last); m = m.AddDate(0, 1, 0) {
+		counts = append(counts, byMonth[m])
+	}
+	return first, counts
+}
+
+func isTargetIdentity(email string, details *models.EmailDetails, userIdentifiers map[string]bool) bool {
+	if userIdentifiers[email] {
+		return true
+	}
+	for name := range details.Names {
+		if userIdentifiers[name] {
+			return true
+		}
+	}
+	return false
+}
+
+// renderSparkline draws one block per bucket, merging adjacent months when
+// there are more of them than width allows.
+func renderSparkline(counts []int, width int) string {
+	if width < 1 {
+		width = 1
+	}
+	group := (len(counts) + width - 1) / width
+	if group < 1 {
+		group = 1
+	}
+
+	var buckets []int
+	maxCount := 0
+	for i := 0; i < len(counts); i += group {
+		sum := 0
+		for j := i; j < i+group && j < len(counts); j++ {
+			sum += counts[j]
+		}
+		buckets = append(buckets, sum)
+		if sum > maxCount {
+			maxCount = sum
+		}
+	}
+
+	var sb strings.Builder
+	for _, n := range buckets {
+		if n == 0 || maxCount == 0 {
+			sb.WriteRune(' ')
+			continue
+		}
+		idx := n * (len(sparkBlocks) - 1) / maxCount
+		sb.WriteRune(sparkBlocks[idx])
+	}
+	return sb.String()
+}
+
+func terminalWidth() int {
+	if w, _, err := term.GetSize(int(os.Stdout.Fd())); err == nil && w > 0 {
+		return w
+	}
+	return 80
+}
+
+func displayActivitySparkline(emails map[string]*models.EmailDetails, userIdentifiers map[string]bool) {
+	if color.NoColor {
+		return
+	}
+
+	start, counts := monthlyActivity(emails, userIdentifiers)
+	if len(counts) < 2 {
+		return
+	}
+
+	peak := 0
+	for _, n := range counts {
+		if n > peak {
+			peak = n
+		}
+	}
+	end := start.AddDate(0, len(counts)-1, 0)
+
+	fmt.Println()
+	fmt.Printf("%s %s to %s (peak %s commits/month)\n", color.WhiteString("Monthly activity:"),
+		start.Format("2006-01"), end.Format("2006-01"), formatCount(peak))
+	fmt.Println(color.CyanString(renderSparkline(counts, terminalWidth()-2)))
+}
@@ -9,10 +9,11 @@ import (
 	"github.com/gnomegl/gitslurp/v2/internal/models"
 )
 
-func displayRepositoryStats(emails map[string]*models.EmailDetails, userIdentifiers map[string]bool) {
+func displayRepositoryStats(emails map[string]*models.EmailDetails, userIdentifiers map[string]bool, maxNames int) {
 	ownRepos := make(map[string]bool)
+	orgRepos := make(map[string]bool)
 	externalRepos := make(map[string]bool)
-	var externalCommits, ownCommits int
+	var externalCommits, orgCommits, ownCommits int
 
 	externalEmailData := make(map[string]map[string]int)
 
@@ -30,9 +31,14 @@ func displayRepositoryStats(emails map[string]*models.EmailDetails, userIdentifi
 		if isTargetUser {
 			for repo, commits := range details.Commits {
 				for _, commit := range commits {
-					if commit.IsExternal {
-						externalRepos[repo] = true
-						externalCommits++
+					if commit.IsExternal || commit.IsOrgRepo {
+						if commit.IsOrgRepo {
+							orgRepos[repo] = true
+							orgCommits++
+						} else {
+							externalRepos[repo] = true
+							externalCommits++
+						}
 
 						if externalEmailData[email] == nil {
 							externalEmailData[email] = make(map[string]int)
@@ -47,19 +53,22 @@ func displayRepositoryStats(emails map[string]*models.EmailDetails, userIdentifi
 		}
 	}
 
-	if len(externalRepos) == 0 {
+	if len(externalRepos) == 0 && len(orgRepos) == 0 {
 		return
 	}
 
 	fmt.Println()
 	headerColor.Println("EXTERNAL CONTRIBUTIONS")
 	fmt.Println(strings.Repeat("-", 60))
-	fmt.Printf("%s %d\n", color.WhiteString("External repositories:"), len(externalRepos))
-	fmt.Printf("%s %d\n", color.WhiteString("External commits:"), externalCommits)
-	fmt.Printf("%s %d\n", color.WhiteString("Own repo commits:"), ownCommits)
-	if ownCommits+externalCommits > 0 {
-		percentage := float64(externalCommits) / float64(externalCommits+ownCommits) * 100
-		fmt.Printf("%s %.1f%%\n", color.WhiteString("External %:"), percentage)
+	fmt.Printf("%s %s\n", color.WhiteString("External repositories:"), formatCount(len(externalRepos)))
+	fmt.Printf("%s %s\n", color.WhiteString("External commits:"), formatCount(externalCommits))
+	if len(orgRepos) > 0 {
+		fmt.Printf("%s %s\n", color.WhiteString("Member org repositories:"), formatCount(len(orgRepos)))
+		fmt.Printf("%s %s\n", color.WhiteString("Member org commits:"), formatCount(orgCommits))
+	}
+	fmt.Printf("%s %s\n", color.WhiteString("Own repo commits:"), formatCount(ownCommits))
+	if total := ownCommits + orgCommits + externalCommits; total > 0 {
+		fmt.Printf("%s %s\n", color.WhiteString("External %:"), formatPercent(percentOf(externalCommits, total)))
 	}
 	fmt.Println()
 
@@ -76,14 +85,14 @@ func displayRepositoryStats(emails map[string]*models.EmailDetails, userIdentifi
 
 		color.Green("%s", email)
 		if len(names) > 0 {
-			fmt.Printf("  Names: %s\n", strings.Join(names, ", "))
+			fmt.Printf("  Names: %s\n", joinNames(names, maxNames))
 		}
 
 		var totalRepoCommits int
 		for _, count := range repoMap {
 			totalRepoCommits += count
 		}
-		fmt.Printf("  %s\n", color.WhiteString("Repositories (%d commits total):", totalRepoCommits))
+		fmt.Printf("  %s\n", color.WhiteString("Repositories (%s commits total):", formatCount(totalRepoCommits)))
 
 		repoNames := make([]string, 0, len(repoMap))
 		for repo := range repoMap {
@@ -93,14 +102,18 @@ func displayRepositoryStats(emails map[string]*models.EmailDetails, userIdentifi
 
 		for _, repo := range repoNames {
 			commitCount := repoMap[repo]
-			fmt.Printf("    - %s (%d commits)\n", repo, commitCount)
+			if orgRepos[repo] {
+				fmt.Printf("    - %s (%s commits, member org)\n", repo, formatCount(commitCount))
+			} else {
+				fmt.Printf("    - %s (%s commits)\n", repo, formatCount(commitCount))
+			}
 		}
 		fmt.Println()
 	}
 }
 
-func displaySummary(targetAccounts, similarAccounts, orgMembers, similarOrgMembers map[string][]string, isOrg bool, orgDomain string, totalCommits, totalContributors int) {
-	if len(targetAccounts) == 0 && len(similarAccounts) == 0 && len(orgMembers) == 0 && len(similarOrgMembers) == 0 {
+func displaySummary(targetAccounts, similarAccounts, orgMembers, similarOrgMembers, publicMembers map[string][]string, machineAccounts map[string]string, isOrg bool, orgDomain string, totalCommits, totalContributors, maxNames int) {
+	if len(targetAccounts) == 0 && len(similarAccounts) == 0 && len(orgMembers) == 0 && len(similarOrgMembers) == 0 && len(publicMembers) == 0 && len(machineAccounts) == 0 {
 		return
 	}
 
@@ -115,7 +128,7 @@ func displaySummary(targetAccounts, similarAccounts, orgMembers, similarOrgMembe
 		for email, names := range targetAccounts {
 			color.Green("%s", email)
 			if len(names) > 0 {
-				fmt.Printf("  Names: %s\n", strings.Join(names, ", "))
+				fmt.Printf("  Names: %s\n", joinNames(names, maxNames))
 			}
 		}
 	}
@@ -128,18 +141,18 @@ func displaySummary(targetAccounts, similarAccounts, orgMembers, similarOrgMembe
 		i := 0
 		for email, names := range similarAccounts {
 			if i >= 10 {
-				fmt.Printf("  ... and %d more similar accounts\n", len(similarAccounts)-10)
+				fmt.Printf("  ... and %s more similar accounts\n", formatCount(len(similarAccounts)-10))
 				break
 			}
 			color.Yellow("%s", email)
 			if len(names) > 0 {
-				fmt.Printf("  Names: %s\n", strings.Join(names, ", "))
+				fmt.Printf("  Names: %s\n", joinNames(names, maxNames))
 			}
 			i++
 		}
 	}
 
-	if isOrg && (len(orgMembers) > 0 || len(similarOrgMembers) > 0) {
+	if isOrg && (len(orgMembers) > 0 || len(similarOrgMembers) > 0 || len(publicMembers) > 0) {
 		fmt.Println()
 		if orgDomain != "" {
 			fmt.Printf("Organization Members (@%s):\n", orgDomain)
@@ -153,7 +166,7 @@ func displaySummary(targetAccounts, similarAccounts, orgMembers, similarOrgMembe
 			for email, names := range similarOrgMembers {
 				color.Yellow("  %s", email)
 				if len(names) > 0 {
-					fmt.Printf("    Names: %s\n", strings.Join(names, ", "))
+					fmt.Printf("    Names: %s\n", joinNames(names, maxNames))
 				}
 			}
 		}
@@ -165,15 +178,48 @@ func displaySummary(targetAccounts, similarAccounts, orgMembers, similarOrgMembe
 			for email, names := range orgMembers {
 				color.Yellow("  %s", email)
 				if len(names) > 0 {
-					fmt.Printf("    Names: %s\n", strings.Join(names, ", "))
+					fmt.Printf("    Names: %s\n", joinNames(names, maxNames))
+				}
+			}
+		}
+
+		if len(publicMembers) > 0 {
+			fmt.Printf("\nPublic Members (%s):\n", formatCount(len(publicMembers)))
+			logins := make([]string, 0, len(publicMembers))
+			for login := range publicMembers {
+				logins = append(logins, login)
+			}
+			sort.Slice(logins, func(i, j int) bool { return strings.ToLower(logins[i]) < strings.ToLower(logins[j]) })
+			for _, login := range logins {
+				if addrs := publicMembers[login]; len(addrs) > 0 {
+					fmt.Printf("  %s  %s\n", color.CyanString(login), strings.Join(addrs, ", "))
+				} else {
+					fmt.Printf("  %s  %s\n", color.CyanString(login), color.HiBlackString("(no commits found)"))
 				}
 			}
 		}
 	}
 
+	if len(machineAccounts) > 0 {
+		fmt.Println()
+		color.New(color.Bold, color.FgHiBlack).Print("Shared/Machine Accounts:")
+		fmt.Println(" (CI or build identities, not counted as people)")
+		machineEmails := make([]string, 0, len(machineAccounts))
+		for email := range machineAccounts {
+			machineEmails = append(machineEmails, email)
+		}
+		sort.Strings(machineEmails)
+		for _, email := range machineEmails {
+			fmt.Printf("  %s (%s)\n", email, machineAccounts[email])
+		}
+	}
+
 	fmt.Println()
-	fmt.Printf("%s %d\n", color.WhiteString("Target accounts:"), len(targetAccounts))
-	fmt.Printf("%s %d\n", color.WhiteString("Similar accounts:"), len(similarAccounts))
-	fmt.Printf("%s %d\n", color.WhiteString("Total target commits:"), totalCommits)
-	fmt.Printf("%s %d\n", color.WhiteString("Total contributors:"), totalContributors)
+	fmt.Printf("%s %s\n", color.WhiteString("Target accounts:"), formatCount(len(targetAccounts)))
+	fmt.Printf("%s %s\n", color.WhiteString("Similar accounts:"), formatCount(len(similarAccounts)))
+	fmt.Printf("%s %s\n", color.WhiteString("Total target commits:"), formatCount(totalCommits))
+	fmt.Printf("%s %s\n", color.WhiteString("Total contributors:"), formatCount(totalContributors))
+	if len(machineAccounts) > 0 {
+		fmt.Printf("%s %s\n", color.WhiteString("Shared/machine accounts:"), formatCount(len(machineAccounts)))
+	}
 }
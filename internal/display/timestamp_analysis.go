@@ -11,41 +11,36 @@ import (
 	"github.com/gnomegl/gitslurp/v2/internal/utils"
 )
 
-func displayTimestampAnalysis(emails map[string]*models.EmailDetails, userIdentifiers map[string]bool) {
+// targetCommitsByEmail gathers the commits of every target identity, keyed by
+// email, along with all of them combined.
+func targetCommitsByEmail(emails map[string]*models.EmailDetails, userIdentifiers map[string]bool) (map[string][]models.CommitInfo, []models.CommitInfo) {
 	targetCommits := make(map[string][]models.CommitInfo)
+	var allTargetCommits []models.CommitInfo
 
 	for email, details := range emails {
-		isTargetUser := userIdentifiers[email]
-		if !isTargetUser {
-			for name := range details.Names {
-				if userIdentifiers[name] {
-					isTargetUser = true
-					break
-				}
-			}
+		if !isTargetIdentity(email, details, userIdentifiers) {
+			continue
 		}
-
-		if isTargetUser {
-			for _, commits := range details.Commits {
-				targetCommits[email] = append(targetCommits[email], commits...)
-			}
+		for _, commits := range details.Commits {
+			targetCommits[email] = append(targetCommits[email], commits...)
+			allTargetCommits = append(allTargetCommits, commits...)
 		}
 	}
 
+	return targetCommits, allTargetCommits
+}
+
+func displayTimestampAnalysis(emails map[string]*models.EmailDetails, userIdentifiers map[string]bool, hashLength int) {
+	targetCommits, allTargetCommits := targetCommitsByEmail(emails, userIdentifiers)
 	if len(targetCommits) == 0 {
 		return
 	}
 
-	var allTargetCommits []models.CommitInfo
-	for _, commits := range targetCommits {
-		allTargetCommits = append(allTargetCommits, commits...)
-	}
-
 	patterns := utils.GetTimestampPatterns(allTargetCommits)
 
 	fmt.Println()
 	headerColor.Printf("TIMESTAMP ANALYSIS")
-	fmt.Printf(" (%d commits)\n", patterns["total_commits"])
+	fmt.Printf(" (%s commits)\n", formatCount(patterns["total_commits"].(int)))
 	fmt.Println(strings.Repeat("-", 40))
 
 	displayGeneralPatterns(patterns)
@@ -55,30 +50,45 @@ func displayTimestampAnalysis(emails map[string]*models.EmailDetails, userIdenti
 		displayAggregatedHourlyGraph(patterns)
 	}
 
+	displayCalendarHeatmap(allTargetCommits)
+
 	for email, commits := range targetCommits {
 		if len(commits) >= 3 {
 			displayUserTimestampAnalysis(email, commits)
 		}
 	}
 
-	displaySuspiciousPatterns(allTargetCommits)
+	displayActivityGaps(emails, userIdentifiers)
+	displaySuspiciousPatterns(allTargetCommits, hashLength)
 }
 
 func displayGeneralPatterns(patterns map[string]interface{}) {
 	if unusualPct, ok := patterns["unusual_hour_percentage"].(float64); ok && unusualPct > 0 {
-		color.Yellow("Unusual hours (10pm-6am): %.1f%%", unusualPct)
+		color.Yellow("Unusual hours (10pm-6am): %s", formatPercent(unusualPct))
 	}
 
 	if weekendPct, ok := patterns["weekend_percentage"].(float64); ok && weekendPct > 0 {
-		color.Cyan("Weekend commits: %.1f%%", weekendPct)
+		color.Cyan("Weekend commits: %s", formatPercent(weekendPct))
 	}
 
 	if nightOwlPct, ok := patterns["night_owl_percentage"].(float64); ok && nightOwlPct > 10 {
-		color.Blue("Night owl (10pm-2am): %.1f%%", nightOwlPct)
+		color.Blue("Night owl (10pm-2am): %s", formatPercent(nightOwlPct))
 	}
 
 	if earlyBirdPct, ok := patterns["early_bird_percentage"].(float64); ok && earlyBirdPct > 10 {
-		color.Green("Early bird (5am-7am): %.1f%%", earlyBirdPct)
+		color.Green("Early bird (5am-7am): %s", formatPercent(earlyBirdPct))
+	}
+
+	if holidayPct, ok := patterns["holiday_percentage"].(float64); ok {
+		color.Cyan("Public holiday commits: %s", formatPercent(holidayPct))
+	}
+
+	if longest, ok := patterns["longest_gap_days"].(int); ok && longest > 0 {
+		fmt.Printf("%s %s days", color.WhiteString("Longest break:"), formatCount(longest))
+		if n, _ := patterns["long_gap_count"].(int); n > 0 {
+			fmt.Printf(" (%s breaks over 14 days)", formatCount(n))
+		}
+		fmt.Println()
 	}
 
 	if mostActiveHour, ok := patterns["most_active_hour"].(int); ok {
@@ -93,12 +103,20 @@ func displayGeneralPatterns(patterns map[string]interface{}) {
 		fmt.Printf("%s %s\n", color.WhiteString("Most common timezone:"), mostActiveTZ)
 	}
 
+	if offset, ok := patterns["inferred_utc_offset"].(int); ok {
+		fmt.Printf("%s %s (from the quietest commit hours)\n", color.WhiteString("Inferred timezone:"), formatUTCOffset(offset))
+	}
+
 	if tzDist, ok := patterns["timezone_distribution"].(map[string]int); ok && len(tzDist) > 1 {
 		color.Yellow("Timezones: %d detected", len(tzDist))
 		displayTimezoneDistribution(tzDist)
 	}
 }
 
+func formatUTCOffset(hours int) string {
+	return fmt.Sprintf("UTC%+03d:00", hours)
+}
+
 func displayTimezoneDistribution(tzDist map[string]int) {
 	type tzEntry struct {
 		zone  string
@@ -118,7 +136,7 @@ func displayTimezoneDistribution(tzDist map[string]int) {
 		if i >= 3 {
 			break
 		}
-		fmt.Printf("  %s: %d commits\n", zone.zone, zone.count)
+		fmt.Printf("  %s: %s commits\n", zone.zone, formatCount(zone.count))
 	}
 }
 
@@ -126,26 +144,33 @@ func displayUserTimestampAnalysis(email string, commits []models.CommitInfo) {
 	patterns := utils.GetTimestampPatterns(commits)
 
 	fmt.Println()
-	fmt.Printf("%s (%d commits):\n", color.WhiteString(email), len(commits))
+	fmt.Printf("%s (%s commits):\n", color.WhiteString(email), formatCount(len(commits)))
 
 	if mostActiveTZ, ok := patterns["most_active_timezone"].(string); ok && mostActiveTZ != "" {
 		fmt.Printf("  Primary timezone: %s\n", mostActiveTZ)
 	}
 
+	if offset, ok := patterns["inferred_utc_offset"].(int); ok {
+		fmt.Printf("  Inferred timezone: %s\n", formatUTCOffset(offset))
+		if stated, ok := patterns["stated_utc_offset"].(int); ok && patterns["timezone_mismatch"] == true {
+			color.Yellow("  ⚠️  Stated %s but activity suggests %s (possible location spoof)", formatUTCOffset(stated), formatUTCOffset(offset))
+		}
+	}
+
 	if tzDist, ok := patterns["timezone_distribution"].(map[string]int); ok && len(tzDist) > 1 {
 		color.Yellow("  Multiple timezones: %d zones detected", len(tzDist))
 	}
 
 	if unusualPct, ok := patterns["unusual_hour_percentage"].(float64); ok && unusualPct > 30 {
-		color.Yellow("  %.1f%% unusual hour commits (in stated timezone)", unusualPct)
+		color.Yellow("  %s unusual hour commits (in stated timezone)", formatPercent(unusualPct))
 	}
 
 	if nightOwlPct, ok := patterns["night_owl_percentage"].(float64); ok && nightOwlPct > 20 {
-		color.Blue("  %.1f%% night owl pattern (10pm-2am local)", nightOwlPct)
+		color.Blue("  %s night owl pattern (10pm-2am local)", formatPercent(nightOwlPct))
 	}
 
 	if earlyBirdPct, ok := patterns["early_bird_percentage"].(float64); ok && earlyBirdPct > 20 {
-		color.Green("  %.1f%% early bird pattern (5am-7am local)", earlyBirdPct)
+		color.Green("  %s early bird pattern (5am-7am local)", formatPercent(earlyBirdPct))
 	}
 
 	if mostActiveHour, ok := patterns["most_active_hour"].(int); ok {
@@ -202,7 +227,123 @@ func displayAggregatedHourlyGraph(patterns map[string]interface{}) {
 	}
 }
 
-func displaySuspiciousPatterns(commits []models.CommitInfo) {
+// heatBlocks shade calendar days from no commits to the busiest day.
+var heatBlocks = []string{"·", "░", "▒", "▓", "█"}
+
+// calendarGrid counts commits per UTC day in week columns, Sunday to
+// Saturday, from the week of the first dated commit to the week of the last.
+// start is the Sunday the first column begins on.
+func calendarGrid(commits []models.CommitInfo) (start time.Time, weeks [][7]int) {
+	byDay := make(map[time.Time]int)
+	var first, last time.Time
+	for _, commit := range commits {
+		if commit.AuthorDate.IsZero() {
+			continue
+		}
+		d := commit.AuthorDate.UTC()
+		day := time.Date(d.Year(), d.Month(), d.Day(), 0, 0, 0, 0, time.UTC)
+		byDay[day]++
+		if first.IsZero() || day.Before(first) {
+			first = day
+		}
+		if day.After(last) {
+			last = day
+		}
+	}
+	if first.IsZero() {
+		return first, nil
+	}
+
+	start = first.AddDate(0, 0, -int(first.Weekday()))
+	for week := start; !week.After(last); week = week.AddDate(0, 0, 7) {
+		var column [7]int
+		for i := range column {
+			column[i] = byDay[week.AddDate(0, 0, i)]
+		}
+		weeks = append(weeks, column)
+	}
+	return start, weeks
+}
+
+// displayCalendarHeatmap draws a GitHub-style grid of the target's commits,
+// one column per week. When the span is wider than the terminal only the
+// most recent weeks are shown.
+func displayCalendarHeatmap(commits []models.CommitInfo) {
+	start, weeks := calendarGrid(commits)
+	if len(weeks) == 0 {
+		return
+	}
+
+	const labelWidth = 4
+	if fit := terminalWidth() - labelWidth - 1; len(weeks) > fit && fit > 0 {
+		start = start.AddDate(0, 0, 7*(len(weeks)-fit))
+		weeks = weeks[len(weeks)-fit:]
+	}
+
+	peak, activeDays := 0, 0
+	for _, column := range weeks {
+		for _, n := range column {
+			if n > 0 {
+				activeDays++
+			}
+			if n > peak {
+				peak = n
+			}
+		}
+	}
+	// a single active day makes no pattern worth a grid
+	if activeDays < 2 {
+		return
+	}
+
+	end := start.AddDate(0, 0, 7*len(weeks)-1)
+	fmt.Println()
+	fmt.Printf("%s %s to %s (peak %s commits/day)\n", color.WhiteString("Commit calendar:"),
+		start.Format("2006-01-02"), end.Format("2006-01-02"), formatCount(peak))
+
+	// month names over the first week of each month, where they fit
+	months := []rune(strings.Repeat(" ", len(weeks)+3))
+	free := 0
+	for i := range weeks {
+		week := start.AddDate(0, 0, 7*i)
+		if i >= free && (i == 0 || week.Day() <= 7) {
+			copy(months[i:], []rune(week.Format("Jan")))
+			free = i + 4
+		}
+	}
+	fmt.Printf("%*s%s\n", labelWidth, "", strings.TrimRight(string(months), " "))
+
+	labels := [7]string{"", "Mon", "", "Wed", "", "Fri", ""}
+	for day := 0; day < 7; day++ {
+		var sb strings.Builder
+		for _, column := range weeks {
+			sb.WriteString(heatCell(column[day], peak))
+		}
+		fmt.Printf("%-*s%s\n", labelWidth, labels[day], sb.String())
+	}
+	fmt.Printf("%*sless %s more\n", labelWidth, "", strings.Join(heatLegend(), " "))
+}
+
+func heatCell(n, peak int) string {
+	if n == 0 {
+		return color.HiBlackString(heatBlocks[0])
+	}
+	level := (n*(len(heatBlocks)-1) + peak - 1) / peak
+	if level == len(heatBlocks)-1 {
+		return color.HiGreenString(heatBlocks[level])
+	}
+	return color.GreenString(heatBlocks[level])
+}
+
+func heatLegend() []string {
+	legend := []string{color.HiBlackString(heatBlocks[0])}
+	for _, block := range heatBlocks[1 : len(heatBlocks)-1] {
+		legend = append(legend, color.GreenString(block))
+	}
+	return append(legend, color.HiGreenString(heatBlocks[len(heatBlocks)-1]))
+}
+
+func displaySuspiciousPatterns(commits []models.CommitInfo, hashLength int) {
 	suspiciousCommits := make([]models.CommitInfo, 0)
 
 	for _, commit := range commits {
@@ -225,12 +366,79 @@ func displaySuspiciousPatterns(commits []models.CommitInfo) {
 			}
 
 			localTimeStr := commit.AuthorDate.Format("2006-01-02 15:04:05")
-			color.Yellow("  %s at %s (%s)", commit.Hash[:8], localTimeStr, commit.TimestampAnalysis.CommitTimezone)
+			color.Yellow("  %s at %s (%s)", shortHash(commit.Hash, hashLength), localTimeStr, commit.TimestampAnalysis.CommitTimezone)
 			if commit.TimestampAnalysis.TimeZoneHint != "" {
 				fmt.Printf("    %s\n", commit.TimestampAnalysis.TimeZoneHint)
 			}
 		}
 	} else if len(suspiciousCommits) > 15 {
-		fmt.Printf("\nFound %d unusual hour commits (showing pattern summary above)\n", len(suspiciousCommits))
+		fmt.Printf("\nFound %s unusual hour commits (showing pattern summary above)\n", formatCount(len(suspiciousCommits)))
+	}
+}
+
+// activityAnalysis is the JSON form of the timestamp analysis: the combined
+// patterns of all target identities plus one entry per identity.
+func activityAnalysis(emails map[string]*models.EmailDetails, userIdentifiers map[string]bool) *JSONActivityAnalysis {
+	targetCommits, allTargetCommits := targetCommitsByEmail(emails, userIdentifiers)
+	if len(allTargetCommits) == 0 {
+		return nil
+	}
+
+	analysis := &JSONActivityAnalysis{
+		Aggregate:   jsonActivityPatterns(utils.GetTimestampPatterns(allTargetCommits)),
+		PerIdentity: make(map[string]JSONActivityPatterns, len(targetCommits)),
+	}
+	for email, commits := range targetCommits {
+		analysis.PerIdentity[email] = jsonActivityPatterns(utils.GetTimestampPatterns(commits))
+	}
+	return analysis
+}
+
+func jsonActivityPatterns(patterns map[string]interface{}) JSONActivityPatterns {
+	out := JSONActivityPatterns{
+		DayDistribution:      make(map[string]int),
+		TimezoneDistribution: make(map[string]int),
+	}
+
+	out.TotalCommits, _ = patterns["total_commits"].(int)
+	out.UnusualHourPercentage, _ = patterns["unusual_hour_percentage"].(float64)
+	out.WeekendPercentage, _ = patterns["weekend_percentage"].(float64)
+	out.NightOwlPercentage, _ = patterns["night_owl_percentage"].(float64)
+	out.EarlyBirdPercentage, _ = patterns["early_bird_percentage"].(float64)
+	if pct, ok := patterns["holiday_percentage"].(float64); ok {
+		out.HolidayPercentage = &pct
+	}
+	out.LongestGapDays, _ = patterns["longest_gap_days"].(int)
+	out.LongGapCount, _ = patterns["long_gap_count"].(int)
+	out.MostActiveHour, _ = patterns["most_active_hour"].(int)
+	out.MostActiveTimezone, _ = patterns["most_active_timezone"].(string)
+	if offset, ok := patterns["stated_utc_offset"].(int); ok {
+		out.StatedUTCOffset = &offset
+	}
+	if offset, ok := patterns["inferred_utc_offset"].(int); ok {
+		out.InferredUTCOffset = &offset
+	}
+	out.TimezoneMismatch, _ = patterns["timezone_mismatch"].(bool)
+	if day, ok := patterns["most_active_day"].(time.Weekday); ok {
+		out.MostActiveDay = day.String()
+	}
+
+	if hours, ok := patterns["hour_distribution"].(map[int]int); ok {
+		for hour, n := range hours {
+			if hour >= 0 && hour < len(out.HourDistribution) {
+				out.HourDistribution[hour] = n
+			}
+		}
+	}
+	if days, ok := patterns["day_distribution"].(map[time.Weekday]int); ok {
+		for day, n := range days {
+			out.DayDistribution[day.String()] = n
+		}
+	}
+	if zones, ok := patterns["timezone_distribution"].(map[string]int); ok {
+		for zone, n := range zones {
+			out.TimezoneDistribution[zone] = n
+		}
 	}
+	return out
 }
@@ -0,0 +1,82 @@
+package display
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gnomegl/gitslurp/v2/internal/models"
+)
+
+func TestCalendarGrid(t *testing.T) {
+	at := func(dates ...string) []models.CommitInfo {
+		var commits []models.CommitInfo
+		for _, d := range dates {
+			when, err := time.Parse(time.RFC3339, d)
+			if err != nil {
+				t.Fatal(err)
+			}
+			commits = append(commits, models.CommitInfo{AuthorDate: when})
+		}
+		return commits
+	}
+
+	tests := []struct {
+		name    string
+		commits []models.CommitInfo
+		start   string
+		weeks   int
+		cells   map[[2]int]int // week, weekday -> commits
+	}{
+		{name: "no dated commits", commits: []models.CommitInfo{{Hash: "a"}}},
+		{
+			name:    "one week",
+			commits: at("2024-03-04T10:00:00Z", "2024-03-04T18:00:00Z", "2024-03-09T09:00:00Z"),
+			start:   "2024-03-03", weeks: 1,
+			cells: map[[2]int]int{{0, 1}: 2, {0, 6}: 1},
+		},
+		{
+			name:    "span of several weeks, sparse",
+			commits: at("2024-01-31T12:00:00Z", "2024-03-01T12:00:00Z"),
+			start:   "2024-01-28", weeks: 5,
+			cells: map[[2]int]int{{0, 3}: 1, {4, 5}: 1, {2, 3}: 0},
+		},
+		{
+			name:    "days are UTC",
+			commits: at("2024-03-09T23:30:00-05:00", "2024-03-02T12:00:00Z"),
+			start:   "2024-02-25", weeks: 3,
+			cells: map[[2]int]int{{0, 6}: 1, {2, 0}: 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, weeks := calendarGrid(tt.commits)
+			if len(weeks) != tt.weeks {
+				t.Fatalf("%d weeks, want %d", len(weeks), tt.weeks)
+			}
+			if tt.weeks == 0 {
+				return
+			}
+			if got := start.Format("2006-01-02"); got != tt.start || start.Weekday() != time.Sunday {
+				t.Errorf("grid starts %s (%s), want Sunday %s", got, start.Weekday(), tt.start)
+			}
+			total := 0
+			for _, column := range weeks {
+				for _, n := range column {
+					total += n
+				}
+			}
+			if total != len(tt.commits) {
+				t.Errorf("grid holds %d commits, want %d", total, len(tt.commits))
+			}
+			for cell, want := range tt.cells {
+				if got := weeks[cell[0]][cell[1]]; got != want {
+					t.Errorf("week %d day %d = %d, want %d", cell[0], cell[1], got, want)
+				}
+			}
+		})
+	}
+
+	// sparse data draws nothing rather than failing
+	displayCalendarHeatmap(at("2024-03-04T10:00:00Z"))
+	displayCalendarHeatmap(nil)
+}
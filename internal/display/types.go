@@ -15,6 +15,7 @@ type Context struct {
 	LookupEmail     string
 	KnownUsername   string
 	User            *gh.User
+	AccountEmails   []*gh.UserEmail
 	ShowTargetOnly  bool
 	IsOrg           bool
 	Cfg             *github.Config
@@ -39,6 +40,7 @@ type DisplayOptions struct {
 	CheckSecrets    bool
 	ShowInteresting bool
 	ShowTargetOnly  bool
+	FindLinks       bool
 }
 
 type EmailProcessResult struct {
@@ -48,6 +50,7 @@ type EmailProcessResult struct {
 	similarAccounts   map[string][]string
 	orgMembers        map[string][]string
 	similarOrgMembers map[string][]string
+	machineAccounts   map[string]string
 }
 
 type NDJSONMeta struct {
@@ -58,8 +61,126 @@ type NDJSONMeta struct {
 	TotalContributors int       `json:"total_contributors"`
 }
 
+// NDJSONAnalysis is the trailing NDJSON record holding cross-identity analysis
+// that can only be computed once every commit has been collected.
+type NDJSONAnalysis struct {
+	ReusedMessages  []JSONReusedMessage   `json:"reused_messages,omitempty"`
+	MonthlyActivity *JSONMonthlyActivity  `json:"monthly_activity,omitempty"`
+	AffectedRepos   []JSONAffectedRepo    `json:"affected_repositories,omitempty"`
+	Mismatches      []JSONMismatch        `json:"authorship_mismatches,omitempty"`
+	ActivityGaps    []JSONActivityGap     `json:"activity_gaps,omitempty"`
+	Activity        *JSONActivityAnalysis `json:"activity_analysis,omitempty"`
+	OrgMembers      []JSONOrgMember       `json:"org_members,omitempty"`
+	PivotLinks      []JSONPivotLink       `json:"pivot_links,omitempty"`
+	LinkedAccounts  []JSONLinkedAccount   `json:"linked_accounts,omitempty"`
+	Alternates      []JSONAlternate       `json:"alternate_accounts,omitempty"`
+}
+
+// JSONAlternate is an email that looks like one of the target's.
+type JSONAlternate struct {
+	Email      string   `json:"email"`
+	Confidence float64  `json:"confidence"`
+	Reasons    []string `json:"reasons"`
+}
+
+// JSONLinkedAccount is an account on another service tied to the target.
+type JSONLinkedAccount struct {
+	Service string   `json:"service"`
+	Handle  string   `json:"handle"`
+	Sources []string `json:"sources"`
+}
+
+// JSONPivotLink is an external link from the target's profile.
+type JSONPivotLink struct {
+	URL      string   `json:"url"`
+	Sources  []string `json:"sources"`
+	Status   string   `json:"status,omitempty"`
+	Resolved string   `json:"resolved_url,omitempty"`
+}
+
+// JSONOrgMember is a public member of an org target and the commit emails
+// attributed to that login.
+type JSONOrgMember struct {
+	Login  string   `json:"login"`
+	Emails []string `json:"emails,omitempty"`
+}
+
+func (a NDJSONAnalysis) empty() bool {
+	return len(a.ReusedMessages) == 0 && a.MonthlyActivity == nil && len(a.AffectedRepos) == 0 && len(a.Mismatches) == 0 && len(a.ActivityGaps) == 0 && a.Activity == nil && len(a.OrgMembers) == 0 && len(a.PivotLinks) == 0 && len(a.LinkedAccounts) == 0 && len(a.Alternates) == 0
+}
+
+// JSONActivityAnalysis is the --timestamp-analysis output, combined across
+// target identities and per identity (keyed by email).
+type JSONActivityAnalysis struct {
+	Aggregate   JSONActivityPatterns            `json:"aggregate"`
+	PerIdentity map[string]JSONActivityPatterns `json:"per_identity"`
+}
+
+// JSONActivityPatterns mirrors utils.GetTimestampPatterns. Hours are local to
+// each commit's timezone; MostActiveTimezone is the most common stated one
+// and InferredUTCOffset the one estimated from commit hours. TimezoneMismatch
+// is set when the stated offset is far from the inferred one.
+type JSONActivityPatterns struct {
+	TotalCommits          int            `json:"total_commits"`
+	UnusualHourPercentage float64        `json:"unusual_hour_percentage"`
+	WeekendPercentage     float64        `json:"weekend_percentage"`
+	NightOwlPercentage    float64        `json:"night_owl_percentage"`
+	EarlyBirdPercentage   float64        `json:"early_bird_percentage"`
+	HolidayPercentage     *float64       `json:"holiday_percentage,omitempty"`
+	LongestGapDays        int            `json:"longest_gap_days"`
+	LongGapCount          int            `json:"long_gap_count"`
+	MostActiveHour        int            `json:"most_active_hour"`
+	MostActiveDay         string         `json:"most_active_day"`
+	MostActiveTimezone    string         `json:"most_active_timezone,omitempty"`
+	StatedUTCOffset       *int           `json:"stated_utc_offset,omitempty"`
+	InferredUTCOffset     *int           `json:"inferred_utc_offset,omitempty"`
+	TimezoneMismatch      bool           `json:"timezone_mismatch,omitempty"`
+	HourDistribution      [24]int        `json:"hour_distribution"`
+	DayDistribution       map[string]int `json:"day_distribution"`
+	TimezoneDistribution  map[string]int `json:"timezone_distribution"`
+}
+
+// JSONActivityGap is a stretch of more than 90 days without target commits.
+type JSONActivityGap struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	Days int    `json:"days"`
+}
+
+type JSONMismatch struct {
+	Repository    string `json:"repository"`
+	Hash          string `json:"hash"`
+	URL           string `json:"url,omitempty"`
+	ClaimedEmail  string `json:"claimed_email"`
+	ResolvedLogin string `json:"resolved_login"`
+	Reason        string `json:"reason"`
+}
+
+// JSONAffectedRepo ranks a repository by the severity of its findings.
+type JSONAffectedRepo struct {
+	Repository  string `json:"repository"`
+	Score       int    `json:"score"`
+	Secrets     int    `json:"secrets"`
+	Interesting int    `json:"interesting"`
+	TopPattern  string `json:"top_pattern"`
+}
+
+// JSONMonthlyActivity holds the target's commit count per month, starting at
+// Start (YYYY-MM) with one entry for every following month.
+type JSONMonthlyActivity struct {
+	Start  string `json:"start"`
+	Counts []int  `json:"counts"`
+}
+
+type JSONReusedMessage struct {
+	Message    string   `json:"message"`
+	Identities []string `json:"identities"`
+	Repos      []string `json:"repositories"`
+}
+
 type JSONUser struct {
 	Login       string `json:"login"`
+	ID          int64  `json:"id,omitempty"`
 	Name        string `json:"name,omitempty"`
 	Email       string `json:"email,omitempty"`
 	Company     string `json:"company,omitempty"`
@@ -70,14 +191,32 @@ type JSONUser struct {
 	Followers   int    `json:"followers"`
 	Following   int    `json:"following"`
 	PublicRepos int    `json:"public_repos"`
+
+	AccountEmails []JSONAccountEmail `json:"account_emails,omitempty"`
+}
+
+// JSONAccountEmail is an address reported by the API for the account itself,
+// as opposed to one harvested from commits.
+type JSONAccountEmail struct {
+	Email      string `json:"email"`
+	Source     string `json:"source"`
+	Primary    bool   `json:"primary"`
+	Verified   bool   `json:"verified"`
+	Visibility string `json:"visibility,omitempty"`
 }
 
 type JSONEmailEntry struct {
-	Email        string     `json:"email"`
-	Names        []string   `json:"names"`
-	CommitCount  int        `json:"commit_count"`
-	IsTarget     bool       `json:"is_target"`
-	Repositories []JSONRepo `json:"repositories"`
+	Email            string     `json:"email"`
+	Names            []string   `json:"names"`
+	CommitCount      int        `json:"commit_count"`
+	FirstSeen        *time.Time `json:"first_seen,omitempty"`
+	LastSeen         *time.Time `json:"last_seen,omitempty"`
+	CoAuthorCommits  int        `json:"co_author_commits,omitempty"`
+	CommitterCommits int        `json:"committer_commits,omitempty"`
+	IsTarget         bool       `json:"is_target"`
+	IsMachine        bool       `json:"is_machine,omitempty"`
+	MachineNote      string     `json:"machine_reason,omitempty"`
+	Repositories     []JSONRepo `json:"repositories"`
 }
 
 type JSONRepo struct {
@@ -86,14 +225,52 @@ type JSONRepo struct {
 }
 
 type JSONCommit struct {
-	Hash           string    `json:"hash"`
-	URL            string    `json:"url"`
-	Message        string    `json:"message,omitempty"`
-	AuthorName     string    `json:"author_name"`
-	AuthorEmail    string    `json:"author_email"`
-	AuthorDate     time.Time `json:"author_date"`
-	CommitterName  string    `json:"committer_name,omitempty"`
-	CommitterEmail string    `json:"committer_email,omitempty"`
-	Secrets        []string  `json:"secrets,omitempty"`
+	Hash           string            `json:"hash"`
+	URL            string            `json:"url"`
+	Message        string            `json:"message,omitempty"`
+	AuthorName     string            `json:"author_name"`
+	AuthorEmail    string            `json:"author_email"`
+	AuthorDate     time.Time         `json:"author_date"`
+	Source         string            `json:"source"`
+	IsOwnRepo      bool              `json:"is_own_repo"`
+	IsFork         bool              `json:"is_fork"`
+	IsExternal     bool              `json:"is_external"`
+	Visibility     string            `json:"repo_visibility,omitempty"`
+	Verification   *JSONVerification `json:"verification,omitempty"`
+	CommitterName  string            `json:"committer_name,omitempty"`
+	CommitterEmail string            `json:"committer_email,omitempty"`
+	CoAuthors      []JSONCoAuthor    `json:"co_authors,omitempty"`
+	Secrets        []JSONSecret      `json:"secrets,omitempty"`
+	Patches        []JSONPatch       `json:"patches,omitempty"`
+	Links          []string          `json:"links,omitempty"`
 }
 
+// JSONCoAuthor is a person from a commit's Co-authored-by trailer.
+type JSONCoAuthor struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+// JSONVerification is omitted when the API returned no verification data.
+type JSONVerification struct {
+	Verified    bool   `json:"verified"`
+	Reason      string `json:"reason"`
+	SignerKeyID string `json:"signer_key_id,omitempty"`
+}
+
+// JSONSecret is one finding in a commit. Line and Context locate it in the
+// file (or message) it was found in.
+type JSONSecret struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Value    string `json:"value"`
+	Location string `json:"location,omitempty"`
+	Line     int    `json:"line,omitempty"`
+	Context  string `json:"context,omitempty"`
+	Verdict  string `json:"verdict,omitempty"`
+}
+
+type JSONPatch struct {
+	Filename string `json:"filename"`
+	Patch    string `json:"patch"`
+}
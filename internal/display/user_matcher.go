@@ -1,10 +1,15 @@
 package display
 
 import (
+	"sort"
 	"strings"
+	"unicode"
 
 	"github.com/gnomegl/gitslurp/v2/internal/models"
 	gh "github.com/google/go-github/v57/github"
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
 )
 
 type UserMatcher struct {
@@ -40,8 +45,8 @@ func (m *UserMatcher) HasMatchingNames(names []string) bool {
 			return c == ' ' || c == ','
 		})
 		for _, part := range nameParts {
-			part = strings.TrimSpace(part)
-			if m.targetNames[part] {
+			part = foldName(part)
+			if part != "" && m.targetNames[part] {
 				return true
 			}
 		}
@@ -94,7 +99,7 @@ func extractTargetUserNames(emails map[string]*models.EmailDetails, userIdentifi
 					return c == ' ' || c == ','
 				})
 				for _, part := range nameParts {
-					part = strings.TrimSpace(part)
+					part = foldName(part)
 					if part != "" {
 						targetNames[part] = true
 					}
@@ -106,11 +111,48 @@ func extractTargetUserNames(emails map[string]*models.EmailDetails, userIdentifi
 	return targetNames
 }
 
+// letterFolds spells out letters that Unicode decomposition leaves alone.
+var letterFolds = strings.NewReplacer("ß", "ss", "ø", "o", "ł", "l", "đ", "d", "æ", "ae", "œ", "oe", "þ", "th", "ı", "i")
+
+// foldName reduces a name, or a part of one, to lowercase letters and digits
+// without diacritics or punctuation, so "José" matches "Jose", "O'Brien"
+// matches "OBrien" and "john.smith" matches "John Smith".
+func foldName(s string) string {
+	stripMarks := transform.Chain(norm.NFKD, runes.Remove(runes.In(unicode.Mn)))
+	if folded, _, err := transform.String(stripMarks, s); err == nil {
+		s = folded
+	}
+	s = letterFolds.Replace(strings.ToLower(s))
+
+	var b strings.Builder
+	for _, r := range s {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// extractNames returns every name used with an email, most frequent commit
+// author name first so capped displays keep the relevant ones.
 func extractNames(details *models.EmailDetails) []string {
+	freq := make(map[string]int, len(details.Names))
+	for _, commits := range details.Commits {
+		for _, commit := range commits {
+			freq[commit.AuthorName]++
+		}
+	}
+
 	names := make([]string, 0, len(details.Names))
 	for name := range details.Names {
 		names = append(names, name)
 	}
+	sort.Slice(names, func(i, j int) bool {
+		if freq[names[i]] != freq[names[j]] {
+			return freq[names[i]] > freq[names[j]]
+		}
+		return names[i] < names[j]
+	})
 	return names
 }
 
@@ -0,0 +1,60 @@
+package display
+
+import (
+	"testing"
+
+	"github.com/gnomegl/gitslurp/v2/internal/models"
+)
+
+func TestFoldName(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"José", "jose"},
+		{"O'Brien", "obrien"},
+		{"O’Brien", "obrien"},
+		{"Jean-Luc", "jeanluc"},
+		{"john.smith", "johnsmith"},
+		{"John Smith", "johnsmith"},
+		{"Strauß", "strauss"},
+		{"Łukasz Søren", "lukaszsoren"},
+		{"Ｊｏｓｅ", "jose"},
+		{"Zoë2", "zoe2"},
+		{"李小龍", "李小龍"},
+		{"--", ""},
+	}
+	for _, tt := range tests {
+		if got := foldName(tt.in); got != tt.want {
+			t.Errorf("foldName(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestHasMatchingNames(t *testing.T) {
+	emails := map[string]*models.EmailDetails{
+		"jose@example.org":  {Names: map[string]struct{}{"José O'Brien-Łukasik": {}}},
+		"other@example.org": {Names: map[string]struct{}{"Someone Else": {}}},
+	}
+	m := NewUserMatcher("jose", "jose@example.org", nil)
+	m.targetNames = extractTargetUserNames(emails, m.identifiers)
+
+	tests := []struct {
+		name string
+		want bool
+	}{
+		{"Jose", true},
+		{"JOSÉ", true},
+		{"Smith, Jose", true},
+		{"Pat OBrien-Lukasik", true},
+		{"pat o’brien-łukasik", true},
+		{"Joseph", false},
+		{"O Brien", false},
+		{"Someone Else", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := m.HasMatchingNames([]string{tt.name}); got != tt.want {
+			t.Errorf("HasMatchingNames(%q) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package github
+
+import (
+	"context"
+	"strings"
+
+	gh "github.com/google/go-github/v57/github"
+)
+
+// FetchAccountEmails returns every email registered on the target account.
+// The API only exposes these to the account itself, so this succeeds only when
+// one of the pool's tokens belongs to login and carries the user:email (or
+// user) scope; otherwise it returns nil without reporting an error.
+func FetchAccountEmails(ctx context.Context, pool *ClientPool, login string) []*gh.UserEmail {
+	if pool == nil || pool.IsAppAuth() || login == "" {
+		return nil
+	}
+
+	for _, mc := range pool.AllClients() {
+		self, resp, err := mc.Client.Users.Get(ctx, "")
+		if err != nil || !strings.EqualFold(self.GetLogin(), login) {
+			continue
+		}
+		if !hasEmailScope(resp) {
+			return nil
+		}
+
+		var all []*gh.UserEmail
+		opts := &gh.ListOptions{PerPage: 100}
+		for {
+			emails, resp, err := mc.Client.Users.ListEmails(ctx, opts)
+			if err != nil {
+				return nil
+			}
+			all = append(all, emails...)
+			if resp.NextPage == 0 {
+				break
+			}
+			opts.Page = resp.NextPage
+		}
+		return all
+	}
+
+	return nil
+}
+
+// hasEmailScope reports whether a classic token may list emails. Fine-grained
+// tokens send no scope header, so they are given the benefit of the doubt.
+func hasEmailScope(resp *gh.Response) bool {
+	if resp == nil || resp.Header == nil {
+		return true
+	}
+	scopes := resp.Header.Get("X-OAuth-Scopes")
+	if scopes == "" {
+		return true
+	}
+	for _, scope := range strings.Split(scopes, ",") {
+		scope = strings.TrimSpace(scope)
+		if scope == "user:email" || scope == "user" {
+			return true
+		}
+	}
+	return false
+}
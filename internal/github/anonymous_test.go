@@ -0,0 +1,60 @@
+package github
+
+import (
+	"testing"
+
+	"github.com/gnomegl/gitslurp/v2/internal/models"
+	gh "github.com/google/go-github/v57/github"
+)
+
+func TestEmailLessCommits(t *testing.T) {
+	commit := func(sha, name, email string) *gh.RepositoryCommit {
+		return &gh.RepositoryCommit{
+			SHA: gh.String(sha),
+			Commit: &gh.Commit{
+				Message: gh.String("edit"),
+				Author:  &gh.CommitAuthor{Name: gh.String(name), Email: gh.String(email)},
+			},
+		}
+	}
+
+	tests := []struct {
+		name             string
+		commit           *gh.RepositoryCommit
+		includeAnonymous bool
+		wantName         string
+		wantKey          string // empty when the commit is dropped
+	}{
+		{"name without email dropped by default", commit("a1", "Web Editor", ""), false, "Web Editor", ""},
+		{"name without email kept with --include-anonymous", commit("a1", "Web Editor", ""), true, "Web Editor", AnonymousPrefix + "Web Editor"},
+		{"no name or email labeled anonymous", commit("b2", "", ""), true, "Anonymous", ""},
+		{"email present", commit("c3", "Dev", "dev@example.org"), true, "Dev", "dev@example.org"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.IncludeAnonymous = tt.includeAnonymous
+
+			info := ProcessCommit(tt.commit, false, &cfg)
+			if info.AuthorName != tt.wantName {
+				t.Errorf("AuthorName = %q, want %q", info.AuthorName, tt.wantName)
+			}
+
+			emails := make(map[string]*models.EmailDetails)
+			aggregateCommits(emails, []models.CommitInfo{info}, "owner/repo", nil, false, &cfg)
+			if tt.wantKey == "" {
+				if len(emails) != 0 {
+					t.Errorf("commit filed under %v, want it dropped", emails)
+				}
+				return
+			}
+			details := emails[tt.wantKey]
+			if details == nil || details.CommitCount != 1 {
+				t.Fatalf("no commit filed under %q: %v", tt.wantKey, emails)
+			}
+			if _, ok := details.Names[tt.wantName]; !ok {
+				t.Errorf("names = %v, want %q", details.Names, tt.wantName)
+			}
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package github
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	gh "github.com/google/go-github/v57/github"
+	"golang.org/x/oauth2"
+)
+
+// installation tokens live for an hour; refresh well before that so requests
+// in flight during long runs never carry an expired token
+const appTokenRefreshMargin = 5 * time.Minute
+
+// AppTokenSource mints GitHub App installation tokens (App JWT -> installation
+// access token) and transparently re-mints them shortly before they expire.
+type AppTokenSource struct {
+	appID          int64
+	installationID int64
+	key            *rsa.PrivateKey
+	transport      http.RoundTripper
+
+	mu    sync.Mutex
+	token *oauth2.Token
+}
+
+func NewAppTokenSource(appID, installationID int64, key *rsa.PrivateKey, proxyURL string) (*AppTokenSource, error) {
+	transport, err := newProxyTransport(proxyURL)
+	if err != nil {
+		return nil, err
+	}
+	return &AppTokenSource{
+		appID:          appID,
+		installationID: installationID,
+		key:            key,
+		transport:      transport,
+	}, nil
+}
+
+// Token implements oauth2.TokenSource.
+func (s *AppTokenSource) Token() (*oauth2.Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != nil && time.Until(s.token.Expiry) > appTokenRefreshMargin {
+		return s.token, nil
+	}
+
+	token, err := s.mint(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	s.token = token
+	return token, nil
+}
+
+func (s *AppTokenSource) mint(ctx context.Context) (*oauth2.Token, error) {
+	jwt, err := signAppJWT(s.appID, s.key, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	httpClient := &http.Client{
+		Transport: &oauth2.Transport{
+			Source: oauth2.StaticTokenSource(&oauth2.Token{AccessToken: jwt}),
+			Base:   s.transport,
+		},
+	}
+
+	installToken, _, err := gh.NewClient(httpClient).Apps.CreateInstallationToken(ctx, s.installationID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create installation token: %v", err)
+	}
+
+	return &oauth2.Token{
+		AccessToken: installToken.GetToken(),
+		TokenType:   "token",
+		Expiry:      installToken.GetExpiresAt().Time,
+	}, nil
+}
+
+// signAppJWT builds the RS256 JWT GitHub expects when authenticating as an App.
+// iat is backdated a minute to tolerate clock drift; exp stays under the
+// 10 minute maximum.
+func signAppJWT(appID int64, key *rsa.PrivateKey, now time.Time) (string, error) {
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT"})
+	if err != nil {
+		return "", err
+	}
+	claims, err := json.Marshal(map[string]interface{}{
+		"iat": now.Add(-time.Minute).Unix(),
+		"exp": now.Add(9 * time.Minute).Unix(),
+		"iss": strconv.FormatInt(appID, 10),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	enc := base64.RawURLEncoding
+	signingInput := enc.EncodeToString(header) + "." + enc.EncodeToString(claims)
+
+	digest := sha256.Sum256([]byte(signingInput))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("failed to sign app JWT: %v", err)
+	}
+
+	return signingInput + "." + enc.EncodeToString(sig), nil
+}
+
+// ReadAppPrivateKey loads a GitHub App private key in PEM form (PKCS#1 as
+// downloaded from GitHub, or PKCS#8).
+func ReadAppPrivateKey(path string) (*rsa.PrivateKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read private key: %v", err)
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("private key is not PEM encoded: %s", path)
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key: %v", err)
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("private key is not an RSA key: %s", path)
+	}
+	return key, nil
+}
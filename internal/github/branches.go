@@ -0,0 +1,99 @@
+package github
+
+import (
+	"context"
+	"time"
+
+	gh "github.com/google/go-github/v57/github"
+)
+
+// listRepoCommits lists a repository's commits on the branches cfg selects:
+// the default branch, --branch, or with --all-branches every branch, deduped
+// by SHA so history shared between branches is only kept once. limiter, when
+// set, paces every page request.
+func listRepoCommits(ctx context.Context, mc *ManagedClient, repo *gh.Repository, cfg *Config, perPage int, limiter <-chan time.Time) []*gh.RepositoryCommit {
+	branches := []string{cfg.Branch} // "" lists the default branch
+	if cfg.AllBranches {
+		if names := listBranches(ctx, mc, repo, limiter); len(names) > 0 {
+			branches = names
+		}
+	}
+
+	if len(branches) == 1 {
+		return listBranchCommits(ctx, mc, repo, branches[0], cfg, perPage, limiter)
+	}
+
+	seen := make(map[string]bool)
+	var all []*gh.RepositoryCommit
+	for _, branch := range branches {
+		if cfg.commitCapReached(len(all)) || mc.budget.Exhausted() {
+			break
+		}
+		for _, commit := range listBranchCommits(ctx, mc, repo, branch, cfg, perPage, limiter) {
+			if seen[commit.GetSHA()] {
+				continue
+			}
+			seen[commit.GetSHA()] = true
+			all = append(all, commit)
+		}
+	}
+	return all
+}
+
+func listBranches(ctx context.Context, mc *ManagedClient, repo *gh.Repository, limiter <-chan time.Time) []string {
+	var names []string
+	opts := &gh.BranchListOptions{ListOptions: gh.ListOptions{PerPage: 100}}
+
+	for {
+		if limiter != nil {
+			<-limiter
+		}
+		branches, resp, err := mc.Client.Repositories.ListBranches(ctx, repo.GetOwner().GetLogin(), repo.GetName(), opts)
+		if resp != nil {
+			mc.UpdateRateLimit(resp.Rate.Remaining, resp.Rate.Reset.Time)
+		}
+		if err != nil {
+			if mc.waitForReset(ctx, err) {
+				continue
+			}
+			break
+		}
+		for _, branch := range branches {
+			names = append(names, branch.GetName())
+		}
+
+		if resp.NextPage == 0 || mc.budget.Exhausted() {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return names
+}
+
+// listBranchCommits pages the commits reachable from branch, newest first.
+func listBranchCommits(ctx context.Context, mc *ManagedClient, repo *gh.Repository, branch string, cfg *Config, perPage int, limiter <-chan time.Time) []*gh.RepositoryCommit {
+	var all []*gh.RepositoryCommit
+	opts := commitListOptions(cfg, perPage)
+	opts.SHA = branch
+
+	for {
+		if limiter != nil {
+			<-limiter
+		}
+		commits, resp, err := mc.Client.Repositories.ListCommits(ctx, repo.GetOwner().GetLogin(), repo.GetName(), opts)
+		if resp != nil {
+			mc.UpdateRateLimit(resp.Rate.Remaining, resp.Rate.Reset.Time)
+		}
+		if err != nil && mc.waitForReset(ctx, err) {
+			continue
+		}
+
+		all = append(all, commits...)
+
+		if err != nil || resp == nil || resp.NextPage == 0 || cfg.QuickMode || mc.budget.Exhausted() || cfg.commitCapReached(len(all)) {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return all
+}
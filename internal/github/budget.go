@@ -0,0 +1,76 @@
+package github
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+
+	"github.com/fatih/color"
+)
+
+// ErrAPIBudgetExhausted is returned in place of every request made after the
+// --max-api-calls ceiling has been reached.
+var ErrAPIBudgetExhausted = errors.New("API call budget exhausted (--max-api-calls)")
+
+// CallBudget counts requests across every client of a pool and enforces an
+// optional user-imposed ceiling on them, independent of rate limits. A zero
+// limit only counts. It is safe for concurrent use, and a nil budget never
+// runs out.
+type CallBudget struct {
+	limit  atomic.Int64
+	used   atomic.Int64
+	notice sync.Once
+}
+
+func (b *CallBudget) SetLimit(n int64) {
+	b.limit.Store(n)
+}
+
+func (b *CallBudget) Limit() int64 {
+	if b == nil {
+		return 0
+	}
+	return b.limit.Load()
+}
+
+func (b *CallBudget) Used() int64 {
+	if b == nil {
+		return 0
+	}
+	return b.used.Load()
+}
+
+func (b *CallBudget) Exhausted() bool {
+	if b == nil {
+		return false
+	}
+	limit := b.limit.Load()
+	return limit > 0 && b.used.Load() >= limit
+}
+
+// take reserves one request, reporting false once the ceiling is hit.
+func (b *CallBudget) take() bool {
+	limit := b.limit.Load()
+	if b.used.Add(1) <= limit || limit <= 0 {
+		return true
+	}
+	b.used.Add(-1)
+	b.notice.Do(func() {
+		color.Yellow("\n[!] Reached --max-api-calls (%d requests); stopping and keeping partial results", limit)
+	})
+	return false
+}
+
+// budgetTransport charges every outgoing request to a shared CallBudget.
+type budgetTransport struct {
+	base   http.RoundTripper
+	budget *CallBudget
+}
+
+func (t *budgetTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !t.budget.take() {
+		return nil, ErrAPIBudgetExhausted
+	}
+	return t.base.RoundTrip(req)
+}
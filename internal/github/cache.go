@@ -0,0 +1,110 @@
+package github
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	gh "github.com/google/go-github/v57/github"
+)
+
+// Full commits are immutable by SHA, so fetched ones are kept on disk at
+// <CacheDir>/<owner>_<repo>@<sha>.json and reused until CacheTTL passes.
+// Expired entries are deleted when read and by PruneCommitCache. The files
+// hold patches, which may contain secrets, so they are private to the user.
+
+func commitCachePath(cfg *Config, repoFullName, sha string) string {
+	name := strings.ReplaceAll(strings.ToLower(repoFullName), "/", "_") + "@" + sha + ".json"
+	return filepath.Join(cfg.CacheDir, name)
+}
+
+// loadCachedCommit returns the cached commit, or nil when caching is off or
+// the entry is missing, unreadable or older than CacheTTL. Expired entries
+// are removed.
+func loadCachedCommit(cfg *Config, repoFullName, sha string) *gh.RepositoryCommit {
+	if cfg.CacheDir == "" || sha == "" {
+		return nil
+	}
+
+	path := commitCachePath(cfg, repoFullName, sha)
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil
+	}
+	if cacheExpired(info, cfg.CacheTTL) {
+		os.Remove(path)
+		return nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var commit gh.RepositoryCommit
+	if err := json.Unmarshal(data, &commit); err != nil || commit.GetSHA() != sha {
+		return nil
+	}
+	return &commit
+}
+
+// storeCachedCommit writes commit to the cache. Failures only cost a refetch
+// on the next run, so they are ignored.
+func storeCachedCommit(cfg *Config, repoFullName string, commit *gh.RepositoryCommit) {
+	if cfg.CacheDir == "" || commit.GetSHA() == "" {
+		return
+	}
+	if err := os.MkdirAll(cfg.CacheDir, 0700); err != nil {
+		return
+	}
+
+	data, err := json.Marshal(commit)
+	if err != nil {
+		return
+	}
+
+	// write then rename so concurrent runs never read a partial file
+	path := commitCachePath(cfg, repoFullName, commit.GetSHA())
+	tmp, err := os.CreateTemp(cfg.CacheDir, ".commit-*")
+	if err != nil {
+		return
+	}
+	_, werr := tmp.Write(data)
+	cerr := tmp.Close()
+	if werr != nil || cerr != nil || os.Rename(tmp.Name(), path) != nil {
+		os.Remove(tmp.Name())
+	}
+}
+
+// PruneCommitCache deletes the cached commits in dir older than ttl and
+// returns how many were removed. A ttl of 0 keeps every entry.
+func PruneCommitCache(dir string, ttl time.Duration) int {
+	if dir == "" || ttl <= 0 {
+		return 0
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return 0
+	}
+
+	removed := 0
+	for _, entry := range entries {
+		// leftovers of interrupted writes are swept along with old entries
+		if entry.IsDir() || !(strings.HasSuffix(entry.Name(), ".json") || strings.HasPrefix(entry.Name(), ".commit-")) {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil || !cacheExpired(info, ttl) {
+			continue
+		}
+		if os.Remove(filepath.Join(dir, entry.Name())) == nil {
+			removed++
+		}
+	}
+	return removed
+}
+
+func cacheExpired(info os.FileInfo, ttl time.Duration) bool {
+	return ttl > 0 && time.Since(info.ModTime()) > ttl
+}
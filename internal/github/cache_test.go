@@ -0,0 +1,92 @@
+package github
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	gh "github.com/google/go-github/v57/github"
+)
+
+func TestCommitCache(t *testing.T) {
+	sha := "0123456789abcdef0123456789abcdef01234567"
+	commit := &gh.RepositoryCommit{
+		SHA:   gh.String(sha),
+		Files: []*gh.CommitFile{{Filename: gh.String(".env"), Patch: gh.String("+TOKEN=x")}},
+	}
+	age := func(t *testing.T, cfg *Config, d time.Duration) {
+		t.Helper()
+		old := time.Now().Add(-d)
+		if err := os.Chtimes(commitCachePath(cfg, "Owner/Repo", sha), old, old); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name   string
+		noDir  bool          // --no-cache
+		store  bool          // whether the commit was cached
+		age    time.Duration // how long ago it was cached
+		ttl    time.Duration
+		hit    bool
+		remain bool // whether the cache file is still there after the read
+	}{
+		{name: "hit", store: true, ttl: time.Hour, hit: true, remain: true},
+		{name: "miss", ttl: time.Hour},
+		{name: "expired", store: true, age: 2 * time.Hour, ttl: time.Hour},
+		{name: "no expiry", store: true, age: 1000 * time.Hour, hit: true, remain: true},
+		{name: "no-cache", noDir: true, store: true, ttl: time.Hour},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.CacheTTL = tt.ttl
+			if !tt.noDir {
+				cfg.CacheDir = t.TempDir()
+			}
+			if tt.store {
+				storeCachedCommit(&cfg, "Owner/Repo", commit)
+				if tt.age > 0 {
+					age(t, &cfg, tt.age)
+				}
+			}
+
+			got := loadCachedCommit(&cfg, "owner/repo", sha)
+			if (got != nil) != tt.hit {
+				t.Fatalf("loadCachedCommit hit = %v, want %v", got != nil, tt.hit)
+			}
+			if got != nil && got.Files[0].GetPatch() != "+TOKEN=x" {
+				t.Errorf("cached patch = %q", got.Files[0].GetPatch())
+			}
+			if tt.noDir {
+				return
+			}
+			_, err := os.Stat(commitCachePath(&cfg, "Owner/Repo", sha))
+			if remain := err == nil; remain != tt.remain {
+				t.Errorf("cache file present = %v, want %v", remain, tt.remain)
+			}
+		})
+	}
+}
+
+func TestPruneCommitCache(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.CacheDir = t.TempDir()
+	for _, sha := range []string{"aaaa", "bbbb"} {
+		storeCachedCommit(&cfg, "owner/repo", &gh.RepositoryCommit{SHA: gh.String(sha)})
+	}
+	old := time.Now().Add(-48 * time.Hour)
+	if err := os.Chtimes(commitCachePath(&cfg, "owner/repo", "aaaa"), old, old); err != nil {
+		t.Fatal(err)
+	}
+
+	if n := PruneCommitCache(cfg.CacheDir, 0); n != 0 {
+		t.Errorf("PruneCommitCache with no TTL removed %d entries", n)
+	}
+	if n := PruneCommitCache(cfg.CacheDir, 24*time.Hour); n != 1 {
+		t.Errorf("PruneCommitCache removed %d entries, want 1", n)
+	}
+	if _, err := os.Stat(commitCachePath(&cfg, "owner/repo", "bbbb")); err != nil {
+		t.Errorf("fresh entry was removed: %v", err)
+	}
+}
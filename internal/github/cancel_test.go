@@ -0,0 +1,97 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gnomegl/gitslurp/v2/internal/status"
+	gh "github.com/google/go-github/v57/github"
+)
+
+func TestCancelledCrawlKeepsPartialResults(t *testing.T) {
+	status.Quiet = true
+	defer func() { status.Quiet = false }()
+
+	tests := []struct {
+		name   string
+		cancel bool
+		repos  int // repositories whose commits were listed
+		emails int
+	}{
+		{"runs to completion", false, 3, 6},
+		{"cancelled mid-crawl", true, 1, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			var mu sync.Mutex
+			listed := make(map[string]bool)
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				// /repos/octo/<name>/commits
+				name := strings.Split(r.URL.Path, "/")[3]
+				page := r.URL.Query().Get("page")
+				if page == "" {
+					page = "1"
+					w.Header().Set("Link", `<http://`+r.Host+r.URL.Path+`?page=2>; rel="next"`)
+				}
+				mu.Lock()
+				listed[name] = true
+				mu.Unlock()
+				if tt.cancel && page == "2" {
+					// the page is lost; the first one is kept
+					cancel()
+				}
+				fmt.Fprintf(w, `[{"sha": "%[1]s%[2]s", "commit": {"author": {"name": "Dev", "email": "%[1]s-%[2]s@example.com"}}}]`, name, page)
+			})
+			pool := testPool(t, handler)
+
+			var repos []*gh.Repository
+			for _, name := range []string{"a", "b", "c"} {
+				repos = append(repos, &gh.Repository{
+					Name:     gh.String(name),
+					FullName: gh.String("octo/" + name),
+					Owner:    &gh.User{Login: gh.String("octo")},
+				})
+			}
+			cfg := DefaultConfig()
+			cfg.RepoConcurrency = 1
+
+			emails := RateLimitedProcessRepos(ctx, pool, repos, false, &cfg, nil, false, nil)
+
+			if len(listed) != tt.repos {
+				t.Errorf("commits listed for %d repositories, want %d", len(listed), tt.repos)
+			}
+			if len(emails) != tt.emails {
+				t.Errorf("%d emails collected, want %d: %v", len(emails), tt.emails, emails)
+			}
+			for email := range emails {
+				if tt.cancel && !strings.HasSuffix(email, "-1@example.com") {
+					t.Errorf("collected %s after the crawl was cancelled", email)
+				}
+			}
+		})
+	}
+}
+
+func testPool(t *testing.T, handler http.Handler) *ClientPool {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	pool, err := NewClientPool(nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, mc := range pool.AllClients() {
+		mc.Client.BaseURL, _ = url.Parse(server.URL + "/")
+	}
+	return pool
+}
@@ -0,0 +1,90 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	gh "github.com/google/go-github/v57/github"
+)
+
+func TestMaxRepos(t *testing.T) {
+	pages := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("/orgs/acme/repos", func(w http.ResponseWriter, r *http.Request) {
+		pages++
+		page := r.URL.Query().Get("page")
+		if page == "" {
+			page = "1"
+		}
+		if page == "1" {
+			w.Header().Set("Link", `<`+"http://"+r.Host+r.URL.Path+`?page=2>; rel="next"`)
+		}
+		fmt.Fprintf(w, `[{"full_name": "acme/%s-a"}, {"full_name": "acme/%s-b"}]`, page, page)
+	})
+	client := testClient(t, mux)
+
+	tests := []struct {
+		maxRepos int
+		want     int
+		pages    int
+	}{
+		{0, 4, 2},
+		{1, 1, 1},
+		{2, 2, 1},
+		{3, 3, 2},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("max %d", tt.maxRepos), func(t *testing.T) {
+			pages = 0
+			cfg := DefaultConfig()
+			cfg.MaxRepos = tt.maxRepos
+
+			repos, err := FetchOrgRepos(context.Background(), client, "acme", &cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(repos) != tt.want {
+				t.Errorf("%d repositories, want %d", len(repos), tt.want)
+			}
+			if pages != tt.pages {
+				t.Errorf("%d pages listed, want %d", pages, tt.pages)
+			}
+		})
+	}
+}
+
+func TestMaxCommitsPerRepo(t *testing.T) {
+	commits := make([]*gh.RepositoryCommit, 5)
+	for i := range commits {
+		commits[i] = &gh.RepositoryCommit{SHA: gh.String(fmt.Sprint(i))}
+	}
+
+	tests := []struct {
+		maxCommits int
+		want       int
+	}{
+		{0, 5},
+		{3, 3},
+		{5, 5},
+		{10, 5},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("max %d", tt.maxCommits), func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.MaxCommits = tt.maxCommits
+
+			got := capCommits(commits, &cfg)
+			if len(got) != tt.want {
+				t.Errorf("%d commits kept, want %d", len(got), tt.want)
+			}
+			if got[0].GetSHA() != "0" {
+				t.Errorf("cap kept %s first, want the newest commit", got[0].GetSHA())
+			}
+			if reached := cfg.commitCapReached(len(got)); reached != (tt.maxCommits > 0 && tt.want == tt.maxCommits) {
+				t.Errorf("commitCapReached(%d) = %v", len(got), reached)
+			}
+		})
+	}
+}
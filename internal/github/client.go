@@ -2,13 +2,17 @@ package github
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/fatih/color"
+	"github.com/gnomegl/gitslurp/v2/internal/status"
 	"github.com/google/go-github/v57/github"
 	"github.com/urfave/cli/v2"
 	"golang.org/x/oauth2"
@@ -108,6 +112,24 @@ func GetUsernameForEmail(ctx context.Context, client *github.Client, email strin
 	return result.Users[0].GetLogin(), nil
 }
 
+const noreplyDomain = "@users.noreply.github.com"
+
+// ResolveNoreplyEmail returns the login encoded in a GitHub noreply address,
+// in either the "login@" or "id+login@" form, and the account ID the second
+// form carries. ok is false for any other email.
+func ResolveNoreplyEmail(email string) (login string, id int64, ok bool) {
+	email = strings.ToLower(email)
+	if !strings.HasSuffix(email, noreplyDomain) {
+		return "", 0, false
+	}
+	login = strings.TrimSuffix(email, noreplyDomain)
+	if prefix, rest, found := strings.Cut(login, "+"); found {
+		id, _ = strconv.ParseInt(prefix, 10, 64)
+		login = rest
+	}
+	return login, id, login != ""
+}
+
 func GetUserByEmail(ctx context.Context, client *github.Client, email string) (*github.User, error) {
 	searchQuery := fmt.Sprintf("in:email %s", email)
 	opts := &github.SearchOptions{
@@ -128,6 +150,55 @@ func GetUserByEmail(ctx context.Context, client *github.Client, email string) (*
 	return result.Users[0], nil
 }
 
+// GetUserByCommitEmail finds the account that public commits authored with
+// email are linked to, for addresses that are not listed on a profile. It
+// returns an empty login when no such commit is indexed.
+func GetUserByCommitEmail(ctx context.Context, client *github.Client, email string) (string, error) {
+	opts := &github.SearchOptions{ListOptions: github.ListOptions{PerPage: 10}}
+	result, _, err := client.Search.Commits(ctx, fmt.Sprintf("author-email:%s", email), opts)
+	if err != nil {
+		return "", fmt.Errorf("failed to search commits: %w", err)
+	}
+
+	for _, commit := range result.Commits {
+		if login := commit.GetAuthor().GetLogin(); login != "" {
+			return login, nil
+		}
+	}
+	return "", nil
+}
+
+// DescribeAccountError explains a failed profile lookup of login: an account
+// that does not exist, one GitHub has suspended or blocked, or a rate limit
+// that leaves the account's status unknown. Other errors are returned as is.
+func DescribeAccountError(login string, err error) error {
+	var rateErr *github.RateLimitError
+	if errors.As(err, &rateErr) {
+		return fmt.Errorf("rate limited while looking up %s (resets at %s); whether the account exists is unknown",
+			login, rateErr.Rate.Reset.Time.Local().Format("15:04:05"))
+	}
+	var abuseErr *github.AbuseRateLimitError
+	if errors.As(err, &abuseErr) {
+		return fmt.Errorf("secondary rate limit hit while looking up %s; whether the account exists is unknown", login)
+	}
+	var errResp *github.ErrorResponse
+	if !errors.As(err, &errResp) || errResp.Response == nil {
+		return err
+	}
+
+	switch {
+	case strings.Contains(strings.ToLower(errResp.Message), "suspended"):
+		return fmt.Errorf("account %s exists but has been suspended by GitHub", login)
+	case errResp.Response.StatusCode == http.StatusNotFound:
+		return fmt.Errorf("no GitHub account is named %s: the handle never existed, or the account was renamed or deleted (a renamed account can be followed by user ID with --by-id)", login)
+	case errResp.Response.StatusCode == http.StatusUnavailableForLegalReasons:
+		return fmt.Errorf("GitHub blocks access to account %s for legal reasons (HTTP 451)", login)
+	case errResp.Response.StatusCode == http.StatusTooManyRequests:
+		return fmt.Errorf("rate limited while looking up %s; whether the account exists is unknown", login)
+	}
+	return err
+}
+
 func UserExists(ctx context.Context, client *github.Client, username string) (bool, error) {
 	_, resp, err := client.Users.Get(ctx, username)
 	if err != nil {
@@ -149,7 +220,7 @@ func ValidateToken(ctx context.Context, client *github.Client) error {
 				return fmt.Errorf("invalid GitHub token")
 			case 403:
 				// Rate limited - skip validation, token is likely valid
-				color.Yellow("[!]  Rate limited, skipping token validation")
+				status.Yellow("[!]  Rate limited, skipping token validation")
 				return nil
 			}
 		}
@@ -164,7 +235,7 @@ func CheckDeleteRepoPermissions(ctx context.Context, client *github.Client) (boo
 	if err != nil {
 		if resp != nil && resp.StatusCode == 403 {
 			// Rate limited - assume permissions are sufficient to avoid blocking
-			color.Yellow("[!]  Rate limited, skipping permission check")
+			status.Yellow("[!]  Rate limited, skipping permission check")
 			return true, nil
 		}
 		return false, fmt.Errorf("error checking permissions: %v", err)
@@ -192,59 +263,92 @@ func CheckDeleteRepoPermissions(ctx context.Context, client *github.Client) (boo
 }
 
 type RateLimit struct {
+	Category  string
 	Limit     int
 	Remaining int
 	ResetTime time.Time
 }
 
 func GetRateLimit(ctx context.Context, client *github.Client) (*RateLimit, error) {
+	limits, err := GetRateLimits(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range limits {
+		if limits[i].Category == "core" {
+			return &limits[i], nil
+		}
+	}
+	return nil, fmt.Errorf("core rate limit information not available")
+}
+
+// GetRateLimits returns the quotas gitslurp draws on: core REST, search
+// (external contributions, email resolution) and GraphQL when reported.
+func GetRateLimits(ctx context.Context, client *github.Client) ([]RateLimit, error) {
 	rateLimit, _, err := client.RateLimits(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get rate limit info: %v", err)
 	}
 
-	core := rateLimit.GetCore()
-	if core == nil {
-		return nil, fmt.Errorf("core rate limit information not available")
+	var limits []RateLimit
+	for _, c := range []struct {
+		name string
+		rate *github.Rate
+	}{
+		{"core", rateLimit.GetCore()},
+		{"search", rateLimit.GetSearch()},
+		{"graphql", rateLimit.GetGraphQL()},
+	} {
+		if c.rate == nil {
+			continue
+		}
+		limits = append(limits, RateLimit{
+			Category:  c.name,
+			Limit:     c.rate.Limit,
+			Remaining: c.rate.Remaining,
+			ResetTime: c.rate.Reset.Time,
+		})
+	}
+	if len(limits) == 0 {
+		return nil, fmt.Errorf("rate limit information not available")
+	}
+	return limits, nil
+}
+
+// printRateLimit prints one quota line colored by how much of it is left.
+func printRateLimit(label string, rl RateLimit) {
+	percentage := 0.0
+	if rl.Limit > 0 {
+		percentage = float64(rl.Remaining) / float64(rl.Limit) * 100
+	}
+
+	reset := "already reset"
+	if resetLocal := rl.ResetTime.Local(); time.Until(resetLocal) > 0 {
+		reset = "resets " + resetLocal.Format("15:04:05")
 	}
 
-	return &RateLimit{
-		Limit:     core.Limit,
-		Remaining: core.Remaining,
-		ResetTime: core.Reset.Time,
-	}, nil
+	line := fmt.Sprintf("%s: %d/%d (%.1f%%), %s", label, rl.Remaining, rl.Limit, percentage, reset)
+	if percentage > 50 {
+		status.Green("%s", line)
+	} else if percentage > 20 {
+		status.Yellow("%s", line)
+	} else {
+		color.Red("%s", line)
+	}
 }
 
 func DisplayRateLimit(ctx context.Context, client *github.Client) {
-	rateLimitInfo, err := GetRateLimit(ctx, client)
+	limits, err := GetRateLimits(ctx, client)
 	if err != nil {
-		color.Yellow("\n[!] Could not fetch rate limit information: %v", err)
+		status.Yellow("\n[!] Could not fetch rate limit information: %v", err)
 		return
 	}
 
-	now := time.Now()
-	resetLocal := rateLimitInfo.ResetTime.In(now.Location())
-	timeUntilReset := resetLocal.Sub(now)
-
 	fmt.Println()
 	fmt.Println(strings.Repeat("-", 50))
 
-	percentage := float64(rateLimitInfo.Remaining) / float64(rateLimitInfo.Limit) * 100
-	if percentage > 50 {
-		color.Green("API: %d/%d (%.1f%%)",
-			rateLimitInfo.Remaining, rateLimitInfo.Limit, percentage)
-	} else if percentage > 20 {
-		color.Yellow("API: %d/%d (%.1f%%)",
-			rateLimitInfo.Remaining, rateLimitInfo.Limit, percentage)
-	} else {
-		color.Red("API: %d/%d (%.1f%%)",
-			rateLimitInfo.Remaining, rateLimitInfo.Limit, percentage)
-	}
-
-	if timeUntilReset > 0 {
-		color.Blue("Resets: %s",
-			resetLocal.Format("2006-01-02 15:04:05"))
-	} else {
-		color.Green("Rate limit has already reset")
+	for _, rl := range limits {
+		printRateLimit(fmt.Sprintf("API %-7s", rl.Category), rl)
 	}
 }
@@ -0,0 +1,87 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestResolveNoreplyEmail(t *testing.T) {
+	tests := []struct {
+		email string
+		login string
+		id    int64
+		ok    bool
+	}{
+		{"12345+octo-dev@users.noreply.github.com", "octo-dev", 12345, true},
+		{"octo-dev@users.noreply.github.com", "octo-dev", 0, true},
+		{"12345+Octo-Dev@Users.NoReply.GitHub.com", "octo-dev", 12345, true},
+		{"dependabot[bot]@users.noreply.github.com", "dependabot[bot]", 0, true},
+		{"@users.noreply.github.com", "", 0, false},
+		{"12345+@users.noreply.github.com", "", 12345, false},
+		{"octo-dev@example.com", "", 0, false},
+		{"octo-dev@noreply.github.com", "", 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.email, func(t *testing.T) {
+			login, id, ok := ResolveNoreplyEmail(tt.email)
+			if login != tt.login || id != tt.id || ok != tt.ok {
+				t.Errorf("ResolveNoreplyEmail = %q, %d, %v, want %q, %d, %v", login, id, ok, tt.login, tt.id, tt.ok)
+			}
+		})
+	}
+}
+
+func TestDescribeAccountError(t *testing.T) {
+	reset := fmt.Sprint(time.Now().Add(time.Hour).Unix())
+	tests := []struct {
+		name    string
+		status  int
+		headers map[string]string
+		body    string
+		want    string
+	}{
+		{"not found", http.StatusNotFound, nil, `{"message": "Not Found"}`, "no GitHub account is named octo"},
+		{"suspended", http.StatusForbidden, nil, `{"message": "Sorry. Your account was suspended."}`, "has been suspended"},
+		{"legal block", http.StatusUnavailableForLegalReasons, nil, `{"message": "Unavailable for legal reasons"}`, "legal reasons"},
+		{
+			name:    "rate limited",
+			status:  http.StatusForbidden,
+			headers: map[string]string{"X-RateLimit-Remaining": "0", "X-RateLimit-Limit": "60", "X-RateLimit-Reset": reset},
+			body:    `{"message": "API rate limit exceeded"}`,
+			want:    "rate limited while looking up octo",
+		},
+		{
+			name:   "secondary rate limit",
+			status: http.StatusForbidden,
+			body:   `{"message": "You have exceeded a secondary rate limit", "documentation_url": "https://docs.github.com/rest/overview/resources-in-the-rest-api#secondary-rate-limits"}`,
+			want:   "secondary rate limit hit while looking up octo",
+		},
+		{"too many requests", http.StatusTooManyRequests, nil, `{"message": "Too Many Requests"}`, "rate limited while looking up octo"},
+		{"other errors pass through", http.StatusInternalServerError, nil, `{"message": "boom"}`, "500 boom"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux := http.NewServeMux()
+			mux.HandleFunc("/users/octo", func(w http.ResponseWriter, r *http.Request) {
+				for k, v := range tt.headers {
+					w.Header().Set(k, v)
+				}
+				w.WriteHeader(tt.status)
+				fmt.Fprint(w, tt.body)
+			})
+			_, _, err := testClient(t, mux).Users.Get(context.Background(), "octo")
+			if err == nil {
+				t.Fatal("lookup succeeded")
+			}
+
+			got := DescribeAccountError("octo", err)
+			if !strings.Contains(got.Error(), tt.want) {
+				t.Errorf("DescribeAccountError = %q, want it to mention %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package github
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/gnomegl/gitslurp/v2/internal/models"
+)
+
+// coAuthorTrailer matches "Co-authored-by: Name <email>" lines, as written by
+// GitHub's co-author feature and pair-programming tools.
+var coAuthorTrailer = regexp.MustCompile(`(?im)^[ \t]*co-authored-by:[ \t]*([^<\r\n]*?)[ \t]*<([^<>\s]+@[^<>\s]+)>[ \t\r]*$`)
+
+// ParseCoAuthors returns the co-authors named in a commit message's trailers,
+// in order and without repeats. Lines without a bracketed email address are
+// skipped.
+func ParseCoAuthors(message string) []models.CoAuthor {
+	var coAuthors []models.CoAuthor
+	seen := make(map[string]bool)
+	for _, m := range coAuthorTrailer.FindAllStringSubmatch(message, -1) {
+		email := strings.ToLower(m[2])
+		if seen[email] {
+			continue
+		}
+		seen[email] = true
+		coAuthors = append(coAuthors, models.CoAuthor{Name: strings.TrimSpace(m[1]), Email: m[2]})
+	}
+	return coAuthors
+}
@@ -0,0 +1,62 @@
+package github
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/gnomegl/gitslurp/v2/internal/models"
+)
+
+func TestParseCoAuthors(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		want    []models.CoAuthor
+	}{
+		{"no trailers", "fix the build", nil},
+		{"one trailer", "pair on parser\n\nCo-authored-by: Ana Lima <ana@example.org>", []models.CoAuthor{{Name: "Ana Lima", Email: "ana@example.org"}}},
+		{
+			name:    "several trailers, any case and some spacing",
+			message: "feat\n\nCo-authored-by: Ana <ana@example.org>\nco-authored-by:Bo <bo@example.org>  \n  CO-AUTHORED-BY:   Cy Dee   <cy@example.org>\r\n",
+			want:    []models.CoAuthor{{Name: "Ana", Email: "ana@example.org"}, {Name: "Bo", Email: "bo@example.org"}, {Name: "Cy Dee", Email: "cy@example.org"}},
+		},
+		{"repeated address", "x\n\nCo-authored-by: Ana <ana@example.org>\nCo-authored-by: A. <ANA@example.org>", []models.CoAuthor{{Name: "Ana", Email: "ana@example.org"}}},
+		{"no name", "x\n\nCo-authored-by: <ana@example.org>", []models.CoAuthor{{Name: "", Email: "ana@example.org"}}},
+		{
+			name:    "malformed lines",
+			message: "x\n\nCo-authored-by: Ana\nCo-authored-by: Bo bo@example.org\nCo-authored-by: Cy <not-an-email>\nCo-authored-by: Di <di@example.org> trailing\nSee Co-authored-by: Ed <ed@example.org>",
+			want:    nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ParseCoAuthors(tt.message)
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("ParseCoAuthors = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCoAuthorIdentities(t *testing.T) {
+	commit := models.CommitInfo{
+		Hash:        "a1",
+		AuthorName:  "Dev",
+		AuthorEmail: "dev@example.org",
+		CoAuthors:   ParseCoAuthors("x\n\nCo-authored-by: Ana <ana@example.org>\nCo-authored-by: Dev <DEV@example.org>"),
+	}
+	cfg := DefaultConfig()
+	emails := make(map[string]*models.EmailDetails)
+	aggregateCommits(emails, []models.CommitInfo{commit}, "owner/repo", nil, false, &cfg)
+
+	if len(emails) != 2 {
+		t.Fatalf("%d identities, want the author and one co-author: %v", len(emails), emails)
+	}
+	ana := emails["ana@example.org"]
+	if ana == nil || len(ana.Commits["owner/repo"]) != 1 || ana.CoAuthorCommits("ana@example.org") != 1 {
+		t.Errorf("co-author not filed under owner/repo: %+v", ana)
+	}
+	if _, ok := ana.Names["Ana"]; !ok {
+		t.Errorf("co-author names = %v, want Ana", ana.Names)
+	}
+}
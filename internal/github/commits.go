@@ -9,7 +9,7 @@ import (
 	"github.com/gnomegl/gitslurp/v2/internal/models"
 	"github.com/gnomegl/gitslurp/v2/internal/scanner"
 
-	"github.com/fatih/color"
+	"github.com/gnomegl/gitslurp/v2/internal/status"
 	"github.com/google/go-github/v57/github"
 )
 
@@ -35,19 +35,40 @@ func FetchReposWithUser(ctx context.Context, client *github.Client, username str
 	}
 
 	fmt.Println()
-	color.Blue("Enumerating user repositories...")
+	status.Blue("Enumerating user repositories...")
 
 	var allRepos []*github.Repository
 	opt := &github.RepositoryListByUserOptions{
 		ListOptions: github.ListOptions{PerPage: cfg.PerPage},
-		Type:        "all",
+		Type:        cfg.RepoType,
+	}
+	if opt.Type == "" {
+		opt.Type = "all"
+	}
+
+	list := func(page int) ([]*github.Repository, *github.Response, error) {
+		opt.Page = page
+		return client.Repositories.ListByUser(ctx, username, opt)
+	}
+	if cfg.IncludePrivate {
+		// only the authenticated user's own listing has private repositories
+		own := &github.RepositoryListOptions{
+			Visibility:  "all",
+			Affiliation: ownRepoAffiliation(opt.Type),
+			ListOptions: github.ListOptions{PerPage: cfg.PerPage},
+		}
+		list = func(page int) ([]*github.Repository, *github.Response, error) {
+			own.Page = page
+			return client.Repositories.List(ctx, "", own)
+		}
+		status.Blue("Including private repositories (--include-private)")
 	}
 
 	totalFetched := 0
 	filteredForks := 0
 
-	for {
-		repos, resp, err := client.Repositories.ListByUser(ctx, username, opt)
+	for page := 0; ; {
+		repos, resp, err := list(page)
 		if err != nil {
 			return nil, fmt.Errorf("error fetching repositories: %v", err)
 		}
@@ -62,19 +83,24 @@ func FetchReposWithUser(ctx context.Context, client *github.Client, username str
 			allRepos = append(allRepos, repo)
 		}
 
-		if resp.NextPage == 0 {
+		if resp.NextPage == 0 || (cfg.MaxRepos > 0 && len(allRepos) >= cfg.MaxRepos) {
 			break
 		}
-		opt.Page = resp.NextPage
+		page = resp.NextPage
+	}
+
+	if cfg.MaxRepos > 0 && len(allRepos) > cfg.MaxRepos {
+		status.Yellow("[>] Limiting to %d of the repositories found (--max-repos)", cfg.MaxRepos)
+		allRepos = capRepos(allRepos, cfg)
 	}
 
 	if cfg.IncludeForks {
-		color.Green("[+] Found %d repositories (including forks)", len(allRepos))
+		status.Green("[+] Found %d repositories (including forks)", len(allRepos))
 	} else {
 		if filteredForks > 0 {
-			color.Green("[+] Found %d owned repositories (%d forks excluded)", len(allRepos), filteredForks)
+			status.Green("[+] Found %d owned repositories (%d forks excluded)", len(allRepos), filteredForks)
 		} else {
-			color.Green("[+] Found %d repositories", len(allRepos))
+			status.Green("[+] Found %d repositories", len(allRepos))
 		}
 	}
 
@@ -83,6 +109,31 @@ func FetchReposWithUser(ctx context.Context, client *github.Client, username str
 	return allRepos, nil
 }
 
+// ownRepoAffiliation maps a --repo-type to the affiliation filter of the
+// authenticated user's repository listing.
+func ownRepoAffiliation(repoType string) string {
+	switch repoType {
+	case "owner":
+		return "owner"
+	case "member":
+		return "collaborator,organization_member"
+	default:
+		return "owner,collaborator,organization_member"
+	}
+}
+
+// RepoVisibility reports a repository's visibility, falling back to the
+// private flag when the API omits the visibility field.
+func RepoVisibility(repo *github.Repository) string {
+	if v := repo.GetVisibility(); v != "" {
+		return v
+	}
+	if repo.GetPrivate() {
+		return "private"
+	}
+	return "public"
+}
+
 func FetchCommits(ctx context.Context, client *github.Client, owner, repo string, isFork bool, since *time.Time, checkSecrets bool, findLinks bool, cfg *Config) ([]models.CommitInfo, error) {
 	if cfg == nil {
 		cfg = &Config{}
@@ -96,10 +147,16 @@ func FetchCommits(ctx context.Context, client *github.Client, owner, repo string
 	}
 	
 	opt := &github.CommitsListOptions{
+		SHA:         cfg.Branch,
 		ListOptions: github.ListOptions{PerPage: perPage},
 	}
 	if since != nil {
 		opt.Since = *since
+	} else if !cfg.Since.IsZero() {
+		opt.Since = cfg.Since
+	}
+	if !cfg.Until.IsZero() {
+		opt.Until = cfg.Until
 	}
 
 	for {
@@ -115,6 +172,9 @@ func FetchCommits(ctx context.Context, client *github.Client, owner, repo string
 		}
 
 		for _, c := range commits {
+			if cfg.commitCapReached(len(allCommits)) {
+				break
+			}
 			if c.GetCommit() == nil || c.GetCommit().GetAuthor() == nil {
 				continue
 			}
@@ -125,6 +185,8 @@ func FetchCommits(ctx context.Context, client *github.Client, owner, repo string
 				AuthorName:  c.GetCommit().GetAuthor().GetName(),
 				AuthorEmail: c.GetCommit().GetAuthor().GetEmail(),
 				Message:     c.GetCommit().GetMessage(),
+				IsMerge:     len(c.Parents) > 1,
+				CoAuthors:   ParseCoAuthors(c.GetCommit().GetMessage()),
 				IsOwnRepo:   !isFork,
 				IsFork:      isFork,
 				RepoName:    repo,
@@ -140,16 +202,12 @@ func FetchCommits(ctx context.Context, client *github.Client, owner, repo string
 				commitInfo.CommitterDate = c.GetCommit().GetCommitter().GetDate().Time
 			}
 
-			// Handle anonymous commits
-			if commitInfo.AuthorEmail == "" && commitInfo.CommitterEmail == "" {
+			// Handle anonymous commits, keeping any name that was set
+			if commitInfo.AuthorName == "" && commitInfo.AuthorEmail == "" {
 				commitInfo.AuthorName = "Anonymous"
-				commitInfo.AuthorEmail = ""
-				if commitInfo.CommitterName == "" {
-					commitInfo.CommitterName = "Anonymous"
-				}
-				if commitInfo.CommitterEmail == "" {
-					commitInfo.CommitterEmail = ""
-				}
+			}
+			if commitInfo.CommitterName == "" && commitInfo.CommitterEmail == "" {
+				commitInfo.CommitterName = "Anonymous"
 			}
 
 			if findLinks {
@@ -161,11 +219,17 @@ func FetchCommits(ctx context.Context, client *github.Client, owner, repo string
 				if err == nil {
 					secretScanner := scanner.NewScanner(cfg.ShowInteresting)
 					matches := secretScanner.ScanText(content)
+					seen := make(map[string]bool)
 					for _, match := range matches {
+						if seen[match.Name+"\x00"+match.Value] || scanner.Suppressed(commitInfo.Hash, match) {
+							continue
+						}
+						seen[match.Name+"\x00"+match.Value] = true
+						match.Verdict = scanner.Verify(match, content)
 						if match.Type == "Secret" {
-							commitInfo.Secrets = append(commitInfo.Secrets, fmt.Sprintf("%s: %s", match.Name, match.Value))
+							commitInfo.Secrets = append(commitInfo.Secrets, match.Finding(""))
 						} else if match.Type == "Interesting" {
-							commitInfo.Secrets = append(commitInfo.Secrets, fmt.Sprintf("INTERESTING: %s: %s", match.Name, match.Value))
+							commitInfo.Secrets = append(commitInfo.Secrets, match.Finding(""))
 						}
 					}
 				}
@@ -174,7 +238,7 @@ func FetchCommits(ctx context.Context, client *github.Client, owner, repo string
 			allCommits = append(allCommits, commitInfo)
 		}
 
-		if resp.NextPage == 0 || cfg.QuickMode {
+		if resp.NextPage == 0 || cfg.QuickMode || cfg.commitCapReached(len(allCommits)) {
 			break
 		}
 		opt.Page = resp.NextPage
@@ -190,9 +254,14 @@ func fetchCommitContent(ctx context.Context, client *github.Client, owner, repo,
 		*cfg = DefaultConfig()
 	}
 
-	commit, _, err := client.Repositories.GetCommit(ctx, owner, repo, sha, nil)
-	if err != nil {
-		return "", fmt.Errorf("failed to fetch commit %s: %w", sha, err)
+	commit := loadCachedCommit(cfg, owner+"/"+repo, sha)
+	if commit == nil {
+		var err error
+		commit, _, err = client.Repositories.GetCommit(ctx, owner, repo, sha, nil)
+		if err != nil {
+			return "", fmt.Errorf("failed to fetch commit %s: %w", sha, err)
+		}
+		storeCachedCommit(cfg, owner+"/"+repo, commit)
 	}
 	var content strings.Builder
 	content.WriteString(commit.GetCommit().GetMessage())
@@ -201,7 +270,7 @@ func fetchCommitContent(ctx context.Context, client *github.Client, owner, repo,
 	for _, file := range commit.Files {
 		filename := file.GetFilename()
 		// js ecosystem is bloat
-		if cfg.SkipNodeModules && (strings.Contains(filename, "/node_modules/") || strings.HasPrefix(filename, "node_modules/")) {
+		if skipScanFile(filename, file.GetPatch(), cfg) {
 			continue
 		}
 		switch filename {
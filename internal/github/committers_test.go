@@ -0,0 +1,56 @@
+package github
+
+import (
+	"testing"
+
+	"github.com/gnomegl/gitslurp/v2/internal/models"
+)
+
+func TestIncludeCommitters(t *testing.T) {
+	commits := []models.CommitInfo{
+		// rebased by a maintainer
+		{Hash: "a1", AuthorName: "Dev", AuthorEmail: "dev@example.org", CommitterName: "Maint", CommitterEmail: "maint@example.org"},
+		// committed by the author
+		{Hash: "b2", AuthorName: "Dev", AuthorEmail: "dev@example.org", CommitterName: "Dev", CommitterEmail: "DEV@example.org"},
+		// web edit, committed by GitHub
+		{Hash: "c3", AuthorName: "Dev", AuthorEmail: "dev@example.org", CommitterName: "GitHub", CommitterEmail: "noreply@github.com"},
+		// the maintainer's own commit
+		{Hash: "d4", AuthorName: "Maint", AuthorEmail: "maint@example.org", CommitterName: "Maint", CommitterEmail: "maint@example.org"},
+	}
+
+	tests := []struct {
+		name              string
+		includeCommitters bool
+		want              map[string]int // commits per email
+		committerOnly     map[string]int // commits filed as committer of someone else's work
+	}{
+		{"authors only by default", false,
+			map[string]int{"dev@example.org": 3, "maint@example.org": 1},
+			map[string]int{"dev@example.org": 0, "maint@example.org": 0}},
+		{"with --include-committers", true,
+			map[string]int{"dev@example.org": 3, "maint@example.org": 2},
+			map[string]int{"dev@example.org": 0, "maint@example.org": 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.IncludeCommitters = tt.includeCommitters
+
+			emails := make(map[string]*models.EmailDetails)
+			aggregateCommits(emails, commits, "owner/repo", nil, false, &cfg)
+			if len(emails) != len(tt.want) {
+				t.Errorf("%d identities, want %d: %v", len(emails), len(tt.want), emails)
+			}
+			for email, n := range tt.want {
+				details := emails[email]
+				if details == nil || details.CommitCount != n {
+					t.Errorf("%s: %v, want %d commits", email, details, n)
+					continue
+				}
+				if got := details.CommitterCommits(email); got != tt.committerOnly[email] {
+					t.Errorf("%s: CommitterCommits = %d, want %d", email, got, tt.committerOnly[email])
+				}
+			}
+		})
+	}
+}
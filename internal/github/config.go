@@ -1,5 +1,11 @@
 package github
 
+import (
+	"time"
+
+	gh "github.com/google/go-github/v57/github"
+)
+
 // Config holds configuration for GitHub operations
 type Config struct {
 	MaxRepos              int
@@ -7,25 +13,115 @@ type Config struct {
 	MaxCommits            int
 	ShowInteresting       bool
 	MaxConcurrentRequests int
+	RepoConcurrency       int
+	CommitConcurrency     int
+	GistConcurrency       int
+	GistRetries           int
 	PerPage               int
 	SkipNodeModules       bool
+	ScanVendored          bool
+	MinifiedLineLength    int
 	QuickMode             bool
 	TimestampAnalysis     bool
 	IncludeForks          bool
+	IncludePatches        bool
+	IncludeAnonymous      bool
+	IncludeCommitters     bool // also file commits under a differing committer email
+	StrictOrgDomain       bool
+	ExcludeMerges         bool
+	TopContributors       int
+	HashLength            int
+	MaxNames              int
+	SortBy                string // commits (default), email, name or recent
+	FilterDomains         []string
+	ExcludeDomains        []string
+	HideNoreply           bool
+	RepoType              string
+	Redact                bool
+	Since                 time.Time // zero when unset
+	Until                 time.Time // exclusive; zero when unset
+	CacheDir              string    // full commit cache; empty disables it
+	CacheTTL              time.Duration
+	GraphQL               bool
+	Branch                string // branch to walk instead of the default one
+	AllBranches           bool
+	OrgMembers            []string    // public members of an org target (--org-members)
+	IncludePrivate        bool        // list the token owner's private repositories too
+	FindLinks             bool        // collect links from messages and added lines (--links)
+	PivotLinks            []PivotLink // links from the profile's website, bio and README
 }
 
 // DefaultConfig returns a default configuration
 func DefaultConfig() Config {
 	return Config{
-		MaxRepos:              100,
+		MaxRepos:              0,
 		MaxGists:              100,
-		MaxCommits:            100,
+		MaxCommits:            0,
 		ShowInteresting:       false,
 		MaxConcurrentRequests: 5,
+		RepoConcurrency:       3,
+		CommitConcurrency:     4,
+		GistConcurrency:       4,
+		GistRetries:           2,
 		PerPage:               100,
 		SkipNodeModules:       true,
+		ScanVendored:          false,
+		MinifiedLineLength:    300,
 		QuickMode:             false,
 		TimestampAnalysis:     false,
 		IncludeForks:          false,
+		IncludePatches:        false,
+		IncludeAnonymous:      false,
+		IncludeCommitters:     false,
+		StrictOrgDomain:       false,
+		ExcludeMerges:         false,
+		TopContributors:       0,
+		HashLength:            8,
+		MaxNames:              10,
+		RepoType:              "all",
+	}
+}
+
+// InWindow reports whether t falls within --since/--until. Commits without a
+// date are kept.
+func (c *Config) InWindow(t time.Time) bool {
+	if t.IsZero() {
+		return true
+	}
+	if !c.Since.IsZero() && t.Before(c.Since) {
+		return false
+	}
+	return c.Until.IsZero() || t.Before(c.Until)
+}
+
+// commitListOptions returns commit list options limited to the date window.
+func commitListOptions(cfg *Config, perPage int) *gh.CommitsListOptions {
+	return &gh.CommitsListOptions{
+		Since:       cfg.Since,
+		Until:       cfg.Until,
+		ListOptions: gh.ListOptions{PerPage: perPage},
+	}
+}
+
+// commitCapReached reports whether n commits fill the per-repository cap
+// (--max-commits-per-repo). A zero cap is unlimited.
+func (c *Config) commitCapReached(n int) bool {
+	return c.MaxCommits > 0 && n >= c.MaxCommits
+}
+
+// capCommits trims a repository's commits to the per-repository cap.
+func capCommits(commits []*gh.RepositoryCommit, cfg *Config) []*gh.RepositoryCommit {
+	if cfg.MaxCommits > 0 && len(commits) > cfg.MaxCommits {
+		return commits[:cfg.MaxCommits]
+	}
+	return commits
+}
+
+// capRepos trims a repository listing to --max-repos. A zero cap is
+// unlimited.
+func capRepos(repos []*gh.Repository, cfg *Config) []*gh.Repository {
+	if cfg.MaxRepos > 0 && len(repos) > cfg.MaxRepos {
+		return repos[:cfg.MaxRepos]
 	}
+	return repos
 }
@@ -9,9 +9,13 @@ import (
 	"os/exec"
 	"path/filepath"
 	"regexp"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/fatih/color"
+	"github.com/gnomegl/gitslurp/v2/internal/status"
 	"github.com/google/go-github/v57/github"
 )
 
@@ -21,8 +25,73 @@ func IsValidEmail(input string) bool {
 	return emailRegex.MatchString(input)
 }
 
-func GetUsernameFromEmailSpoof(ctx context.Context, client *github.Client, email string, token string) (string, error) {
-	color.Yellow("[@] Attempting email spoofing method for: %s", email)
+// Spoofing defaults, used when SpoofOptions leaves a field unset.
+const (
+	DefaultSpoofRepoPrefix = "temp-spoof"
+	DefaultSpoofSyncWait   = 3 * time.Second
+
+	// spoofSyncAttempts is how many times the pushed commit is looked up
+	// before giving up, since GitHub can take a while to index a push.
+	spoofSyncAttempts = 5
+
+	// spoofCleanupTimeout bounds deleting the temporary repository, which
+	// runs even after the run's context is cancelled.
+	spoofCleanupTimeout = 30 * time.Second
+)
+
+// SpoofOptions configure the temporary repository the spoofing fallback
+// pushes to.
+type SpoofOptions struct {
+	RepoPrefix string        // --spoof-repo-prefix; the name is <prefix>-<unix time>
+	SyncWait   time.Duration // --spoof-sync-wait; pause before each commit lookup
+}
+
+// pendingCleanups holds the temporary repositories ("owner/name") created
+// and not yet deleted, so an interrupt handler can hold off exiting until
+// they are gone.
+var pendingCleanups = struct {
+	sync.Mutex
+	repos map[string]bool
+}{repos: make(map[string]bool)}
+
+// PendingCleanups returns the temporary spoofing repositories still waiting
+// to be deleted, sorted.
+func PendingCleanups() []string {
+	pendingCleanups.Lock()
+	defer pendingCleanups.Unlock()
+	repos := make([]string, 0, len(pendingCleanups.repos))
+	for repo := range pendingCleanups.repos {
+		repos = append(repos, repo)
+	}
+	sort.Strings(repos)
+	return repos
+}
+
+// trackCleanup records fullName as pending until the returned func is called.
+func trackCleanup(fullName string) (done func()) {
+	pendingCleanups.Lock()
+	pendingCleanups.repos[fullName] = true
+	pendingCleanups.Unlock()
+	return func() {
+		pendingCleanups.Lock()
+		delete(pendingCleanups.repos, fullName)
+		pendingCleanups.Unlock()
+	}
+}
+
+// SpoofRepoSettingsURL is where a temporary repository can be deleted by hand.
+func SpoofRepoSettingsURL(fullName string) string {
+	return fmt.Sprintf("https://github.com/%s/settings", fullName)
+}
+
+func GetUsernameFromEmailSpoof(ctx context.Context, client *github.Client, email string, token string, opts SpoofOptions) (string, error) {
+	status.Yellow("[@] Attempting email spoofing method for: %s", email)
+	if opts.RepoPrefix == "" {
+		opts.RepoPrefix = DefaultSpoofRepoPrefix
+	}
+	if opts.SyncWait <= 0 {
+		opts.SyncWait = DefaultSpoofSyncWait
+	}
 	
 	user, _, err := client.Users.Get(ctx, "")
 	if err != nil {
@@ -35,7 +104,7 @@ func GetUsernameFromEmailSpoof(ctx context.Context, client *github.Client, email
 	}
 	defer os.RemoveAll(tempDir)
 
-	repoName := fmt.Sprintf("temp-spoof-%d", time.Now().Unix())
+	repoName := fmt.Sprintf("%s-%d", opts.RepoPrefix, time.Now().Unix())
 	
 	repo := &github.Repository{
 		Name:        github.String(repoName),
@@ -49,12 +118,26 @@ func GetUsernameFromEmailSpoof(ctx context.Context, client *github.Client, email
 		return "", fmt.Errorf("failed to create repository (check token permissions): %v", err)
 	}
 	
+	fullName := user.GetLogin() + "/" + repoName
+	cleanupDone := trackCleanup(fullName)
+	status.Yellow("[o] Created temporary private repository %s", fullName)
+
 	defer func() {
-		color.Yellow("[-] Cleaning up temporary repository...")
-		_, err := client.Repositories.Delete(ctx, user.GetLogin(), repoName)
+		defer cleanupDone()
+		// Ctrl-C or --timeout may have cancelled ctx by now, and the
+		// repository has to go regardless
+		cleanupCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), spoofCleanupTimeout)
+		defer cancel()
+
+		// shown even with --quiet: if the process dies now the repository stays
+		color.Yellow("[-] Deleting temporary repository %s (if this does not finish, delete it at %s)", fullName, SpoofRepoSettingsURL(fullName))
+		_, err := client.Repositories.Delete(cleanupCtx, user.GetLogin(), repoName)
 		if err != nil {
-			color.Red("[!] Warning: Failed to delete temporary repository %s: %v", repoName, err)
+			color.Red("[!] Warning: Failed to delete temporary repository %s: %v", fullName, err)
+			color.Red("    Delete it by hand at %s", SpoofRepoSettingsURL(fullName))
+			return
 		}
+		status.Green("[+] Deleted temporary repository %s", fullName)
 	}()
 
 	repoPath := filepath.Join(tempDir, repoName)
@@ -62,13 +145,13 @@ func GetUsernameFromEmailSpoof(ctx context.Context, client *github.Client, email
 		return "", fmt.Errorf("failed to create repo directory: %v", err)
 	}
 	
-	if err := runGitCommand(repoPath, "init"); err != nil {
+	if err := runGitCommand(ctx, repoPath, "init"); err != nil {
 		return "", fmt.Errorf("failed to initialize git repo: %v", err)
 	}
 	
 	// Use authenticated clone URL with token
 	authenticatedURL := fmt.Sprintf("https://%s@github.com/%s/%s.git", token, user.GetLogin(), repoName)
-	if err := runGitCommand(repoPath, "remote", "add", "origin", authenticatedURL); err != nil {
+	if err := runGitCommand(ctx, repoPath, "remote", "add", "origin", authenticatedURL); err != nil {
 		return "", fmt.Errorf("failed to add remote: %v", err)
 	}
 
@@ -79,51 +162,55 @@ func GetUsernameFromEmailSpoof(ctx context.Context, client *github.Client, email
 	}
 
 	// Configure git with the target email
-	if err := runGitCommand(repoPath, "config", "user.email", email); err != nil {
+	if err := runGitCommand(ctx, repoPath, "config", "user.email", email); err != nil {
 		return "", fmt.Errorf("failed to set git email: %v", err)
 	}
 	
-	if err := runGitCommand(repoPath, "config", "user.name", "TempUser"); err != nil {
+	if err := runGitCommand(ctx, repoPath, "config", "user.name", "TempUser"); err != nil {
 		return "", fmt.Errorf("failed to set git name: %v", err)
 	}
 
-	if err := runGitCommand(repoPath, "add", "temp.txt"); err != nil {
+	if err := runGitCommand(ctx, repoPath, "add", "temp.txt"); err != nil {
 		return "", fmt.Errorf("failed to add file: %v", err)
 	}
 	
-	if err := runGitCommand(repoPath, "commit", "-m", "temp commit for email spoofing"); err != nil {
+	if err := runGitCommand(ctx, repoPath, "commit", "-m", "temp commit for email spoofing"); err != nil {
 		return "", fmt.Errorf("failed to commit: %v", err)
 	}
 
-	if err := runGitCommand(repoPath, "branch", "-M", "master"); err != nil {
+	if err := runGitCommand(ctx, repoPath, "branch", "-M", "master"); err != nil {
 		return "", fmt.Errorf("failed to rename branch: %v", err)
 	}
 	
-	if err := runGitCommand(repoPath, "push", "-u", "origin", "master"); err != nil {
+	if err := runGitCommand(ctx, repoPath, "push", "-u", "origin", "master"); err != nil {
 		return "", fmt.Errorf("failed to push: %v", err)
 	}
 
-	// Give GitHub API time to sync after push
-	time.Sleep(3 * time.Second)
-
-	commits, _, err := client.Repositories.ListCommits(ctx, createdRepo.GetOwner().GetLogin(), repoName, &github.CommitsListOptions{
-		ListOptions: github.ListOptions{PerPage: 1},
+	pushed, err := waitForCommit(ctx, opts.SyncWait, func() ([]*github.RepositoryCommit, error) {
+		commits, _, err := client.Repositories.ListCommits(ctx, createdRepo.GetOwner().GetLogin(), repoName, &github.CommitsListOptions{
+			ListOptions: github.ListOptions{PerPage: 1},
+		})
+		return commits, err
 	})
-	if err != nil || len(commits) == 0 {
+	if err != nil {
 		return "", fmt.Errorf("failed to get commits: %v", err)
 	}
 
-	commitSHA := commits[0].GetSHA()
-	
+	commitSHA := pushed.GetSHA()
+	if username := pushed.GetAuthor().GetLogin(); username != "" {
+		status.Green("[+] Found username via API: %s", username)
+		return username, nil
+	}
+
 	commit, _, err := client.Repositories.GetCommit(ctx, createdRepo.GetOwner().GetLogin(), repoName, commitSHA, nil)
-	if err == nil && commit.GetAuthor() != nil && commit.GetAuthor().GetLogin() != "" {
+	if err == nil && commit.GetAuthor().GetLogin() != "" {
 		username := commit.GetAuthor().GetLogin()
-		color.Green("[+] Found username via API: %s", username)
+		status.Green("[+] Found username via API: %s", username)
 		return username, nil
 	}
 
 	// if api doesn't provide username, temporarily make repo public and scrape
-	color.Yellow("[o] Temporarily making repository public for web scraping...")
+	status.Yellow("[o] Temporarily making repository public for web scraping...")
 	
 	repoUpdate := &github.Repository{
 		Private: github.Bool(false),
@@ -137,18 +224,44 @@ func GetUsernameFromEmailSpoof(ctx context.Context, client *github.Client, email
 	time.Sleep(2 * time.Second)
 
 	commitURL := fmt.Sprintf("https://github.com/%s/%s/commit/%s", createdRepo.GetOwner().GetLogin(), repoName, commitSHA)
-	username, err := scrapeUsernameFromCommitPage(commitURL)
+	username, err := scrapeUsernameFromCommitPage(ctx, commitURL, createdRepo.GetOwner().GetLogin())
 	if err != nil {
 		return "", fmt.Errorf("failed to scrape username: %v", err)
 	}
 
-	color.Green("[+] Found username via scraping: %s", username)
+	status.Green("[+] Found username via scraping: %s", username)
 	return username, nil
 }
 
+// waitForCommit polls list until the API returns the pushed commit, pausing
+// wait before each of up to spoofSyncAttempts tries.
+func waitForCommit(ctx context.Context, wait time.Duration, list func() ([]*github.RepositoryCommit, error)) (*github.RepositoryCommit, error) {
+	var err error
+	for attempt := 1; attempt <= spoofSyncAttempts; attempt++ {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(wait):
+		}
+
+		var commits []*github.RepositoryCommit
+		commits, err = list()
+		if err == nil && len(commits) > 0 {
+			return commits[0], nil
+		}
+		if attempt < spoofSyncAttempts {
+			status.Yellow("[o] Pushed commit not visible yet, retrying (%d/%d)...", attempt, spoofSyncAttempts)
+		}
+	}
+	if err == nil {
+		err = fmt.Errorf("commit still not listed after %d attempts", spoofSyncAttempts)
+	}
+	return nil, err
+}
+
 // executes a git command in the specified directory
-func runGitCommand(dir string, args ...string) error {
-	cmd := exec.Command("git", args...)
+func runGitCommand(ctx context.Context, dir string, args ...string) error {
+	cmd := exec.CommandContext(ctx, "git", args...)
 	cmd.Dir = dir
 	output, err := cmd.CombinedOutput()
 	if err != nil {
@@ -157,9 +270,28 @@ func runGitCommand(dir string, args ...string) error {
 	return nil
 }
 
-// scrapes the GitHub commit page to extract the username
-func scrapeUsernameFromCommitPage(url string) (string, error) {
-	resp, err := http.Get(url)
+// commitAuthorPatterns find the author's login on a commit page, most
+// specific first: the JSON payload the page renders from, then the author
+// link and avatar of the older server-rendered markup.
+var commitAuthorPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`"authors":\[\{"login":"([^"]+)"`),
+	regexp.MustCompile(`"author":\{"login":"([^"]+)"`),
+	regexp.MustCompile(`<a[^>]+class="[^"]*commit-author[^"]*"[^>]+href="/([^"/?]+)"`),
+	regexp.MustCompile(`href="/([\w-]+)"[^>]*>[^<]*</a>[^<]*authored`),
+	regexp.MustCompile(`<img[^>]+alt="@([^"]+)"[^>]*class="[^"]*avatar[^"]*"`),
+}
+
+var loginPattern = regexp.MustCompile(`^[A-Za-z0-9](?:[A-Za-z0-9-]{0,38})$`)
+
+// scrapeUsernameFromCommitPage is the last resort when the API does not link
+// the commit to an account. owner is the login the temporary repository
+// belongs to; it shows up all over the page and is never the answer.
+func scrapeUsernameFromCommitPage(ctx context.Context, url, owner string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to build request: %v", err)
+	}
+	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
 		return "", fmt.Errorf("failed to fetch commit page: %v", err)
 	}
@@ -170,25 +302,19 @@ func scrapeUsernameFromCommitPage(url string) (string, error) {
 		return "", fmt.Errorf("failed to read response body: %v", err)
 	}
 
-	html := string(body)
-	
-	// <a class="commit-author" href="/username">
-	usernameRegex1 := regexp.MustCompile(`<a[^>]+class="[^"]*commit-author[^"]*"[^>]+href="/([^"]+)"`)
-	if matches := usernameRegex1.FindStringSubmatch(html); len(matches) > 1 {
-		return matches[1], nil
-	}
-
-	// check commit info
-	usernameRegex2 := regexp.MustCompile(`href="/([\w-]+)"[^>]*>[^<]*</a>[^<]*authored`)
-	if matches := usernameRegex2.FindStringSubmatch(html); len(matches) > 1 {
-		return matches[1], nil
+	if username, ok := usernameFromCommitHTML(string(body), owner); ok {
+		return username, nil
 	}
+	return "", fmt.Errorf("could not extract username from commit page")
+}
 
-	// avatar link pattern
-	usernameRegex3 := regexp.MustCompile(`<img[^>]+alt="@([^"]+)"[^>]*class="[^"]*avatar[^"]*"`)
-	if matches := usernameRegex3.FindStringSubmatch(html); len(matches) > 1 {
-		return matches[1], nil
+func usernameFromCommitHTML(html, owner string) (string, bool) {
+	for _, pattern := range commitAuthorPatterns {
+		for _, m := range pattern.FindAllStringSubmatch(html, -1) {
+			if loginPattern.MatchString(m[1]) && !strings.EqualFold(m[1], owner) {
+				return m[1], true
+			}
+		}
 	}
-
-	return "", fmt.Errorf("could not extract username from commit page")
+	return "", false
 }
@@ -0,0 +1,125 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gnomegl/gitslurp/v2/internal/status"
+	gh "github.com/google/go-github/v57/github"
+)
+
+func TestWaitForCommit(t *testing.T) {
+	status.Quiet = true
+	defer func() { status.Quiet = false }()
+
+	pushed := &gh.RepositoryCommit{SHA: gh.String("abc123")}
+	errSync := errors.New("409 Git Repository is empty")
+
+	// lookup results in order: "ok" lists the commit, "empty" lists
+	// nothing and "error" fails with errSync
+	tests := []struct {
+		name      string
+		lookups   []string
+		cancelled bool
+		wantCalls int
+		wantFound bool
+		wantErr   error
+	}{
+		{"listed at once", []string{"ok"}, false, 1, true, nil},
+		{"listed after sync errors", []string{"error", "error", "ok"}, false, 3, true, nil},
+		{"listed after empty lists", []string{"empty", "empty", "ok"}, false, 3, true, nil},
+		{"never synced", []string{"error", "error", "error", "error", "error", "ok"}, false, spoofSyncAttempts, false, errSync},
+		{"never listed", []string{"empty", "empty", "empty", "empty", "empty", "ok"}, false, spoofSyncAttempts, false, nil},
+		{"cancelled", []string{"ok"}, true, 0, false, context.Canceled},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if tt.cancelled {
+				cancel()
+			}
+
+			calls := 0
+			got, err := waitForCommit(ctx, time.Millisecond, func() ([]*gh.RepositoryCommit, error) {
+				calls++
+				switch tt.lookups[calls-1] {
+				case "ok":
+					return []*gh.RepositoryCommit{pushed}, nil
+				case "error":
+					return nil, errSync
+				}
+				return nil, nil
+			})
+
+			if calls != tt.wantCalls {
+				t.Errorf("%d lookups, want %d", calls, tt.wantCalls)
+			}
+			if tt.wantFound {
+				if err != nil || got != pushed {
+					t.Errorf("waitForCommit = %v, %v, want the pushed commit", got, err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("waitForCommit = %v, want an error", got)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("err = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUsernameFromCommitHTML(t *testing.T) {
+	tests := []struct {
+		fixture string
+		want    string
+		ok      bool
+	}{
+		{"react.html", "octo-dev", true},
+		{"legacy.html", "octo-dev", true},
+		{"authored.html", "octo-dev", true},
+		// only the repository owner appears
+		{"unlinked.html", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			html, err := os.ReadFile(filepath.Join("testdata", "commit_pages", tt.fixture))
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, ok := usernameFromCommitHTML(string(html), "investigator")
+			if got != tt.want || ok != tt.ok {
+				t.Errorf("usernameFromCommitHTML = %q, %v, want %q, %v", got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestPendingCleanups(t *testing.T) {
+	doneB := trackCleanup("octo/temp-spoof-2")
+	doneA := trackCleanup("octo/temp-spoof-1")
+
+	steps := []struct {
+		done func()
+		want []string
+	}{
+		{nil, []string{"octo/temp-spoof-1", "octo/temp-spoof-2"}},
+		{doneB, []string{"octo/temp-spoof-1"}},
+		{doneA, []string{}},
+	}
+	for i, step := range steps {
+		if step.done != nil {
+			step.done()
+		}
+		if got := PendingCleanups(); !reflect.DeepEqual(got, step.want) {
+			t.Errorf("step %d: PendingCleanups = %v, want %v", i, got, step.want)
+		}
+	}
+}
@@ -3,17 +3,21 @@ package github
 import (
 	"context"
 	"fmt"
-	"os"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
-	"github.com/fatih/color"
 	"github.com/gnomegl/gitslurp/v2/internal/models"
 	"github.com/gnomegl/gitslurp/v2/internal/scanner"
+	"github.com/gnomegl/gitslurp/v2/internal/status"
 	gh "github.com/google/go-github/v57/github"
 	"github.com/schollz/progressbar/v3"
 )
 
+// ProcessUserEvents collects commits from the push events in a user's public
+// activity stream (--quick). It needs a handful of requests regardless of
+// how many repositories the user has, but only sees recent activity.
 func ProcessUserEvents(ctx context.Context, pool *ClientPool, username string, checkSecrets bool, cfg *Config, targetUserIdentifiers map[string]bool, showTargetOnly bool) map[string]*models.EmailDetails {
 	if cfg == nil {
 		cfg = &Config{}
@@ -24,18 +28,19 @@ func ProcessUserEvents(ctx context.Context, pool *ClientPool, username string, c
 
 	fmt.Println()
 	if checkSecrets && cfg.ShowInteresting {
-		color.Cyan("Quick Mode: Recent Activity Scan (Secrets & Patterns)")
+		status.Cyan("Quick Mode: Recent Activity Scan (Secrets & Patterns)")
 	} else if checkSecrets {
-		color.Cyan("Quick Mode: Recent Activity Scan (Secrets)")
+		status.Cyan("Quick Mode: Recent Activity Scan (Secrets)")
 	} else if cfg.ShowInteresting {
-		color.Cyan("Quick Mode: Recent Activity Scan (Patterns)")
+		status.Cyan("Quick Mode: Recent Activity Scan (Patterns)")
 	} else {
-		color.Cyan("Quick Mode: Recent Activity Scan")
+		status.Cyan("Quick Mode: Recent Activity Scan")
 	}
 
-	color.Yellow("[!] Use --deep flag for complete commit history across all repos")
+	status.Yellow("[!] Results are limited to the user's last 300 public events (at most 90 days)")
+	status.Yellow("[!] Drop --quick for a repository scan, or use --deep for complete commit history across all repos")
 	fmt.Println()
-	color.Blue("Fetching recent GitHub events from API...")
+	status.Blue("Fetching recent GitHub events from API...")
 
 	var allEvents []*gh.Event
 	opts := &gh.ListOptions{PerPage: 100}
@@ -45,7 +50,7 @@ func ProcessUserEvents(ctx context.Context, pool *ClientPool, username string, c
 		progressbar.OptionSetDescription("[cyan]Fetching event stream[reset]"),
 		progressbar.OptionSetWidth(20),
 		progressbar.OptionSpinnerType(14),
-		progressbar.OptionSetWriter(os.Stderr),
+		progressbar.OptionSetWriter(status.Writer()),
 		progressbar.OptionSetTheme(progressbar.Theme{
 			Saucer:        "[green]#[reset]",
 			SaucerHead:    "[green]>[reset]",
@@ -60,8 +65,11 @@ func ProcessUserEvents(ctx context.Context, pool *ClientPool, username string, c
 		if resp != nil {
 			mc.UpdateRateLimit(resp.Rate.Remaining, resp.Rate.Reset.Time)
 		}
+		if err != nil && mc.waitForReset(ctx, err) {
+			continue
+		}
 		if err != nil {
-			color.Yellow("[!]  Warning: Could not fetch user events: %v", err)
+			status.Yellow("[!]  Warning: Could not fetch user events: %v", err)
 			break
 		}
 
@@ -77,13 +85,13 @@ func ProcessUserEvents(ctx context.Context, pool *ClientPool, username string, c
 	bar.Finish()
 
 	if len(allEvents) == 0 {
-		color.Yellow("[!] No recent events found for user: %s", username)
+		status.Yellow("[!] No recent events found for user: %s", username)
 		return emails
 	}
 
-	color.Green("[+] Found %d recent events", len(allEvents))
+	status.Green("[+] Found %d recent events", len(allEvents))
 	fmt.Println()
-	color.Blue("Analyzing events for commit data...")
+	status.Blue("Analyzing events for commit data...")
 
 	commitCount := 0
 	processBar := progressbar.NewOptions(len(allEvents),
@@ -91,7 +99,7 @@ func ProcessUserEvents(ctx context.Context, pool *ClientPool, username string, c
 		progressbar.OptionShowCount(),
 		progressbar.OptionSetWidth(20),
 		progressbar.OptionSetDescription("[cyan]Processing events[reset]"),
-		progressbar.OptionSetWriter(os.Stderr),
+		progressbar.OptionSetWriter(status.Writer()),
 		progressbar.OptionSetTheme(progressbar.Theme{
 			Saucer:        "[green]#[reset]",
 			SaucerHead:    "[green]>[reset]",
@@ -104,7 +112,8 @@ func ProcessUserEvents(ctx context.Context, pool *ClientPool, username string, c
 		if event.Type != nil && *event.Type == "PushEvent" {
 			commits := processEventCommits(event, checkSecrets, cfg)
 			commitCount += len(commits)
-			aggregateCommits(emails, commits, event.Repo.GetFullName(), targetUserIdentifiers, showTargetOnly)
+			// event repositories carry "owner/name" in Name and no FullName
+			aggregateCommits(emails, commits, event.Repo.GetName(), targetUserIdentifiers, showTargetOnly, cfg)
 		}
 		processBar.Add(1)
 	}
@@ -113,9 +122,9 @@ func ProcessUserEvents(ctx context.Context, pool *ClientPool, username string, c
 
 	fmt.Println()
 	if commitCount > 0 {
-		color.Green("[+] Extracted %d commits from %d push events", commitCount, len(allEvents))
+		status.Green("[+] Extracted %d commits from %d push events", commitCount, len(allEvents))
 	} else {
-		color.Yellow("[!] No commits found in recent events")
+		status.Yellow("[!] No commits found in recent events")
 	}
 
 	return emails
@@ -124,46 +133,24 @@ func ProcessUserEvents(ctx context.Context, pool *ClientPool, username string, c
 func processEventCommits(event *gh.Event, checkSecrets bool, cfg *Config) []models.CommitInfo {
 	var commits []models.CommitInfo
 
-	payloadData := event.Payload()
-	if payloadData == nil {
-		return commits
-	}
-
-	payload, ok := payloadData.(map[string]interface{})
-	if !ok {
+	payload, err := event.ParsePayload()
+	if err != nil {
 		return commits
 	}
-
-	commitsData, ok := payload["commits"].([]interface{})
+	push, ok := payload.(*gh.PushEvent)
 	if !ok {
 		return commits
 	}
 
-	for _, commitData := range commitsData {
-		commit, ok := commitData.(map[string]interface{})
-		if !ok {
-			continue
-		}
-
+	for _, commit := range push.Commits {
 		var commitInfo models.CommitInfo
 
-		if sha, ok := commit["sha"].(string); ok {
-			commitInfo.Hash = sha
-		}
-		if message, ok := commit["message"].(string); ok {
-			commitInfo.Message = message
-		}
-		if url, ok := commit["url"].(string); ok {
-			commitInfo.URL = url
-		}
-
-		if author, ok := commit["author"].(map[string]interface{}); ok {
-			if name, ok := author["name"].(string); ok {
-				commitInfo.AuthorName = name
-			}
-			if email, ok := author["email"].(string); ok {
-				commitInfo.AuthorEmail = email
-			}
+		commitInfo.Hash = commit.GetSHA()
+		commitInfo.Message = commit.GetMessage()
+		commitInfo.URL = commit.GetURL()
+		if author := commit.GetAuthor(); author != nil {
+			commitInfo.AuthorName = author.GetName()
+			commitInfo.AuthorEmail = author.GetEmail()
 		}
 
 		if event.CreatedAt != nil {
@@ -185,7 +172,7 @@ func processEventCommits(event *gh.Event, checkSecrets bool, cfg *Config) []mode
 		if (checkSecrets || cfg.ShowInteresting) && commitInfo.Message != "" {
 			secretScanner := scanner.NewScanner(cfg.ShowInteresting)
 			commitInfo.Secrets = append(commitInfo.Secrets,
-				scanContent(secretScanner, commitInfo.Message, "commit message", checkSecrets, cfg.ShowInteresting)...)
+				scanContent(secretScanner, commitInfo.Message, "commit message", commitInfo.Hash, checkSecrets, cfg.ShowInteresting, make(map[string]bool))...)
 		}
 
 		commits = append(commits, commitInfo)
@@ -194,6 +181,17 @@ func processEventCommits(event *gh.Event, checkSecrets bool, cfg *Config) []mode
 	return commits
 }
 
+// withoutMerges drops commits with more than one parent.
+func withoutMerges(commits []*gh.RepositoryCommit) []*gh.RepositoryCommit {
+	direct := commits[:0]
+	for _, commit := range commits {
+		if len(commit.Parents) <= 1 {
+			direct = append(direct, commit)
+		}
+	}
+	return direct
+}
+
 func RateLimitedProcessRepos(ctx context.Context, pool *ClientPool, repos []*gh.Repository, checkSecrets bool, cfg *Config, targetUserIdentifiers map[string]bool, showTargetOnly bool, updateChan chan<- EmailUpdate) map[string]*models.EmailDetails {
 	if cfg == nil {
 		cfg = &Config{}
@@ -221,7 +219,7 @@ func RateLimitedProcessRepos(ctx context.Context, pool *ClientPool, repos []*gh.
 		progressbar.OptionShowCount(),
 		progressbar.OptionSetWidth(10),
 		progressbar.OptionSetDescription(progressDescription),
-		progressbar.OptionSetWriter(os.Stderr),
+		progressbar.OptionSetWriter(status.Writer()),
 		progressbar.OptionSetTheme(progressbar.Theme{
 			Saucer:        "[green]#[reset]",
 			SaucerHead:    "[green]>[reset]",
@@ -230,31 +228,48 @@ func RateLimitedProcessRepos(ctx context.Context, pool *ClientPool, repos []*gh.
 			BarEnd:        "[blue]|[reset]",
 		}))
 
+	var histories map[string][]*gh.RepositoryCommit
+	if cfg.GraphQL && cfg.Branch == "" && !cfg.AllBranches {
+		histories = FetchHistoriesGraphQL(ctx, pool.GetClient(), repos, cfg)
+	} else if cfg.GraphQL {
+		status.Yellow("[!] --graphql only lists default branches; using REST for the selected branches")
+	}
+
+	var mutex sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, repoConcurrency(cfg))
+
 	for _, repo := range repos {
-		<-rateLimiter.C
+		wg.Add(1)
+		go func(repo *gh.Repository) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			if pool.Budget().Exhausted() || ctx.Err() != nil {
+				bar.Add(1)
+				return
+			}
 
-		mc := pool.GetClient()
-		repoDirectCommits := 0
-		repoMergeCommits := 0
-		var allRepoCommits []*gh.RepositoryCommit
+			mc := pool.GetClient()
+			repoDirectCommits := 0
+			repoMergeCommits := 0
 
-		perPage := 100
-		if cfg.QuickMode {
-			perPage = 50
-		}
+			allRepoCommits, listed := histories[repo.GetFullName()]
+			if !listed {
+				<-rateLimiter.C
 
-		opts := &gh.CommitsListOptions{
-			ListOptions: gh.ListOptions{PerPage: perPage},
-		}
+				perPage := 100
+				if cfg.QuickMode {
+					perPage = 50
+				}
 
-		for {
-			<-rateLimiter.C
-			commits, resp, _ := mc.Client.Repositories.ListCommits(ctx, repo.GetOwner().GetLogin(), repo.GetName(), opts)
-			if resp != nil {
-				mc.UpdateRateLimit(resp.Rate.Remaining, resp.Rate.Reset.Time)
+				allRepoCommits = listRepoCommits(ctx, mc, repo, cfg, perPage, rateLimiter.C)
 			}
 
-			for _, commit := range commits {
+			allRepoCommits = capCommits(allRepoCommits, cfg)
+
+			for _, commit := range allRepoCommits {
 				if len(commit.Parents) <= 1 {
 					repoDirectCommits++
 				} else {
@@ -262,53 +277,59 @@ func RateLimitedProcessRepos(ctx context.Context, pool *ClientPool, repos []*gh.
 				}
 			}
 
-			allRepoCommits = append(allRepoCommits, commits...)
-
-			if resp == nil || resp.NextPage == 0 || cfg.QuickMode {
-				break
+			// aggregateCommits drops merges too; leaving them out here
+			// saves fetching their patches
+			if cfg.ExcludeMerges {
+				allRepoCommits = withoutMerges(allRepoCommits)
 			}
-			opts.Page = resp.NextPage
-		}
 
-		var repoCommitInfos []models.CommitInfo
-		for _, commit := range allRepoCommits {
 			if (checkSecrets || cfg.ShowInteresting) && !cfg.QuickMode {
-				<-rateLimiter.C
-				fullCommit, getResp, err := mc.Client.Repositories.GetCommit(ctx, repo.GetOwner().GetLogin(), repo.GetName(), commit.GetSHA(), &gh.ListOptions{})
-				if getResp != nil {
-					mc.UpdateRateLimit(getResp.Rate.Remaining, getResp.Rate.Reset.Time)
-				}
-				if err == nil {
-					commit = fullCommit
-				}
+				allRepoCommits = fetchFullCommits(ctx, mc, repo, allRepoCommits, cfg, rateLimiter.C)
 			}
 
-			commitInfo := ProcessCommit(commit, checkSecrets, cfg)
-			if commitInfo.AuthorEmail != "" && strings.Contains(commitInfo.AuthorEmail, "@") {
-				repoCommitInfos = append(repoCommitInfos, commitInfo)
+			var repoCommitInfos []models.CommitInfo
+			for _, commit := range allRepoCommits {
+				commitInfo := ProcessCommit(commit, checkSecrets, cfg)
+				commitInfo.IsFork = repo.GetFork()
+				commitInfo.IsOwnRepo = !repo.GetFork()
+				commitInfo.RepoVisibility = RepoVisibility(repo)
+				if commitInfo.AuthorEmail != "" && strings.Contains(commitInfo.AuthorEmail, "@") {
+					repoCommitInfos = append(repoCommitInfos, commitInfo)
+				} else if commitInfo.AuthorEmail == "" && cfg.IncludeAnonymous {
+					repoCommitInfos = append(repoCommitInfos, commitInfo)
+				}
 			}
-		}
 
-		aggregateCommits(emails, repoCommitInfos, repo.GetFullName(), targetUserIdentifiers, showTargetOnly)
+			mutex.Lock()
+			aggregateCommits(emails, repoCommitInfos, repo.GetFullName(), targetUserIdentifiers, showTargetOnly, cfg)
 
-		if updateChan != nil {
-			for email, details := range emails {
-				if !seenEmails[email] {
-					seenEmails[email] = true
-					updateChan <- EmailUpdate{Email: email, Details: details, RepoName: repo.GetFullName()}
+			if updateChan != nil {
+				for email, details := range emails {
+					if !seenEmails[email] {
+						seenEmails[email] = true
+						// other workers keep adding to details while it is streamed
+						updateChan <- EmailUpdate{Email: email, Details: details.Snapshot(), RepoName: RepoKey(repo.GetFullName())}
+					}
 				}
 			}
-		}
 
-		totalCommitsProcessed += len(allRepoCommits)
-		totalDirectCommits += repoDirectCommits
-		totalMergeCommits += repoMergeCommits
+			totalCommitsProcessed += len(allRepoCommits)
+			totalDirectCommits += repoDirectCommits
+			totalMergeCommits += repoMergeCommits
+			mutex.Unlock()
 
-		bar.Add(1)
+			bar.Add(1)
+		}(repo)
 	}
 
+	wg.Wait()
 	bar.Finish()
 
+	if cfg.ExcludeMerges && totalMergeCommits > 0 {
+		fmt.Println()
+		status.Blue("Excluded %d merge commits from %d total (--exclude-merges)", totalMergeCommits, totalDirectCommits+totalMergeCommits)
+	}
+
 	if len(emails) > 0 {
 		domainStats := make(map[string]int)
 		for email := range emails {
@@ -319,7 +340,7 @@ func RateLimitedProcessRepos(ctx context.Context, pool *ClientPool, repos []*gh.
 		}
 
 		fmt.Println()
-		color.Cyan("Email Domain Distribution (Top 10):")
+		status.Cyan("Email Domain Distribution (Top 10):")
 		type domainCount struct {
 			domain string
 			count  int
@@ -348,72 +369,40 @@ func RateLimitedProcessRepos(ctx context.Context, pool *ClientPool, repos []*gh.
 	return emails
 }
 
-func ProcessReposLimited(ctx context.Context, pool *ClientPool, repos []*gh.Repository, checkSecrets bool, cfg *Config, targetUserIdentifiers map[string]bool, showTargetOnly bool) map[string]*models.EmailDetails {
+// Light scan limits, used unless --max-repos or --max-commits-per-repo set
+// their own.
+const (
+	lightScanRepos   = 10
+	lightScanCommits = 50
+)
+
+// ProcessReposLimited is the default light scan: only the most recently pushed
+// repositories and their newest commits go through RateLimitedProcessRepos.
+// --deep crawls every repository's full history instead.
+func ProcessReposLimited(ctx context.Context, pool *ClientPool, repos []*gh.Repository, checkSecrets bool, cfg *Config, targetUserIdentifiers map[string]bool, showTargetOnly bool, updateChan chan<- EmailUpdate) map[string]*models.EmailDetails {
 	if cfg == nil {
 		cfg = &Config{}
 		*cfg = DefaultConfig()
 	}
 
-	emails := make(map[string]*models.EmailDetails)
-
-	maxRepos := 10
-	maxCommitsPerRepo := 50
-
-	if len(repos) > maxRepos {
-		color.Yellow("[>] Processing only %d most recent repositories (out of %d total)", maxRepos, len(repos))
-		repos = repos[:maxRepos]
+	light := *cfg
+	maxRepos := lightScanRepos
+	if cfg.MaxRepos > 0 {
+		maxRepos = cfg.MaxRepos
 	}
-
-	color.Blue("[>] Light processing: %d repos, max %d recent commits each", len(repos), maxCommitsPerRepo)
-
-	var progressDescription string
-	if checkSecrets && cfg.ShowInteresting {
-		progressDescription = "[cyan]Processing repos (secrets + patterns)[reset]"
-	} else if checkSecrets {
-		progressDescription = "[cyan]Processing repos (secrets)[reset]"
-	} else if cfg.ShowInteresting {
-		progressDescription = "[cyan]Processing repos (patterns)[reset]"
-	} else {
-		progressDescription = "[cyan]Processing repositories[reset]"
+	if light.MaxCommits == 0 {
+		light.MaxCommits = lightScanCommits
 	}
 
-	bar := progressbar.NewOptions(len(repos),
-		progressbar.OptionEnableColorCodes(true),
-		progressbar.OptionShowCount(),
-		progressbar.OptionSetWidth(20),
-		progressbar.OptionSetDescription(progressDescription),
-		progressbar.OptionSetWriter(os.Stderr),
-		progressbar.OptionSetTheme(progressbar.Theme{
-			Saucer:        "[green]=[reset]",
-			SaucerHead:    "[green]>[reset]",
-			SaucerPadding: "[white].[reset]",
-			BarStart:      "[blue]|[reset]",
-			BarEnd:        "[blue]|[reset]",
-		}))
-
-	for _, repo := range repos {
-		time.Sleep(time.Millisecond * 100)
-
-		mc := pool.GetClient()
-		opts := &gh.CommitsListOptions{
-			ListOptions: gh.ListOptions{PerPage: maxCommitsPerRepo},
-		}
-
-		commits, resp, _ := mc.Client.Repositories.ListCommits(ctx, repo.GetOwner().GetLogin(), repo.GetName(), opts)
-		if resp != nil {
-			mc.UpdateRateLimit(resp.Rate.Remaining, resp.Rate.Reset.Time)
-		}
-
-		var repoCommits []models.CommitInfo
-		for _, commit := range commits {
-			commitInfo := ProcessCommit(commit, false, cfg)
-			repoCommits = append(repoCommits, commitInfo)
-		}
-
-		aggregateCommits(emails, repoCommits, repo.GetFullName(), targetUserIdentifiers, showTargetOnly)
-		bar.Add(1)
+	if len(repos) > maxRepos {
+		recent := append([]*gh.Repository(nil), repos...)
+		sort.SliceStable(recent, func(i, j int) bool {
+			return recent[i].GetPushedAt().Time.After(recent[j].GetPushedAt().Time)
+		})
+		status.Yellow("[>] Processing only the %d most recently pushed repositories (out of %d total)", maxRepos, len(repos))
+		repos = recent[:maxRepos]
 	}
 
-	bar.Finish()
-	return emails
+	status.Blue("[>] Light scan: %d repos, up to %d recent commits each (use --deep for full history)", len(repos), light.MaxCommits)
+	return RateLimitedProcessRepos(ctx, pool, repos, checkSecrets, &light, targetUserIdentifiers, showTargetOnly, updateChan)
 }
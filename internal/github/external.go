@@ -3,6 +3,7 @@ package github
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/gnomegl/gitslurp/v2/internal/models"
 	"github.com/gnomegl/gitslurp/v2/internal/scanner"
@@ -38,6 +39,9 @@ func FetchExternalContributions(ctx context.Context, pool *ClientPool, username
 	if resp != nil {
 		mc.UpdateRateLimit(resp.Rate.Remaining, resp.Rate.Reset.Time)
 	}
+	if err != nil && mc.waitForReset(ctx, err) {
+		result, _, err = mc.Client.Search.Commits(ctx, query, opts)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("error searching commits: %v", err)
 	}
@@ -91,6 +95,13 @@ func FetchExternalContributions(ctx context.Context, pool *ClientPool, username
 		}
 	}
 
+	// contributions to the target's own orgs are reported apart from other
+	// people's projects; without membership data everything stays external
+	memberOrgs, err := FetchUserOrgs(ctx, pool.GetClient().Client, username)
+	if err != nil {
+		memberOrgs = nil
+	}
+
 	for _, commitResult := range allResults {
 		if commitResult.Commit == nil || commitResult.Commit.Author == nil {
 			continue
@@ -98,11 +109,14 @@ func FetchExternalContributions(ctx context.Context, pool *ClientPool, username
 
 		email := commitResult.Commit.Author.GetEmail()
 		name := commitResult.Commit.Author.GetName()
-		repoName := commitResult.Repository.GetFullName()
+		repoName := RepoKey(commitResult.Repository.GetFullName())
 
 		if email == "noreply@github.com" {
 			continue
 		}
+		if commitResult.Commit.Author.Date != nil && !cfg.InWindow(commitResult.Commit.Author.Date.Time) {
+			continue
+		}
 
 		if _, ok := emails[email]; !ok {
 			emails[email] = &models.EmailDetails{
@@ -114,15 +128,25 @@ func FetchExternalContributions(ctx context.Context, pool *ClientPool, username
 		emails[email].Names[name] = struct{}{}
 
 		commitInfo := models.CommitInfo{
-			Hash:        commitResult.GetSHA(),
-			URL:         commitResult.GetHTMLURL(),
-			AuthorName:  name,
-			AuthorEmail: email,
-			Message:     commitResult.Commit.GetMessage(),
-			RepoName:    repoName,
-			IsOwnRepo:   false,
-			IsFork:      false,
-			IsExternal:  true,
+			Hash:           commitResult.GetSHA(),
+			URL:            commitResult.GetHTMLURL(),
+			AuthorName:     name,
+			AuthorEmail:    email,
+			Message:        commitResult.Commit.GetMessage(),
+			CoAuthors:      ParseCoAuthors(commitResult.Commit.GetMessage()),
+			RepoName:       RepoName(commitResult.Repository.GetFullName()),
+			IsOwnRepo:      false,
+			IsFork:         false,
+			IsExternal:     true,
+			RepoVisibility: RepoVisibility(commitResult.Repository),
+		}
+		if memberOrgs[strings.ToLower(commitResult.Repository.GetOwner().GetLogin())] {
+			commitInfo.IsExternal = false
+			commitInfo.IsOrgRepo = true
+		}
+
+		if cfg.FindLinks {
+			commitInfo.Links = CommitLinks(commitInfo.Message, nil)
 		}
 
 		if commitResult.Commit.Author != nil && commitResult.Commit.Author.Date != nil {
@@ -142,10 +166,14 @@ func FetchExternalContributions(ctx context.Context, pool *ClientPool, username
 			if commitInfo.Message != "" {
 				matches := secretScanner.ScanText(commitInfo.Message)
 				for _, match := range matches {
+					if scanner.Suppressed(commitInfo.Hash, match) {
+						continue
+					}
 					if match.Type == "Secret" && checkSecrets {
-						commitInfo.Secrets = append(commitInfo.Secrets, fmt.Sprintf("%s: %s", match.Name, match.Value))
+						match.Verdict = scanner.Verify(match, commitInfo.Message)
+						commitInfo.Secrets = append(commitInfo.Secrets, match.Finding(""))
 					} else if match.Type == "Interesting" && cfg.ShowInteresting {
-						commitInfo.Secrets = append(commitInfo.Secrets, fmt.Sprintf("INTERESTING: %s: %s", match.Name, match.Value))
+						commitInfo.Secrets = append(commitInfo.Secrets, match.Finding(""))
 					}
 				}
 			}
@@ -161,13 +189,7 @@ func FetchExternalContributions(ctx context.Context, pool *ClientPool, username
 func mergeExternalContributions(existingEmails map[string]*models.EmailDetails, externalEmails map[string]*models.EmailDetails) {
 	for email, extDetails := range externalEmails {
 		if existing, ok := existingEmails[email]; ok {
-			for name := range extDetails.Names {
-				existing.Names[name] = struct{}{}
-			}
-			for repo, commits := range extDetails.Commits {
-				existing.Commits[repo] = append(existing.Commits[repo], commits...)
-			}
-			existing.CommitCount += extDetails.CommitCount
+			existing.Merge(extDetails)
 		} else {
 			existingEmails[email] = extDetails
 		}
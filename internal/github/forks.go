@@ -0,0 +1,193 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/gnomegl/gitslurp/v2/internal/models"
+	"github.com/gnomegl/gitslurp/v2/internal/status"
+	gh "github.com/google/go-github/v57/github"
+)
+
+// ForkContribution is the work a user pushed to one of their forks that the
+// upstream repository does not have.
+type ForkContribution struct {
+	Fork    string
+	Parent  string
+	AheadBy int
+	Commits []models.CommitInfo
+}
+
+// FetchForkContributions compares each fork the user owns against its parent
+// and keeps the commits ahead of upstream that the user authored. Upstream
+// history shared with the parent is left out, so only the user's own work on
+// forked projects is reported.
+func FetchForkContributions(ctx context.Context, pool *ClientPool, username string, userIdentifiers map[string]bool, checkSecrets bool, cfg *Config) (map[string]*models.EmailDetails, []ForkContribution, error) {
+	if cfg == nil {
+		cfg = &Config{}
+		*cfg = DefaultConfig()
+	}
+
+	forks, err := listUserForks(ctx, pool.GetClient().Client, username, cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	emails := make(map[string]*models.EmailDetails)
+	if len(forks) == 0 {
+		return emails, nil, nil
+	}
+
+	status.Blue("Comparing %d forks against their upstream repositories...", len(forks))
+
+	var contributions []ForkContribution
+	for _, fork := range forks {
+		if pool.Budget().Exhausted() {
+			break
+		}
+
+		contribution, err := compareFork(ctx, pool, fork, username, userIdentifiers)
+		if err != nil {
+			status.Yellow("[!] Could not compare fork %s: %v", fork.GetFullName(), err)
+			continue
+		}
+		if contribution == nil || len(contribution.Commits) == 0 {
+			continue
+		}
+
+		for i := range contribution.Commits {
+			if checkSecrets || cfg.ShowInteresting {
+				ScanCommitContent(&contribution.Commits[i], contribution.Commits[i].Message, nil, checkSecrets, cfg)
+			}
+			if cfg.FindLinks {
+				contribution.Commits[i].Links = CommitLinks(contribution.Commits[i].Message, nil)
+			}
+		}
+
+		AggregateCommits(emails, contribution.Commits, contribution.Fork, cfg)
+		contributions = append(contributions, *contribution)
+	}
+
+	if len(contributions) == 0 {
+		status.Yellow("[!] No commits ahead of upstream found in %d forks", len(forks))
+		return emails, nil, nil
+	}
+
+	total := 0
+	for _, c := range contributions {
+		total += len(c.Commits)
+	}
+	status.Green("[+] Found %d commits of the user's own work across %d forks", total, len(contributions))
+	for _, c := range contributions {
+		fmt.Printf("  %s (fork of %s): %d of %d commits ahead\n", c.Fork, c.Parent, len(c.Commits), c.AheadBy)
+	}
+	fmt.Println()
+
+	return emails, contributions, nil
+}
+
+func listUserForks(ctx context.Context, client *gh.Client, username string, cfg *Config) ([]*gh.Repository, error) {
+	var forks []*gh.Repository
+	opt := &gh.RepositoryListByUserOptions{
+		ListOptions: gh.ListOptions{PerPage: cfg.PerPage},
+		Type:        "owner",
+	}
+
+	for {
+		repos, resp, err := client.Repositories.ListByUser(ctx, username, opt)
+		if err != nil {
+			return nil, fmt.Errorf("error fetching forks: %v", err)
+		}
+		for _, repo := range repos {
+			if repo.GetFork() {
+				forks = append(forks, repo)
+			}
+		}
+
+		if resp.NextPage == 0 || (cfg.MaxRepos > 0 && len(forks) >= cfg.MaxRepos) {
+			break
+		}
+		opt.Page = resp.NextPage
+	}
+
+	if cfg.MaxRepos > 0 && len(forks) > cfg.MaxRepos {
+		forks = forks[:cfg.MaxRepos]
+	}
+	return forks, nil
+}
+
+// compareFork lists the commits on the fork's default branch that are not on
+// the parent's. The compare API returns at most 250 commits.
+func compareFork(ctx context.Context, pool *ClientPool, fork *gh.Repository, username string, userIdentifiers map[string]bool) (*ForkContribution, error) {
+	mc := pool.GetClient()
+	owner, name := fork.GetOwner().GetLogin(), fork.GetName()
+
+	// list results omit the parent, so fetch the full repository
+	full, resp, err := mc.Client.Repositories.Get(ctx, owner, name)
+	if resp != nil {
+		mc.UpdateRateLimit(resp.Rate.Remaining, resp.Rate.Reset.Time)
+	}
+	if err != nil {
+		return nil, err
+	}
+	parent := full.GetParent()
+	if parent == nil {
+		return nil, nil
+	}
+
+	base := fmt.Sprintf("%s:%s", parent.GetOwner().GetLogin(), parent.GetDefaultBranch())
+	comparison, resp, err := mc.Client.Repositories.CompareCommits(ctx, owner, name, base, full.GetDefaultBranch(), nil)
+	if resp != nil {
+		mc.UpdateRateLimit(resp.Rate.Remaining, resp.Rate.Reset.Time)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	contribution := &ForkContribution{
+		Fork:    full.GetFullName(),
+		Parent:  parent.GetFullName(),
+		AheadBy: comparison.GetAheadBy(),
+	}
+
+	for _, rc := range comparison.Commits {
+		commit := rc.GetCommit()
+		if commit == nil {
+			continue
+		}
+		author := commit.GetAuthor()
+		login := rc.GetAuthor().GetLogin()
+		if !strings.EqualFold(login, username) && (author.GetEmail() == "" || !userIdentifiers[author.GetEmail()]) {
+			continue
+		}
+
+		info := models.CommitInfo{
+			Hash:           rc.GetSHA(),
+			URL:            rc.GetHTMLURL(),
+			AuthorName:     author.GetName(),
+			AuthorEmail:    author.GetEmail(),
+			AuthorLogin:    login,
+			Message:        commit.GetMessage(),
+			CoAuthors:      ParseCoAuthors(commit.GetMessage()),
+			RepoName:       contribution.Fork,
+			IsFork:         true,
+			RepoVisibility: RepoVisibility(full),
+		}
+		if author.Date != nil {
+			info.AuthorDate = author.Date.Time
+		}
+		info.Verification = commitVerification(commit.Verification)
+		if committer := commit.GetCommitter(); committer != nil {
+			info.CommitterName = committer.GetName()
+			info.CommitterEmail = committer.GetEmail()
+			if committer.Date != nil {
+				info.CommitterDate = committer.Date.Time
+			}
+		}
+
+		contribution.Commits = append(contribution.Commits, info)
+	}
+
+	return contribution, nil
+}
@@ -3,13 +3,17 @@ package github
 import (
 	"context"
 	"fmt"
+	"sync"
 
-	"github.com/fatih/color"
+	"github.com/gnomegl/gitslurp/v2/internal/status"
 	"github.com/google/go-github/v57/github"
 )
 
-// FetchGists retrieves all public gists for a given username
-func FetchGists(ctx context.Context, client *github.Client, username string, cfg *Config) ([]*github.Gist, error) {
+// FetchGists retrieves all public gists for a given username, along with the
+// content of each one. Content is fetched concurrently with retries; gists
+// whose content could not be retrieved are left out and counted in the
+// returned number of skipped gists.
+func FetchGists(ctx context.Context, client *github.Client, username string, cfg *Config) ([]*github.Gist, int, error) {
 	if cfg == nil {
 		cfg = &Config{}
 		*cfg = DefaultConfig()
@@ -21,28 +25,136 @@ func FetchGists(ctx context.Context, client *github.Client, username string, cfg
 	}
 
 	for {
-		gists, resp, err := client.Gists.List(ctx, username, opt)
+		var gists []*github.Gist
+		var resp *github.Response
+		err := withRetry(ctx, cfg.GistRetries, func() (*github.Response, error) {
+			var err error
+			gists, resp, err = client.Gists.List(ctx, username, opt)
+			return resp, err
+		})
 		if err != nil {
-			return nil, fmt.Errorf("error fetching gists: %v", err)
+			return nil, 0, fmt.Errorf("error fetching gists: %v", err)
 		}
 
-		// Fetch the content of each gist
-		for _, gist := range gists {
-			gistContent, _, err := client.Gists.Get(ctx, gist.GetID())
+		allGists = append(allGists, gists...)
+		if resp.NextPage == 0 {
+			break
+		}
+		opt.Page = resp.NextPage
+	}
+
+	fetched := fetchGistContents(ctx, client, allGists, cfg)
+	if len(fetched) == len(allGists) && len(allGists) > 0 {
+		status.Green("[+] Fetched content for all %d gists", len(allGists))
+	}
+
+	return fetched, len(allGists) - len(fetched), nil
+}
+
+// fetchGistContents fills in the files of each gist and returns the gists
+// whose content was fetched, in their original order.
+func fetchGistContents(ctx context.Context, client *github.Client, gists []*github.Gist, cfg *Config) []*github.Gist {
+	workers := cfg.GistConcurrency
+	if workers < 1 {
+		workers = 1
+	}
+
+	var wg sync.WaitGroup
+	ok := make([]bool, len(gists))
+	sem := make(chan struct{}, workers)
+
+	for i, gist := range gists {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, gist *github.Gist) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			var gistContent *github.Gist
+			err := withRetry(ctx, cfg.GistRetries, func() (*github.Response, error) {
+				var resp *github.Response
+				var err error
+				gistContent, resp, err = client.Gists.Get(ctx, gist.GetID())
+				return resp, err
+			})
 			if err != nil {
-				// Log warning but continue with other gists
-				color.Yellow("[!]  Warning: Could not fetch content for gist %s: %v", gist.GetID(), err)
-				continue
+				status.Yellow("[!]  Warning: Could not fetch content for gist %s: %v", gist.GetID(), err)
+				return
 			}
-			// Update the files with their content
 			gist.Files = gistContent.Files
+			ok[i] = true
+		}(i, gist)
+	}
+	wg.Wait()
+
+	fetched := make([]*github.Gist, 0, len(gists))
+	for i, gist := range gists {
+		if ok[i] {
+			fetched = append(fetched, gist)
 		}
+	}
+	return fetched
+}
 
-		allGists = append(allGists, gists...)
-		if resp.NextPage == 0 {
-			break
+// gistRevisionLimit caps how many revisions of a gist are looked at. Each
+// revision older than the current content costs a request.
+const gistRevisionLimit = 100
+
+type gistRevision struct {
+	commit *github.GistCommit
+	files  map[github.GistFilename]github.GistFile
+}
+
+// fetchGistRevisions returns a gist's revisions, newest first. The newest is
+// the gist's current content and comes without files; older ones carry the
+// files as they were, or none when they could not be fetched.
+func fetchGistRevisions(ctx context.Context, client *github.Client, gist *github.Gist, cfg *Config) []gistRevision {
+	var commits []*github.GistCommit
+	err := withRetry(ctx, cfg.GistRetries, func() (*github.Response, error) {
+		var resp *github.Response
+		var err error
+		commits, resp, err = client.Gists.ListCommits(ctx, gist.GetID(), &github.ListOptions{PerPage: gistRevisionLimit})
+		return resp, err
+	})
+	if err != nil {
+		status.Yellow("[!]  Warning: Could not list revisions of gist %s: %v", gist.GetID(), err)
+		return nil
+	}
+
+	revisions := make([]gistRevision, len(commits))
+	workers := cfg.GistConcurrency
+	if workers < 1 {
+		workers = 1
+	}
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, workers)
+
+	for i, commit := range commits {
+		revisions[i].commit = commit
+		if i == 0 {
+			continue
 		}
-		opt.Page = resp.NextPage
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(rev *gistRevision) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			var old *github.Gist
+			err := withRetry(ctx, cfg.GistRetries, func() (*github.Response, error) {
+				var resp *github.Response
+				var err error
+				old, resp, err = client.Gists.GetRevision(ctx, gist.GetID(), rev.commit.GetVersion())
+				return resp, err
+			})
+			if err != nil {
+				status.Yellow("[!]  Warning: Could not fetch revision %s of gist %s: %v", rev.commit.GetVersion(), gist.GetID(), err)
+				return
+			}
+			rev.files = old.Files
+		}(&revisions[i])
 	}
-	return allGists, nil
+	wg.Wait()
+
+	return revisions
 }
@@ -0,0 +1,305 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gnomegl/gitslurp/v2/internal/status"
+	gh "github.com/google/go-github/v57/github"
+)
+
+// graphqlBatchRepos is how many repositories share one history query. Each
+// returns up to 100 commits, well inside GraphQL's node limit.
+const graphqlBatchRepos = 10
+
+const graphqlHistoryFragment = `
+fragment page on CommitHistoryConnection {
+  pageInfo { hasNextPage endCursor }
+  nodes {
+    oid
+    url
+    message
+    parents { totalCount }
+    author { name email date user { login } }
+    committer { name email date }
+    signature { isValid state signature }
+  }
+}`
+
+type graphqlHistory struct {
+	PageInfo struct {
+		HasNextPage bool   `json:"hasNextPage"`
+		EndCursor   string `json:"endCursor"`
+	} `json:"pageInfo"`
+	Nodes []graphqlCommit `json:"nodes"`
+}
+
+type graphqlRepo struct {
+	DefaultBranchRef *struct {
+		Target struct {
+			History *graphqlHistory `json:"history"`
+		} `json:"target"`
+	} `json:"defaultBranchRef"`
+}
+
+type graphqlActor struct {
+	Name  string     `json:"name"`
+	Email string     `json:"email"`
+	Date  *time.Time `json:"date"`
+	User  *struct {
+		Login string `json:"login"`
+	} `json:"user"`
+}
+
+type graphqlCommit struct {
+	OID     string `json:"oid"`
+	URL     string `json:"url"`
+	Message string `json:"message"`
+	Parents struct {
+		TotalCount int `json:"totalCount"`
+	} `json:"parents"`
+	Author    graphqlActor `json:"author"`
+	Committer graphqlActor `json:"committer"`
+	Signature *struct {
+		IsValid   bool   `json:"isValid"`
+		State     string `json:"state"`
+		Signature string `json:"signature"`
+	} `json:"signature"`
+}
+
+// restCommit converts a history node into the REST shape the rest of the
+// pipeline consumes. Files are left empty; GraphQL has no diffs.
+func (c graphqlCommit) restCommit() *gh.RepositoryCommit {
+	actor := func(a graphqlActor) *gh.CommitAuthor {
+		author := &gh.CommitAuthor{Name: gh.String(a.Name), Email: gh.String(a.Email)}
+		if a.Date != nil {
+			author.Date = &gh.Timestamp{Time: *a.Date}
+		}
+		return author
+	}
+
+	commit := &gh.RepositoryCommit{
+		SHA:     gh.String(c.OID),
+		HTMLURL: gh.String(c.URL),
+		Commit: &gh.Commit{
+			Message:   gh.String(c.Message),
+			Author:    actor(c.Author),
+			Committer: actor(c.Committer),
+		},
+		Parents: make([]*gh.Commit, c.Parents.TotalCount),
+	}
+	if c.Author.User != nil {
+		commit.Author = &gh.User{Login: gh.String(c.Author.User.Login)}
+	}
+
+	verification := &gh.SignatureVerification{Verified: gh.Bool(false), Reason: gh.String("unsigned")}
+	if c.Signature != nil {
+		verification.Verified = gh.Bool(c.Signature.IsValid)
+		verification.Reason = gh.String(strings.ToLower(c.Signature.State))
+		verification.Signature = gh.String(c.Signature.Signature)
+	}
+	commit.Commit.Verification = verification
+	return commit
+}
+
+// graphqlEndpoint derives the GraphQL URL from the REST base URL, which is
+// /api/v3/ on GitHub Enterprise Server.
+func graphqlEndpoint(client *gh.Client) string {
+	base := client.BaseURL.String()
+	if strings.HasSuffix(base, "/api/v3/") {
+		return strings.TrimSuffix(base, "v3/") + "graphql"
+	}
+	return base + "graphql"
+}
+
+func (mc *ManagedClient) graphql(ctx context.Context, query string, variables map[string]interface{}, out interface{}) error {
+	body, err := json.Marshal(map[string]interface{}{"query": query, "variables": variables})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, graphqlEndpoint(mc.Client), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := mc.Client.Client().Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("graphql request failed: %s", resp.Status)
+	}
+
+	var result struct {
+		Data   json.RawMessage `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("failed to decode graphql response: %v", err)
+	}
+	if len(result.Data) == 0 || string(result.Data) == "null" {
+		if len(result.Errors) > 0 {
+			return fmt.Errorf("graphql error: %s", result.Errors[0].Message)
+		}
+		return fmt.Errorf("graphql response has no data")
+	}
+	// per-repository errors leave that alias null; callers fall back for it
+	return json.Unmarshal(result.Data, out)
+}
+
+// historyArgs builds the history() arguments shared by both queries.
+func historyArgs(cfg *Config, vars map[string]interface{}) string {
+	perPage := 100
+	if cfg.QuickMode {
+		perPage = 50
+	}
+	vars["first"] = perPage
+	args := "first: $first"
+	if !cfg.Since.IsZero() {
+		vars["since"] = cfg.Since.Format(time.RFC3339)
+		args += ", since: $since"
+	}
+	if !cfg.Until.IsZero() {
+		vars["until"] = cfg.Until.Format(time.RFC3339)
+		args += ", until: $until"
+	}
+	return args
+}
+
+func historyVarDecls(vars map[string]interface{}) string {
+	decls := "$first: Int!"
+	if _, ok := vars["since"]; ok {
+		decls += ", $since: GitTimestamp"
+	}
+	if _, ok := vars["until"]; ok {
+		decls += ", $until: GitTimestamp"
+	}
+	return decls
+}
+
+// FetchHistoriesGraphQL lists the default-branch history of repos over the
+// GraphQL API (--graphql), several repositories per query. Repositories whose
+// history could not be fetched completely are left out so the caller can list
+// them over REST instead.
+func FetchHistoriesGraphQL(ctx context.Context, mc *ManagedClient, repos []*gh.Repository, cfg *Config) map[string][]*gh.RepositoryCommit {
+	histories := make(map[string][]*gh.RepositoryCommit)
+	requests := 0
+
+	for start := 0; start < len(repos); start += graphqlBatchRepos {
+		if mc.budget.Exhausted() {
+			break
+		}
+		end := start + graphqlBatchRepos
+		if end > len(repos) {
+			end = len(repos)
+		}
+		batch := repos[start:end]
+
+		vars := make(map[string]interface{})
+		args := historyArgs(cfg, vars)
+		decls := historyVarDecls(vars)
+		var fields strings.Builder
+		for i, repo := range batch {
+			vars[fmt.Sprintf("o%d", i)] = repo.GetOwner().GetLogin()
+			vars[fmt.Sprintf("n%d", i)] = repo.GetName()
+			decls += fmt.Sprintf(", $o%d: String!, $n%d: String!", i, i)
+			fmt.Fprintf(&fields, "  r%d: repository(owner: $o%d, name: $n%d) { defaultBranchRef { target { ... on Commit { history(%s) { ...page } } } } }\n", i, i, i, args)
+		}
+		query := fmt.Sprintf("query(%s) {\n%s}\n%s", decls, fields.String(), graphqlHistoryFragment)
+
+		var data map[string]*graphqlRepo
+		requests++
+		if err := mc.graphql(ctx, query, vars, &data); err != nil {
+			continue
+		}
+
+		for i, repo := range batch {
+			r := data[fmt.Sprintf("r%d", i)]
+			if r == nil {
+				continue
+			}
+			if r.DefaultBranchRef == nil || r.DefaultBranchRef.Target.History == nil {
+				// empty repository
+				histories[repo.GetFullName()] = nil
+				continue
+			}
+
+			history := r.DefaultBranchRef.Target.History
+			commits := make([]*gh.RepositoryCommit, 0, len(history.Nodes))
+			for _, node := range history.Nodes {
+				commits = append(commits, node.restCommit())
+			}
+
+			complete := true
+			if history.PageInfo.HasNextPage && !cfg.QuickMode && !cfg.commitCapReached(len(commits)) {
+				var more []*gh.RepositoryCommit
+				var n int
+				more, n, complete = fetchRemainingHistory(ctx, mc, repo, history.PageInfo.EndCursor, cfg)
+				requests += n
+				commits = append(commits, more...)
+			}
+			if complete {
+				histories[repo.GetFullName()] = commits
+			}
+		}
+	}
+
+	if missing := len(repos) - len(histories); missing > 0 {
+		status.Yellow("[!] GraphQL history unavailable for %d of %d repositories, listing them over REST", missing, len(repos))
+	}
+	if len(histories) > 0 {
+		status.Green("[+] Listed %d repository histories over GraphQL in %d requests", len(histories), requests)
+	}
+	return histories
+}
+
+// fetchRemainingHistory pages one repository's history from cursor. It
+// reports how many requests were made and whether the listing finished.
+func fetchRemainingHistory(ctx context.Context, mc *ManagedClient, repo *gh.Repository, cursor string, cfg *Config) ([]*gh.RepositoryCommit, int, bool) {
+	vars := map[string]interface{}{
+		"owner": repo.GetOwner().GetLogin(),
+		"name":  repo.GetName(),
+	}
+	args := historyArgs(cfg, vars) + ", after: $after"
+	query := fmt.Sprintf("query(%s, $owner: String!, $name: String!, $after: String) {\n  r: repository(owner: $owner, name: $name) { defaultBranchRef { target { ... on Commit { history(%s) { ...page } } } } }\n}\n%s",
+		historyVarDecls(vars), args, graphqlHistoryFragment)
+
+	var commits []*gh.RepositoryCommit
+	requests := 0
+	for cursor != "" && !cfg.commitCapReached(len(commits)) {
+		if mc.budget.Exhausted() {
+			return nil, requests, false
+		}
+		vars["after"] = cursor
+
+		var data struct {
+			R *graphqlRepo `json:"r"`
+		}
+		requests++
+		if err := mc.graphql(ctx, query, vars, &data); err != nil {
+			return nil, requests, false
+		}
+		if data.R == nil || data.R.DefaultBranchRef == nil || data.R.DefaultBranchRef.Target.History == nil {
+			return nil, requests, false
+		}
+
+		history := data.R.DefaultBranchRef.Target.History
+		for _, node := range history.Nodes {
+			commits = append(commits, node.restCommit())
+		}
+		cursor = ""
+		if history.PageInfo.HasNextPage {
+			cursor = history.PageInfo.EndCursor
+		}
+	}
+	return commits, requests, true
+}
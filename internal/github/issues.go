@@ -0,0 +1,178 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gnomegl/gitslurp/v2/internal/models"
+	"github.com/gnomegl/gitslurp/v2/internal/scanner"
+	"github.com/gnomegl/gitslurp/v2/internal/status"
+	gh "github.com/google/go-github/v57/github"
+)
+
+// issuePages caps how many pages of 100 issues and 100 comments are read per
+// repository with --scan-issues, newest first.
+const issuePages = 3
+
+// mentionedEmail matches email addresses written in issue and comment text.
+var mentionedEmail = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)
+
+// issueText is an issue, pull request or comment written by the target.
+type issueText struct {
+	number  int
+	url     string
+	body    string
+	created time.Time
+}
+
+// ProcessIssueComments scans the issues, pull requests and comments the
+// user wrote in the given repositories (--scan-issues) for secrets and
+// email addresses, collecting links with --links. Each text becomes a commit under
+// "issues:<owner>/<repo>", filed under the user's noreply address like
+// gists, so findings reach the usual secrets display.
+func ProcessIssueComments(ctx context.Context, pool *ClientPool, repos []*gh.Repository, login string, checkSecrets bool, cfg *Config) map[string]*models.EmailDetails {
+	emails := make(map[string]*models.EmailDetails)
+	email := login + noreplyDomain
+	secretScanner := scanner.NewScanner(cfg.ShowInteresting)
+
+	for _, repo := range repos {
+		if ctx.Err() != nil {
+			break
+		}
+		if repo.GetFork() {
+			continue
+		}
+
+		texts := fetchIssueTexts(ctx, pool, repo.GetOwner().GetLogin(), repo.GetName(), login, cfg)
+		if len(texts) == 0 {
+			continue
+		}
+
+		repoName := "issues:" + RepoKey(repo.GetFullName())
+		if _, ok := emails[email]; !ok {
+			emails[email] = &models.EmailDetails{
+				Names:   map[string]struct{}{login: {}},
+				Commits: make(map[string][]models.CommitInfo),
+			}
+		}
+		seen := make(map[string]bool)
+		for _, text := range texts {
+			info := scanIssueText(secretScanner, text, login, checkSecrets, cfg.ShowInteresting, cfg.FindLinks, seen)
+			info.RepoName = "issues:" + RepoName(repo.GetFullName())
+			emails[email].Commits[repoName] = append(emails[email].Commits[repoName], info)
+			emails[email].CommitCount++
+		}
+	}
+
+	return emails
+}
+
+// scanIssueText turns one issue or comment into a commit carrying its
+// findings and, with findLinks, its links. Email addresses other than GitHub noreply ones are
+// reported as interesting findings even without --interesting.
+func scanIssueText(secretScanner *scanner.Scanner, text issueText, login string, checkSecrets, showInteresting, findLinks bool, seen map[string]bool) models.CommitInfo {
+	info := models.CommitInfo{
+		Hash:          fmt.Sprintf("#%d", text.number),
+		URL:           text.url,
+		AuthorName:    login,
+		AuthorLogin:   login,
+		AuthorDate:    text.created,
+		CommitterDate: text.created,
+		Message:       text.body,
+	}
+	if findLinks {
+		info.Links = scanner.ExtractLinks(text.body)
+	}
+
+	location := fmt.Sprintf("issue #%d", text.number)
+	info.Secrets = scanContent(secretScanner, text.body, location, info.Hash, checkSecrets, showInteresting, seen)
+	for _, loc := range mentionedEmail.FindAllStringIndex(text.body, -1) {
+		value := text.body[loc[0]:loc[1]]
+		key := "email\x00" + strings.ToLower(value)
+		if seen[key] || strings.HasSuffix(strings.ToLower(value), noreplyDomain) {
+			continue
+		}
+		seen[key] = true
+		match := scanner.Match{Type: "Interesting", Name: "Email Address", Value: value, Offset: loc[0]}
+		info.Secrets = append(info.Secrets, match.Located(location, text.body))
+	}
+	return info
+}
+
+// fetchIssueTexts lists the issues, pull requests and comments login wrote in
+// owner/repo. Listing errors are warned about and end that list early.
+func fetchIssueTexts(ctx context.Context, pool *ClientPool, owner, repo, login string, cfg *Config) []issueText {
+	var texts []issueText
+	since := cfg.Since
+
+	issueOpts := &gh.IssueListByRepoOptions{
+		Creator:     login,
+		State:       "all",
+		Sort:        "created",
+		Since:       since,
+		ListOptions: gh.ListOptions{PerPage: 100},
+	}
+	for page := 1; ; page++ {
+		mc := pool.GetClient()
+		issues, resp, err := mc.Client.Issues.ListByRepo(ctx, owner, repo, issueOpts)
+		if resp != nil {
+			mc.UpdateRateLimit(resp.Rate.Remaining, resp.Rate.Reset.Time)
+		}
+		if err != nil {
+			status.Yellow("[!]  Warning: Could not list issues of %s/%s: %v", owner, repo, err)
+			break
+		}
+		for _, issue := range issues {
+			body := strings.TrimSpace(issue.GetTitle() + "\n\n" + issue.GetBody())
+			texts = append(texts, issueText{issue.GetNumber(), issue.GetHTMLURL(), body, issue.GetCreatedAt().Time})
+		}
+		if resp.NextPage == 0 || page >= issuePages {
+			break
+		}
+		issueOpts.Page = resp.NextPage
+	}
+
+	sort, direction := "created", "desc"
+	commentOpts := &gh.IssueListCommentsOptions{
+		Sort:        &sort,
+		Direction:   &direction,
+		ListOptions: gh.ListOptions{PerPage: 100},
+	}
+	if !since.IsZero() {
+		commentOpts.Since = &since
+	}
+	for page := 1; ; page++ {
+		mc := pool.GetClient()
+		// issue number 0 lists the comments of every issue and pull request
+		comments, resp, err := mc.Client.Issues.ListComments(ctx, owner, repo, 0, commentOpts)
+		if resp != nil {
+			mc.UpdateRateLimit(resp.Rate.Remaining, resp.Rate.Reset.Time)
+		}
+		if err != nil {
+			status.Yellow("[!]  Warning: Could not list issue comments of %s/%s: %v", owner, repo, err)
+			break
+		}
+		for _, comment := range comments {
+			if !strings.EqualFold(comment.GetUser().GetLogin(), login) {
+				continue
+			}
+			texts = append(texts, issueText{issueNumber(comment.GetIssueURL()), comment.GetHTMLURL(), comment.GetBody(), comment.GetCreatedAt().Time})
+		}
+		if resp.NextPage == 0 || page >= issuePages {
+			break
+		}
+		commentOpts.Page = resp.NextPage
+	}
+
+	return texts
+}
+
+// issueNumber returns the number at the end of an issue API URL, or 0.
+func issueNumber(issueURL string) int {
+	n, _ := strconv.Atoi(issueURL[strings.LastIndex(issueURL, "/")+1:])
+	return n
+}
@@ -0,0 +1,54 @@
+package github
+
+import (
+	"testing"
+
+	"github.com/gnomegl/gitslurp/v2/internal/models"
+	gh "github.com/google/go-github/v57/github"
+)
+
+func TestExcludeMerges(t *testing.T) {
+	listed := func(sha string, parents int) *gh.RepositoryCommit {
+		return &gh.RepositoryCommit{
+			SHA:     gh.String(sha),
+			Parents: make([]*gh.Commit, parents),
+			Commit: &gh.Commit{
+				Message: gh.String("change " + sha),
+				Author:  &gh.CommitAuthor{Name: gh.String("Dev"), Email: gh.String("dev@example.org")},
+			},
+		}
+	}
+
+	tests := []struct {
+		name          string
+		excludeMerges bool
+		want          int
+	}{
+		{"merges kept by default", false, 3},
+		{"merges dropped with --exclude-merges", true, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.ExcludeMerges = tt.excludeMerges
+
+			var commits []models.CommitInfo
+			for _, c := range []*gh.RepositoryCommit{listed("a1", 1), listed("b2", 2), listed("c3", 0)} {
+				commits = append(commits, ProcessCommit(c, false, &cfg))
+			}
+			if !commits[1].IsMerge || commits[0].IsMerge || commits[2].IsMerge {
+				t.Fatalf("IsMerge = %v %v %v, want only the 2-parent commit", commits[0].IsMerge, commits[1].IsMerge, commits[2].IsMerge)
+			}
+
+			emails := make(map[string]*models.EmailDetails)
+			aggregateCommits(emails, commits, "owner/repo", nil, false, &cfg)
+			details := emails["dev@example.org"]
+			if details == nil {
+				t.Fatal("no details filed for dev@example.org")
+			}
+			if details.CommitCount != tt.want || len(details.Commits["owner/repo"]) != tt.want {
+				t.Errorf("CommitCount = %d with %d commits filed, want %d", details.CommitCount, len(details.Commits["owner/repo"]), tt.want)
+			}
+		})
+	}
+}
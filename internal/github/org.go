@@ -3,8 +3,9 @@ package github
 import (
 	"context"
 	"fmt"
+	"strings"
 
-	"github.com/fatih/color"
+	"github.com/gnomegl/gitslurp/v2/internal/status"
 	"github.com/google/go-github/v57/github"
 )
 
@@ -16,7 +17,7 @@ func FetchOrgRepos(ctx context.Context, client *github.Client, orgName string, c
 	}
 
 	fmt.Println()
-	color.Blue("Enumerating organization repositories...")
+	status.Blue("Enumerating organization repositories...")
 
 	var allRepos []*github.Repository
 	opt := &github.RepositoryListByOrgOptions{
@@ -24,24 +25,91 @@ func FetchOrgRepos(ctx context.Context, client *github.Client, orgName string, c
 		ListOptions: github.ListOptions{PerPage: cfg.PerPage},
 	}
 
+	filteredForks := 0
 	for {
 		repos, resp, err := client.Repositories.ListByOrg(ctx, orgName, opt)
 		if err != nil {
 			return nil, fmt.Errorf("error fetching repositories: %v", err)
 		}
-		allRepos = append(allRepos, repos...)
+		for _, repo := range repos {
+			if !cfg.IncludeForks && repo.GetFork() {
+				filteredForks++
+				continue
+			}
+			allRepos = append(allRepos, repo)
+		}
 
-		if resp.NextPage == 0 {
+		if resp.NextPage == 0 || (cfg.MaxRepos > 0 && len(allRepos) >= cfg.MaxRepos) {
 			break
 		}
 		opt.Page = resp.NextPage
 	}
 
-	color.Green("[+] Found %d organization repositories", len(allRepos))
+	if cfg.MaxRepos > 0 && len(allRepos) > cfg.MaxRepos {
+		status.Yellow("[>] Limiting to %d of the repositories found (--max-repos)", cfg.MaxRepos)
+		allRepos = capRepos(allRepos, cfg)
+	}
+
+	if filteredForks > 0 {
+		status.Green("[+] Found %d organization repositories (%d forks excluded)", len(allRepos), filteredForks)
+	} else {
+		status.Green("[+] Found %d organization repositories", len(allRepos))
+	}
 
 	return allRepos, nil
 }
 
+// FetchOrgMembers returns the logins of an organization's public members,
+// including those who never committed to its repositories.
+func FetchOrgMembers(ctx context.Context, client *github.Client, orgName string) ([]string, error) {
+	var members []string
+	opt := &github.ListMembersOptions{
+		PublicOnly:  true,
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+
+	for {
+		page, resp, err := client.Organizations.ListMembers(ctx, orgName, opt)
+		if err != nil {
+			return nil, fmt.Errorf("error fetching organization members: %v", err)
+		}
+		for _, member := range page {
+			members = append(members, member.GetLogin())
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opt.Page = resp.NextPage
+	}
+
+	return members, nil
+}
+
+// FetchUserOrgs returns the lowercased logins of the organizations a user is a
+// public member of.
+func FetchUserOrgs(ctx context.Context, client *github.Client, username string) (map[string]bool, error) {
+	orgs := make(map[string]bool)
+	opt := &github.ListOptions{PerPage: 100}
+
+	for {
+		page, resp, err := client.Organizations.List(ctx, username, opt)
+		if err != nil {
+			return nil, fmt.Errorf("error fetching organizations: %v", err)
+		}
+		for _, org := range page {
+			orgs[strings.ToLower(org.GetLogin())] = true
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opt.Page = resp.NextPage
+	}
+
+	return orgs, nil
+}
+
 // IsOrganization checks if the given name belongs to a GitHub organization
 func IsOrganization(ctx context.Context, client *github.Client, name string) (bool, error) {
 	_, resp, err := client.Organizations.Get(ctx, name)
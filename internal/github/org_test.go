@@ -0,0 +1,62 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	gh "github.com/google/go-github/v57/github"
+)
+
+// testClient returns a client whose API requests are served by mux.
+func testClient(t *testing.T, mux *http.ServeMux) *gh.Client {
+	t.Helper()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client := gh.NewClient(nil)
+	client.BaseURL, _ = url.Parse(server.URL + "/")
+	return client
+}
+
+func TestFetchOrgReposForks(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/orgs/acme/repos", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[
+			{"name": "api", "full_name": "acme/api"},
+			{"name": "linux", "full_name": "acme/linux", "fork": true},
+			{"name": "web", "full_name": "acme/web"}
+		]`)
+	})
+	client := testClient(t, mux)
+
+	tests := []struct {
+		name         string
+		includeForks bool
+		want         []string
+	}{
+		{"forks excluded by default", false, []string{"acme/api", "acme/web"}},
+		{"forks included with -F", true, []string{"acme/api", "acme/linux", "acme/web"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.IncludeForks = tt.includeForks
+
+			repos, err := FetchOrgRepos(context.Background(), client, "acme", &cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, repo := range repos {
+				got = append(got, repo.GetFullName())
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("repositories = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,166 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gnomegl/gitslurp/v2/internal/scanner"
+	gh "github.com/google/go-github/v57/github"
+)
+
+// pivotCheckInterval spaces out the HEAD requests of --resolve-links.
+const pivotCheckInterval = 500 * time.Millisecond
+
+// PivotLink is an external link from the target's profile: the website
+// field, the bio or the profile README.
+type PivotLink struct {
+	URL      string
+	Sources  []string // website, bio, readme
+	Status   string   // HTTP status with --resolve-links, or why it failed
+	Resolved string   // where redirects ended, when elsewhere
+}
+
+// readmeNoiseHosts serve the images and counters profile READMEs are full of.
+var readmeNoiseHosts = []string{
+	"githubusercontent.com",
+	"github-readme-stats.vercel.app",
+	"github-profile-trophy.vercel.app",
+	"readme-typing-svg.herokuapp.com",
+	"readme-typing-svg.demolab.com",
+	"komarev.com",
+	"skillicons.dev",
+}
+
+// FetchProfileReadme returns the profile README, the README of the
+// <login>/<login> repository, or "" when there is none.
+func FetchProfileReadme(ctx context.Context, client *gh.Client, login string) string {
+	readme, _, err := client.Repositories.GetReadme(ctx, login, login, nil)
+	if err != nil {
+		return ""
+	}
+	content, err := readme.GetContent()
+	if err != nil {
+		return ""
+	}
+	return content
+}
+
+// CollectPivotLinks gathers the links in a profile's website field, bio and
+// README. Links that only differ by scheme, "www." or a trailing slash are
+// merged, and links back to the user's own GitHub pages are dropped.
+func CollectPivotLinks(user *gh.User, readme string) []PivotLink {
+	if user == nil {
+		return nil
+	}
+
+	var links []PivotLink
+	index := make(map[string]int)
+	add := func(link, source string) {
+		key := pivotKey(link)
+		if key == "" || isOwnGitHubLink(key, user.GetLogin()) {
+			return
+		}
+		if i, ok := index[key]; ok {
+			for _, s := range links[i].Sources {
+				if s == source {
+					return
+				}
+			}
+			links[i].Sources = append(links[i].Sources, source)
+			return
+		}
+		index[key] = len(links)
+		links = append(links, PivotLink{URL: link, Sources: []string{source}})
+	}
+
+	if blog := strings.TrimSpace(user.GetBlog()); blog != "" {
+		if !strings.Contains(blog, "://") {
+			blog = "https://" + blog
+		}
+		add(blog, "website")
+	}
+	for _, link := range scanner.ExtractLinks(user.GetBio()) {
+		add(link, "bio")
+	}
+	for _, link := range scanner.ExtractLinks(readme) {
+		if !isReadmeNoise(link) {
+			add(link, "readme")
+		}
+	}
+	return links
+}
+
+// pivotKey is the form links are deduplicated by: host and path, lowercased,
+// without scheme, "www." or a trailing slash.
+func pivotKey(link string) string {
+	if !strings.Contains(link, "://") {
+		link = "https://" + link
+	}
+	u, err := url.Parse(link)
+	if err != nil || u.Hostname() == "" {
+		return ""
+	}
+	host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	key := host + strings.TrimRight(u.EscapedPath(), "/")
+	if u.RawQuery != "" {
+		key += "?" + u.RawQuery
+	}
+	return strings.ToLower(key)
+}
+
+func isOwnGitHubLink(key, login string) bool {
+	own := "github.com/" + strings.ToLower(login)
+	return key == own || strings.HasPrefix(key, own+"/") || strings.HasPrefix(key, own+"?")
+}
+
+func isReadmeNoise(link string) bool {
+	key := pivotKey(link)
+	host, _, _ := strings.Cut(key, "/")
+	for _, noise := range readmeNoiseHosts {
+		if host == noise || strings.HasSuffix(host, "."+noise) {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckPivotLinks sends a HEAD request to each link, one at a time, and
+// records the status and, when redirected elsewhere, the final URL
+// (--resolve-links).
+func CheckPivotLinks(ctx context.Context, links []PivotLink) {
+	client := &http.Client{Timeout: 10 * time.Second}
+	for i := range links {
+		if i > 0 {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(pivotCheckInterval):
+			}
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodHead, links[i].URL, nil)
+		if err != nil {
+			links[i].Status = "invalid URL"
+			continue
+		}
+		req.Header.Set("User-Agent", "gitslurp")
+		resp, err := client.Do(req)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			links[i].Status = "unreachable"
+			continue
+		}
+		resp.Body.Close()
+
+		links[i].Status = fmt.Sprintf("%d", resp.StatusCode)
+		if final := resp.Request.URL.String(); pivotKey(final) != pivotKey(links[i].URL) {
+			links[i].Resolved = final
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	gh "github.com/google/go-github/v57/github"
+)
+
+func TestCollectPivotLinks(t *testing.T) {
+	tests := []struct {
+		name   string
+		blog   string
+		bio    string
+		readme string
+		want   []PivotLink
+	}{
+		{"empty profile", "", "", "", nil},
+		{"website without scheme", "octo.dev", "", "", []PivotLink{{URL: "https://octo.dev", Sources: []string{"website"}}}},
+		{
+			name: "bio links",
+			bio:  "Building things. Blog: https://blog.octo.dev/ | talks at www.speakerdeck.com/octo.",
+			want: []PivotLink{
+				{URL: "https://blog.octo.dev/", Sources: []string{"bio"}},
+				{URL: "www.speakerdeck.com/octo", Sources: []string{"bio"}},
+			},
+		},
+		{
+			name:   "same link in every source",
+			blog:   "https://www.octo.dev/",
+			bio:    "see http://octo.dev",
+			readme: "Website: https://octo.dev/ and again https://octo.dev",
+			want:   []PivotLink{{URL: "https://www.octo.dev/", Sources: []string{"website", "bio", "readme"}}},
+		},
+		{
+			name:   "own GitHub pages and README badges",
+			bio:    "https://github.com/octo/tool https://github.com/octocorp",
+			readme: "![stats](https://github-readme-stats.vercel.app/api?username=octo) ![](https://raw.githubusercontent.com/octo/octo/main/a.gif) https://github.com/Octo?tab=repositories",
+			want:   []PivotLink{{URL: "https://github.com/octocorp", Sources: []string{"bio"}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := &gh.User{Login: gh.String("octo"), Blog: gh.String(tt.blog), Bio: gh.String(tt.bio)}
+			if got := CollectPivotLinks(user, tt.readme); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CollectPivotLinks =\n %+v\nwant\n %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckPivotLinks(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ok", func(w http.ResponseWriter, r *http.Request) {})
+	mux.HandleFunc("/moved", func(w http.ResponseWriter, r *http.Request) { http.Redirect(w, r, "/ok", http.StatusMovedPermanently) })
+	mux.HandleFunc("/gone", func(w http.ResponseWriter, r *http.Request) { http.Error(w, "gone", http.StatusGone) })
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	links := []PivotLink{{URL: server.URL + "/ok"}, {URL: server.URL + "/moved"}, {URL: server.URL + "/gone"}, {URL: "http://unreachable.invalid"}}
+	CheckPivotLinks(context.Background(), links)
+
+	want := []struct{ status, resolved string }{
+		{"200", ""},
+		{"200", server.URL + "/ok"},
+		{"410", ""},
+		{"unreachable", ""},
+	}
+	for i, w := range want {
+		if links[i].Status != w.status || links[i].Resolved != w.resolved {
+			t.Errorf("%s: status %q resolved %q, want %q %q", links[i].URL, links[i].Status, links[i].Resolved, w.status, w.resolved)
+		}
+	}
+}
@@ -9,7 +9,7 @@ import (
 	"sync"
 	"time"
 
-	"github.com/fatih/color"
+	"github.com/gnomegl/gitslurp/v2/internal/status"
 	gh "github.com/google/go-github/v57/github"
 	"golang.org/x/oauth2"
 )
@@ -18,6 +18,9 @@ type ManagedClient struct {
 	Client    *gh.Client
 	Token     string
 	Proxy     string
+	source    oauth2.TokenSource
+	budget    *CallBudget
+	waiter    *rateWaiter
 	remaining int
 	resetAt   time.Time
 	mu        sync.Mutex
@@ -44,22 +47,35 @@ func (mc *ManagedClient) ResetAt() time.Time {
 
 type ClientPool struct {
 	clients []*ManagedClient
+	budget  *CallBudget
+	waiter  *rateWaiter
 	mu      sync.Mutex
 }
 
 func NewClientPool(tokens []string, proxies []string) (*ClientPool, error) {
+	budget := &CallBudget{}
+	waiter := &rateWaiter{}
+
 	if len(tokens) == 0 {
-		client := gh.NewClient(nil)
+		client := gh.NewClient(&http.Client{
+			Transport: &budgetTransport{base: http.DefaultTransport, budget: budget},
+		})
 		return &ClientPool{
 			clients: []*ManagedClient{{
 				Client:    client,
+				budget:    budget,
+				waiter:    waiter,
 				remaining: 60,
 			}},
+			budget: budget,
+			waiter: waiter,
 		}, nil
 	}
 
 	pool := &ClientPool{
 		clients: make([]*ManagedClient, 0, len(tokens)),
+		budget:  budget,
+		waiter:  waiter,
 	}
 
 	for i, token := range tokens {
@@ -68,7 +84,7 @@ func NewClientPool(tokens []string, proxies []string) (*ClientPool, error) {
 			proxyURL = proxies[i]
 		}
 
-		client, err := createClientWithProxy(token, proxyURL)
+		client, err := createClientWithProxy(token, proxyURL, budget)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create client for token %d: %v", i+1, err)
 		}
@@ -77,6 +93,8 @@ func NewClientPool(tokens []string, proxies []string) (*ClientPool, error) {
 			Client:    client,
 			Token:     token,
 			Proxy:     proxyURL,
+			budget:    budget,
+			waiter:    waiter,
 			remaining: 5000,
 		})
 	}
@@ -84,7 +102,38 @@ func NewClientPool(tokens []string, proxies []string) (*ClientPool, error) {
 	return pool, nil
 }
 
-func createClientWithProxy(token, proxyURL string) (*gh.Client, error) {
+// NewAppClientPool builds a single-client pool authenticated as a GitHub App
+// installation. Tokens are minted and refreshed by source as needed.
+func NewAppClientPool(source *AppTokenSource, proxyURL string) (*ClientPool, error) {
+	transport, err := newProxyTransport(proxyURL)
+	if err != nil {
+		return nil, err
+	}
+
+	budget := &CallBudget{}
+	waiter := &rateWaiter{}
+	client := gh.NewClient(&http.Client{
+		Transport: &oauth2.Transport{
+			Source: source,
+			Base:   &budgetTransport{base: transport, budget: budget},
+		},
+	})
+
+	return &ClientPool{
+		clients: []*ManagedClient{{
+			Client:    client,
+			Proxy:     proxyURL,
+			source:    source,
+			budget:    budget,
+			waiter:    waiter,
+			remaining: 5000,
+		}},
+		budget: budget,
+		waiter: waiter,
+	}, nil
+}
+
+func newProxyTransport(proxyURL string) (*http.Transport, error) {
 	transport := &http.Transport{}
 
 	if proxyURL != "" {
@@ -95,6 +144,16 @@ func createClientWithProxy(token, proxyURL string) (*gh.Client, error) {
 		transport.Proxy = http.ProxyURL(parsed)
 	}
 
+	return transport, nil
+}
+
+func createClientWithProxy(token, proxyURL string, budget *CallBudget) (*gh.Client, error) {
+	proxyTransport, err := newProxyTransport(proxyURL)
+	if err != nil {
+		return nil, err
+	}
+	transport := &budgetTransport{base: proxyTransport, budget: budget}
+
 	var httpClient *http.Client
 	if token != "" {
 		ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token})
@@ -156,13 +215,36 @@ func (p *ClientPool) GetClient() *ManagedClient {
 	return best
 }
 
+// PrimaryToken returns the first client's token. For GitHub App pools this is
+// the current installation token, refreshed if it is close to expiry.
 func (p *ClientPool) PrimaryToken() string {
 	if len(p.clients) == 0 {
 		return ""
 	}
+	if src := p.clients[0].source; src != nil {
+		token, err := src.Token()
+		if err != nil {
+			return ""
+		}
+		return token.AccessToken
+	}
 	return p.clients[0].Token
 }
 
+// IsAppAuth reports whether the pool authenticates as a GitHub App installation.
+func (p *ClientPool) IsAppAuth() bool {
+	return len(p.clients) > 0 && p.clients[0].source != nil
+}
+
+// Budget is the request counter and ceiling shared by all of the pool's
+// clients. It is nil for a nil pool.
+func (p *ClientPool) Budget() *CallBudget {
+	if p == nil {
+		return nil
+	}
+	return p.budget
+}
+
 func (p *ClientPool) Size() int {
 	return len(p.clients)
 }
@@ -179,27 +261,22 @@ func (p *ClientPool) DisplayPoolRateLimit(ctx context.Context) {
 
 	fmt.Println()
 	fmt.Println(strings.Repeat("-", 50))
-	color.Cyan("Token Pool Rate Limits (%d tokens):", p.Size())
+	status.Cyan("Token Pool Rate Limits (%d tokens):", p.Size())
 
 	for i, mc := range p.clients {
-		rateLimitInfo, err := GetRateLimit(ctx, mc.Client)
-		if err != nil {
-			color.Yellow("  Token %d: Could not fetch rate limit: %v", i+1, err)
-			continue
-		}
-
-		percentage := float64(rateLimitInfo.Remaining) / float64(rateLimitInfo.Limit) * 100
 		label := fmt.Sprintf("  Token %d", i+1)
 		if mc.Proxy != "" {
 			label += " (proxied)"
 		}
 
-		if percentage > 50 {
-			color.Green("%s: %d/%d (%.1f%%)", label, rateLimitInfo.Remaining, rateLimitInfo.Limit, percentage)
-		} else if percentage > 20 {
-			color.Yellow("%s: %d/%d (%.1f%%)", label, rateLimitInfo.Remaining, rateLimitInfo.Limit, percentage)
-		} else {
-			color.Red("%s: %d/%d (%.1f%%)", label, rateLimitInfo.Remaining, rateLimitInfo.Limit, percentage)
+		limits, err := GetRateLimits(ctx, mc.Client)
+		if err != nil {
+			status.Yellow("%s: Could not fetch rate limit: %v", label, err)
+			continue
+		}
+
+		for _, rl := range limits {
+			printRateLimit(fmt.Sprintf("%s %-7s", label, rl.Category), rl)
 		}
 	}
 }
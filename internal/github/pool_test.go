@@ -0,0 +1,75 @@
+package github
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+func TestNewClientPoolPairsProxies(t *testing.T) {
+	tokens := []string{"tok1", "tok2", "tok3"}
+	proxies := []string{"http://proxy1:8080", "http://proxy2:8080"}
+	pool, err := NewClientPool(tokens, proxies)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pool.Size() != 3 {
+		t.Fatalf("pool size = %d, want 3", pool.Size())
+	}
+
+	req, _ := http.NewRequest("GET", "https://api.github.com/", nil)
+	for i, mc := range pool.AllClients() {
+		wantProxy := ""
+		if i < len(proxies) {
+			wantProxy = proxies[i]
+		}
+		if mc.Token != tokens[i] || mc.Proxy != wantProxy {
+			t.Errorf("client %d = token %q, proxy %q; want %q, %q", i, mc.Token, mc.Proxy, tokens[i], wantProxy)
+		}
+
+		// the proxy must be the one the client's requests go through
+		base := mc.Client.Client().Transport.(*oauth2.Transport).Base.(*budgetTransport).base.(*http.Transport)
+		var got string
+		if base.Proxy != nil {
+			u, err := base.Proxy(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got = u.String()
+		}
+		if got != wantProxy {
+			t.Errorf("client %d sends requests through %q, want %q", i, got, wantProxy)
+		}
+	}
+}
+
+func TestGetClientRotation(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name      string
+		remaining []int
+		resets    []time.Duration
+		want      int
+	}{
+		{"highest remaining budget", []int{1200, 4800, 300}, []time.Duration{time.Hour, time.Hour, time.Hour}, 1},
+		{"first of equal budgets", []int{5000, 5000, 5000}, []time.Duration{time.Hour, time.Hour, time.Hour}, 0},
+		{"all nearly spent, earliest reset", []int{50, 90, 10}, []time.Duration{40 * time.Minute, 30 * time.Minute, 50 * time.Minute}, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pool, err := NewClientPool([]string{"a", "b", "c"}, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			clients := pool.AllClients()
+			for i, mc := range clients {
+				mc.UpdateRateLimit(tt.remaining[i], now.Add(tt.resets[i]))
+			}
+			if got := pool.GetClient(); got != clients[tt.want] {
+				t.Errorf("GetClient picked token %q, want %q", got.Token, clients[tt.want].Token)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/gnomegl/gitslurp/v2/internal/status"
+)
+
+func TestIncludePrivate(t *testing.T) {
+	status.Quiet = true
+	defer func() { status.Quiet = false }()
+
+	var path, visibility, affiliation string
+	mux := http.NewServeMux()
+	serve := func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		visibility = r.URL.Query().Get("visibility")
+		affiliation = r.URL.Query().Get("affiliation")
+		fmt.Fprint(w, `[{"full_name": "octo/public"}, {"full_name": "octo/secret", "private": true}]`)
+	}
+	mux.HandleFunc("/users/octo/repos", serve)
+	mux.HandleFunc("/user/repos", serve)
+	client := testClient(t, mux)
+
+	tests := []struct {
+		name            string
+		includePrivate  bool
+		repoType        string
+		wantPath        string
+		wantAffiliation string
+	}{
+		{"public listing", false, "owner", "/users/octo/repos", ""},
+		{"own repositories", true, "owner", "/user/repos", "owner"},
+		{"member repositories", true, "member", "/user/repos", "collaborator,organization_member"},
+		{"all repositories", true, "all", "/user/repos", "owner,collaborator,organization_member"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path, visibility, affiliation = "", "", ""
+			cfg := DefaultConfig()
+			cfg.IncludePrivate = tt.includePrivate
+			cfg.RepoType = tt.repoType
+
+			repos, err := FetchReposWithUser(context.Background(), client, "octo", &cfg, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if path != tt.wantPath {
+				t.Errorf("listed %s, want %s", path, tt.wantPath)
+			}
+			if tt.includePrivate && visibility != "all" {
+				t.Errorf("visibility = %q, want all", visibility)
+			}
+			if affiliation != tt.wantAffiliation {
+				t.Errorf("affiliation = %q, want %q", affiliation, tt.wantAffiliation)
+			}
+			if len(repos) != 2 {
+				t.Errorf("%d repositories, want 2", len(repos))
+			}
+		})
+	}
+}
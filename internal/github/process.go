@@ -3,16 +3,14 @@ package github
 import (
 	"context"
 	"fmt"
-	"net/url"
-	"os"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/gnomegl/gitslurp/v2/internal/models"
 	"github.com/gnomegl/gitslurp/v2/internal/scanner"
 	"github.com/gnomegl/gitslurp/v2/internal/utils"
 	gh "github.com/google/go-github/v57/github"
-	"github.com/schollz/progressbar/v3"
 	"slices"
 )
 
@@ -26,8 +24,10 @@ func ProcessCommit(commit *gh.RepositoryCommit, checkSecrets bool, cfg *Config)
 
 	if commit.Commit != nil {
 		info.Message = commit.Commit.GetMessage()
+		info.CoAuthors = ParseCoAuthors(info.Message)
 		info.Hash = commit.GetSHA()
 		info.URL = commit.GetHTMLURL()
+		info.IsMerge = len(commit.Parents) > 1
 
 		if commit.Commit.Author != nil {
 			info.AuthorName = commit.Commit.Author.GetName()
@@ -45,328 +45,193 @@ func ProcessCommit(commit *gh.RepositoryCommit, checkSecrets bool, cfg *Config)
 			info.CommitterDate = commit.Commit.Committer.GetDate().Time
 		}
 
-		if info.AuthorEmail == "" && info.CommitterEmail == "" {
+		info.Verification = commitVerification(commit.Commit.Verification)
+
+		// Only label truly anonymous commits; a name without an email is still
+		// attribution worth keeping (web edits, misconfigured clients).
+		if info.AuthorName == "" && info.AuthorEmail == "" {
 			info.AuthorName = "Anonymous"
-			info.AuthorEmail = ""
-			if info.CommitterName == "" {
-				info.CommitterName = "Anonymous"
-			}
-			if info.CommitterEmail == "" {
-				info.CommitterEmail = ""
-			}
+		}
+		if info.CommitterName == "" && info.CommitterEmail == "" {
+			info.CommitterName = "Anonymous"
 		}
 
+		patches := make([]models.FilePatch, 0, len(commit.Files))
+		for _, file := range commit.Files {
+			patches = append(patches, models.FilePatch{Filename: file.GetFilename(), Patch: file.GetPatch()})
+		}
 		if checkSecrets || cfg.ShowInteresting {
-			secretScanner := scanner.NewScanner(cfg.ShowInteresting)
+			ScanCommitContent(&info, commit.GetCommit().GetMessage(), patches, checkSecrets, cfg)
+		}
+		if cfg.FindLinks {
+			info.Links = CommitLinks(commit.GetCommit().GetMessage(), patches)
+		}
+	}
 
-			message := commit.GetCommit().GetMessage()
-			info.Secrets = append(info.Secrets, scanContent(secretScanner, message, "commit message", checkSecrets, cfg.ShowInteresting)...)
+	return info
+}
 
-			for _, file := range commit.Files {
-				filename := file.GetFilename()
-				if cfg.SkipNodeModules && (strings.Contains(filename, "/node_modules/") || strings.HasPrefix(filename, "node_modules/") || filename == "Cargo.lock") {
-					continue
-				}
-				if isPackageManagerFile(filename) {
-					continue
-				}
+// ScanCommitContent scans a commit message and its per-file patches, appending
+// findings to info (and, with IncludePatches, the patches that produced them).
+// Collectors that build commits from sources other than the API share it.
+func ScanCommitContent(info *models.CommitInfo, message string, patches []models.FilePatch, checkSecrets bool, cfg *Config) {
+	secretScanner := scanner.NewScanner(cfg.ShowInteresting)
 
-				if file.GetPatch() != "" {
-					info.Secrets = append(info.Secrets, scanContent(secretScanner, file.GetPatch(), filename, checkSecrets, cfg.ShowInteresting)...)
-				}
+	seen := make(map[string]bool)
+
+	info.Secrets = append(info.Secrets, scanContent(secretScanner, message, "commit message", info.Hash, checkSecrets, cfg.ShowInteresting, seen)...)
+
+	for _, file := range patches {
+		filename := file.Filename
+		if skipScanFile(filename, file.Patch, cfg) {
+			continue
+		}
+
+		if file.Patch != "" {
+			findings := scanContent(secretScanner, file.Patch, filename, info.Hash, checkSecrets, cfg.ShowInteresting, seen)
+			info.Secrets = append(info.Secrets, findings...)
+			if cfg.IncludePatches && len(findings) > 0 {
+				info.Patches = append(info.Patches, file)
 			}
 		}
 	}
-
-	return info
 }
 
-func ExtractLinks(text string) []string {
-	var links []string
-	words := strings.Fields(text)
-
-	for _, word := range words {
-		if strings.HasPrefix(word, "http://") || strings.HasPrefix(word, "https://") {
-			if _, err := url.ParseRequestURI(word); err == nil {
-				links = append(links, word)
+// CommitLinks returns the links in a commit message and in the lines its
+// patches add (--links).
+func CommitLinks(message string, patches []models.FilePatch) []string {
+	text := message
+	for _, file := range patches {
+		for _, line := range strings.Split(file.Patch, "\n") {
+			if strings.HasPrefix(line, "+") && !strings.HasPrefix(line, "+++") {
+				text += "\n" + line[1:]
 			}
 		}
 	}
+	return scanner.ExtractLinks(text)
+}
 
-	return links
+// repoConcurrency returns how many repositories are processed in parallel,
+// falling back to MaxConcurrentRequests for configs built before the split.
+func repoConcurrency(cfg *Config) int {
+	if cfg.RepoConcurrency > 0 {
+		return cfg.RepoConcurrency
+	}
+	if cfg.MaxConcurrentRequests > 0 {
+		return cfg.MaxConcurrentRequests
+	}
+	return 1
 }
 
-func ProcessRepos(ctx context.Context, pool *ClientPool, repos []*gh.Repository, checkSecrets bool, cfg *Config, targetUserIdentifiers map[string]bool, showTargetOnly bool) map[string]*models.EmailDetails {
-	if cfg == nil {
-		cfg = &Config{}
-		*cfg = DefaultConfig()
+// fetchFullCommits replaces each listed commit with its full detail (files and
+// patches), fetching up to cfg.CommitConcurrency details at once. Every
+// request waits for a tick of limiter, so repo- and commit-level workers
+// sharing it stay within the same overall request rate.
+func fetchFullCommits(ctx context.Context, mc *ManagedClient, repo *gh.Repository, commits []*gh.RepositoryCommit, cfg *Config, limiter <-chan time.Time) []*gh.RepositoryCommit {
+	workers := cfg.CommitConcurrency
+	if workers <= 0 {
+		workers = 1
 	}
 
-	emails := make(map[string]*models.EmailDetails)
-	var mutex sync.Mutex
-	sem := make(chan bool, cfg.MaxConcurrentRequests)
+	full := make([]*gh.RepositoryCommit, len(commits))
+	sem := make(chan struct{}, workers)
 	var wg sync.WaitGroup
 
-	var progressDescription string
-	if checkSecrets && cfg.ShowInteresting {
-		progressDescription = "[cyan]Processing repositories (secrets + patterns)[reset]"
-	} else if checkSecrets {
-		progressDescription = "[cyan]Processing repositories (secrets)[reset]"
-	} else if cfg.ShowInteresting {
-		progressDescription = "[cyan]Processing repositories (patterns)[reset]"
-	} else {
-		progressDescription = "[cyan]Processing repositories[reset]"
-	}
-
-	bar := progressbar.NewOptions(len(repos),
-		progressbar.OptionEnableColorCodes(true),
-		progressbar.OptionShowCount(),
-		progressbar.OptionSetWidth(10),
-		progressbar.OptionSetDescription(progressDescription),
-		progressbar.OptionSetWriter(os.Stderr),
-		progressbar.OptionSetTheme(progressbar.Theme{
-			Saucer:        "[green]#[reset]",
-			SaucerHead:    "[green]#[reset]",
-			SaucerPadding: "-",
-			BarStart:      "[",
-			BarEnd:        "]",
-		}))
-
-	for _, repo := range repos {
+	for i, commit := range commits {
 		wg.Add(1)
-		go func(repo *gh.Repository) {
+		// acquired before starting the goroutine, so a long history never
+		// has more than workers of them at once
+		sem <- struct{}{}
+		go func(i int, commit *gh.RepositoryCommit) {
 			defer wg.Done()
-			sem <- true
 			defer func() { <-sem }()
 
-			mc := pool.GetClient()
-			var allCommits []*gh.RepositoryCommit
-			opts := &gh.CommitsListOptions{
-				ListOptions: gh.ListOptions{PerPage: 100},
+			full[i] = commit
+			if cached := loadCachedCommit(cfg, repo.GetFullName(), commit.GetSHA()); cached != nil {
+				full[i] = cached
+				return
 			}
-
+			if mc.budget.Exhausted() || ctx.Err() != nil {
+				return
+			}
+			<-limiter
 			for {
-				commits, resp, err := mc.Client.Repositories.ListCommits(ctx, repo.GetOwner().GetLogin(), repo.GetName(), opts)
+				fullCommit, resp, err := mc.Client.Repositories.GetCommit(ctx, repo.GetOwner().GetLogin(), repo.GetName(), commit.GetSHA(), &gh.ListOptions{})
 				if resp != nil {
 					mc.UpdateRateLimit(resp.Rate.Remaining, resp.Rate.Reset.Time)
 				}
-				if err != nil {
-					break
+				if err != nil && mc.waitForReset(ctx, err) {
+					continue
 				}
-				allCommits = append(allCommits, commits...)
-				if resp.NextPage == 0 {
-					break
+				if err == nil {
+					full[i] = fullCommit
+					storeCachedCommit(cfg, repo.GetFullName(), fullCommit)
 				}
-				opts.Page = resp.NextPage
+				break
 			}
-
-			var repoCommits []models.CommitInfo
-			for _, commit := range allCommits {
-				if checkSecrets || cfg.ShowInteresting {
-					fullCommit, getResp, err := mc.Client.Repositories.GetCommit(ctx, repo.GetOwner().GetLogin(), repo.GetName(), commit.GetSHA(), &gh.ListOptions{})
-					if getResp != nil {
-						mc.UpdateRateLimit(getResp.Rate.Remaining, getResp.Rate.Reset.Time)
-					}
-					if err == nil {
-						commit = fullCommit
-					}
-				}
-				commitInfo := ProcessCommit(commit, checkSecrets, cfg)
-				repoCommits = append(repoCommits, commitInfo)
-			}
-
-			mutex.Lock()
-			aggregateCommits(emails, repoCommits, repo.GetFullName(), targetUserIdentifiers, showTargetOnly)
-			mutex.Unlock()
-
-			bar.Add(1)
-		}(repo)
+		}(i, commit)
 	}
 
 	wg.Wait()
-	bar.Finish()
-	return emails
+	return full
 }
 
+// EmailUpdate streams an email the first time it is seen. Details is a
+// snapshot; aggregation carries on in the original.
 type EmailUpdate struct {
 	Email   string
 	Details *models.EmailDetails
 	RepoName string
 }
 
-func ProcessReposStreaming(ctx context.Context, pool *ClientPool, repos []*gh.Repository, checkSecrets bool, cfg *Config, targetUserIdentifiers map[string]bool, showTargetOnly bool, updateChan chan<- EmailUpdate) map[string]*models.EmailDetails {
-	if cfg == nil {
-		cfg = &Config{}
-		*cfg = DefaultConfig()
-	}
-
-	emails := make(map[string]*models.EmailDetails)
-	var mutex sync.Mutex
-	sem := make(chan bool, cfg.MaxConcurrentRequests)
-	var wg sync.WaitGroup
-
-	var progressDescription string
-	if checkSecrets && cfg.ShowInteresting {
-		progressDescription = "[cyan]Processing repositories (secrets + patterns)[reset]"
-	} else if checkSecrets {
-		progressDescription = "[cyan]Processing repositories (secrets)[reset]"
-	} else if cfg.ShowInteresting {
-		progressDescription = "[cyan]Processing repositories (patterns)[reset]"
-	} else {
-		progressDescription = "[cyan]Processing repositories[reset]"
-	}
-
-	bar := progressbar.NewOptions(len(repos),
-		progressbar.OptionEnableColorCodes(true),
-		progressbar.OptionShowCount(),
-		progressbar.OptionSetWidth(10),
-		progressbar.OptionSetDescription(progressDescription),
-		progressbar.OptionSetWriter(os.Stderr),
-		progressbar.OptionSetTheme(progressbar.Theme{
-			Saucer:        "[green]#[reset]",
-			SaucerHead:    "[green]#[reset]",
-			SaucerPadding: "-",
-			BarStart:      "[",
-			BarEnd:        "]",
-		}))
-
-	for _, repo := range repos {
-		wg.Add(1)
-		go func(repo *gh.Repository) {
-			defer wg.Done()
-			sem <- true
-			defer func() { <-sem }()
-
-			mc := pool.GetClient()
-			var allCommits []*gh.RepositoryCommit
-			opts := &gh.CommitsListOptions{
-				ListOptions: gh.ListOptions{PerPage: 100},
-			}
-
-			for {
-				commits, resp, err := mc.Client.Repositories.ListCommits(ctx, repo.GetOwner().GetLogin(), repo.GetName(), opts)
-				if resp != nil {
-					mc.UpdateRateLimit(resp.Rate.Remaining, resp.Rate.Reset.Time)
-				}
-				if err != nil {
-					break
-				}
-				allCommits = append(allCommits, commits...)
-				if resp.NextPage == 0 {
-					break
-				}
-				opts.Page = resp.NextPage
+// scanContent formats the matches found in text. Matches already recorded in
+// seen (by type, name and value) are skipped, so a secret repeated across a
+// commit's message and files is reported once, at its first location, and
+// matches listed for commit in the --baseline file are dropped.
+func scanContent(secretScanner *scanner.Scanner, text, location, commit string, checkSecrets bool, showInteresting bool, seen map[string]bool) []models.SecretFinding {
+	var findings []models.SecretFinding
+	if matches := secretScanner.ScanText(text); len(matches) > 0 {
+		for _, match := range matches {
+			key := match.Type + "\x00" + match.Name + "\x00" + match.Value
+			if seen[key] || scanner.Suppressed(commit, match) {
+				continue
 			}
+			seen[key] = true
 
-			var repoCommits []models.CommitInfo
-			for _, commit := range allCommits {
-				if checkSecrets || cfg.ShowInteresting {
-					fullCommit, getResp, err := mc.Client.Repositories.GetCommit(ctx, repo.GetOwner().GetLogin(), repo.GetName(), commit.GetSHA(), &gh.ListOptions{})
-					if getResp != nil {
-						mc.UpdateRateLimit(getResp.Rate.Remaining, getResp.Rate.Reset.Time)
-					}
-					if err == nil {
-						commit = fullCommit
-					}
-				}
-				commitInfo := ProcessCommit(commit, checkSecrets, cfg)
-				repoCommits = append(repoCommits, commitInfo)
+			if (match.Type == "Secret" && checkSecrets) || (match.Type == "Interesting" && showInteresting) {
+				match.Verdict = scanner.Verify(match, text)
+				findings = append(findings, match.Located(location, text))
 			}
-
-			mutex.Lock()
-			newEmails := aggregateCommitsStreaming(emails, repoCommits, repo.GetFullName(), targetUserIdentifiers, showTargetOnly)
-			for email, details := range newEmails {
-				if updateChan != nil {
-					updateChan <- EmailUpdate{
-						Email:   email,
-						Details: details,
-						RepoName: repo.GetFullName(),
-					}
-				}
-			}
-			mutex.Unlock()
-
-			bar.Add(1)
-		}(repo)
-	}
-
-	wg.Wait()
-	bar.Finish()
-	if updateChan != nil {
-		close(updateChan)
+		}
 	}
-	return emails
+	return findings
 }
 
-func aggregateCommitsStreaming(emails map[string]*models.EmailDetails, commits []models.CommitInfo, repoName string, targetUserIdentifiers map[string]bool, showTargetOnly bool) map[string]*models.EmailDetails {
-	newEmails := make(map[string]*models.EmailDetails)
+// ProcessGists scans each gist's current files and, when the gist has been
+// edited, its earlier revisions. Every revision is recorded as a commit, and
+// a finding is reported at the newest revision that still contains it, so
+// secrets later removed from a gist show up on the revision that had them.
+func ProcessGists(ctx context.Context, pool *ClientPool, gists []*gh.Gist, checkSecrets bool, cfg *Config) map[string]*models.EmailDetails {
+	emails := make(map[string]*models.EmailDetails)
 
-	for _, commit := range commits {
-		if commit.AuthorEmail == "" {
+	for _, gist := range gists {
+		if gist.Owner == nil || gist.Owner.Login == nil {
 			continue
 		}
 
-		if showTargetOnly && targetUserIdentifiers != nil {
-			isTargetUser := targetUserIdentifiers[commit.AuthorEmail] || targetUserIdentifiers[commit.AuthorName]
-			if !isTargetUser {
-				continue
-			}
-		}
-
-		email := commit.AuthorEmail
-		isNew := false
+		email := fmt.Sprintf("%s@users.noreply.github.com", gist.GetOwner().GetLogin())
 		if _, exists := emails[email]; !exists {
 			emails[email] = &models.EmailDetails{
-				Names:   make(map[string]struct{}),
-				Commits: make(map[string][]models.CommitInfo),
-			}
-			isNew = true
-		}
-
-		details := emails[email]
-		details.Names[commit.AuthorName] = struct{}{}
-		details.Commits[repoName] = append(details.Commits[repoName], commit)
-		details.CommitCount++
-
-		if isNew {
-			detailsCopy := &models.EmailDetails{
 				Names:       make(map[string]struct{}),
 				Commits:     make(map[string][]models.CommitInfo),
-				CommitCount: details.CommitCount,
-			}
-			for name := range details.Names {
-				detailsCopy.Names[name] = struct{}{}
-			}
-			for repo, commits := range details.Commits {
-				detailsCopy.Commits[repo] = append([]models.CommitInfo{}, commits...)
-			}
-			newEmails[email] = detailsCopy
-		}
-	}
-
-	return newEmails
-}
-
-func scanContent(scanner *scanner.Scanner, text, location string, checkSecrets bool, showInteresting bool) []string {
-	var findings []string
-	if matches := scanner.ScanText(text); len(matches) > 0 {
-		for _, match := range matches {
-			if match.Type == "Secret" && checkSecrets {
-				findings = append(findings, fmt.Sprintf("%s: %s (in %s)", match.Name, match.Value, location))
-			} else if match.Type == "Interesting" && showInteresting {
-				findings = append(findings, fmt.Sprintf("INTERESTING: %s: %s (in %s)", match.Name, match.Value, location))
+				CommitCount: 0,
 			}
 		}
-	}
-	return findings
-}
-
-func ProcessGists(ctx context.Context, pool *ClientPool, gists []*gh.Gist, checkSecrets bool, cfg *Config) map[string]*models.EmailDetails {
-	emails := make(map[string]*models.EmailDetails)
-
-	for _, gist := range gists {
-		if gist.Owner == nil || gist.Owner.Login == nil {
-			continue
+		gistName := fmt.Sprintf("gist:%s", gist.GetID())
+		record := func(commitInfo models.CommitInfo) {
+			emails[email].Names[commitInfo.AuthorName] = struct{}{}
+			emails[email].Commits[gistName] = append(emails[email].Commits[gistName], commitInfo)
+			emails[email].CommitCount++
 		}
 
 		commitInfo := models.CommitInfo{
@@ -376,42 +241,45 @@ func ProcessGists(ctx context.Context, pool *ClientPool, gists []*gh.Gist, check
 			AuthorEmail: "",
 		}
 
-		if checkSecrets || cfg.ShowInteresting {
-			secretScanner := scanner.NewScanner(cfg.ShowInteresting)
-
-			commitInfo.Secrets = append(commitInfo.Secrets, scanContent(secretScanner, gist.GetDescription(), "description", checkSecrets, cfg.ShowInteresting)...)
+		if !checkSecrets && !cfg.ShowInteresting {
+			record(commitInfo)
+			continue
+		}
 
-			for filename, file := range gist.Files {
-				if cfg.SkipNodeModules && (strings.Contains(string(filename), "/node_modules/") || strings.HasPrefix(string(filename), "node_modules/")) {
+		secretScanner := scanner.NewScanner(cfg.ShowInteresting)
+		seen := make(map[string]bool)
+		scanFiles := func(commitInfo *models.CommitInfo, files map[gh.GistFilename]gh.GistFile) {
+			for filename, file := range files {
+				content := file.GetContent()
+				if content == "" || skipScanFile(string(filename), content, cfg) {
 					continue
 				}
-				if isPackageManagerFile(string(filename)) {
-					continue
-				}
-
-				if content := file.GetContent(); content != "" {
-					commitInfo.Secrets = append(commitInfo.Secrets, scanContent(secretScanner, content, string(filename), checkSecrets, cfg.ShowInteresting)...)
-				}
+				commitInfo.Secrets = append(commitInfo.Secrets, scanContent(secretScanner, content, string(filename), commitInfo.Hash, checkSecrets, cfg.ShowInteresting, seen)...)
 			}
 		}
 
-		email := commitInfo.AuthorEmail
-		if email == "" {
-			email = fmt.Sprintf("%s@users.noreply.github.com", gist.GetOwner().GetLogin())
+		revisions := fetchGistRevisions(ctx, pool.GetClient().Client, gist, cfg)
+		if len(revisions) > 0 {
+			commitInfo.AuthorDate = revisions[0].commit.GetCommittedAt().Time
+			commitInfo.CommitterDate = commitInfo.AuthorDate
 		}
-
-		if _, exists := emails[email]; !exists {
-			emails[email] = &models.EmailDetails{
-				Names:       make(map[string]struct{}),
-				Commits:     make(map[string][]models.CommitInfo),
-				CommitCount: 0,
+		commitInfo.Secrets = append(commitInfo.Secrets, scanContent(secretScanner, gist.GetDescription(), "description", commitInfo.Hash, checkSecrets, cfg.ShowInteresting, seen)...)
+		scanFiles(&commitInfo, gist.Files)
+		record(commitInfo)
+
+		for _, rev := range revisions[min(1, len(revisions)):] {
+			revInfo := models.CommitInfo{
+				Hash:          rev.commit.GetVersion(),
+				URL:           fmt.Sprintf("%s/%s", gist.GetHTMLURL(), rev.commit.GetVersion()),
+				AuthorName:    gist.GetOwner().GetLogin(),
+				AuthorLogin:   rev.commit.GetUser().GetLogin(),
+				AuthorDate:    rev.commit.GetCommittedAt().Time,
+				CommitterDate: rev.commit.GetCommittedAt().Time,
+				Message:       "gist revision",
 			}
+			scanFiles(&revInfo, rev.files)
+			record(revInfo)
 		}
-
-		emails[email].Names[commitInfo.AuthorName] = struct{}{}
-		gistName := fmt.Sprintf("gist:%s", gist.GetID())
-		emails[email].Commits[gistName] = append(emails[email].Commits[gistName], commitInfo)
-		emails[email].CommitCount++
 	}
 
 	return emails
@@ -437,30 +305,100 @@ func isPackageManagerFile(filename string) bool {
 	return slices.Contains(packageFiles, filename)
 }
 
-func aggregateCommits(emails map[string]*models.EmailDetails, commits []models.CommitInfo, repoName string, targetUserIdentifiers map[string]bool, showTargetOnly bool) {
+// AnonymousPrefix marks aggregation keys for commits that carry an author name
+// but no email (bucketed by name with --include-anonymous).
+const AnonymousPrefix = "anonymous:"
+
+// identityKey returns the key a commit is aggregated under: its author email,
+// or with includeAnonymous the prefixed author name for email-less commits.
+// An empty key means the commit is dropped.
+func identityKey(commit models.CommitInfo, includeAnonymous bool) string {
+	if commit.AuthorEmail != "" {
+		return commit.AuthorEmail
+	}
+	if includeAnonymous && commit.AuthorName != "" && commit.AuthorName != "Anonymous" {
+		return AnonymousPrefix + commit.AuthorName
+	}
+	return ""
+}
+
+// AggregateCommits groups commits built outside the API path (such as a local
+// clone) under the same identity keys RateLimitedProcessRepos uses.
+func AggregateCommits(emails map[string]*models.EmailDetails, commits []models.CommitInfo, repoName string, cfg *Config) {
+	aggregateCommits(emails, commits, repoName, nil, false, cfg)
+}
+
+// aggregateCommits files commits under their identity keys, dropping those
+// outside the --since/--until window and, with --exclude-merges, merges.
+func aggregateCommits(emails map[string]*models.EmailDetails, commits []models.CommitInfo, repoName string, targetUserIdentifiers map[string]bool, showTargetOnly bool, cfg *Config) {
+	displayName := RepoName(repoName)
+	repoName = RepoKey(repoName)
 	for _, commit := range commits {
-		if commit.AuthorEmail == "" {
+		if !cfg.InWindow(commit.AuthorDate) || (cfg.ExcludeMerges && commit.IsMerge) {
 			continue
 		}
-
-		if showTargetOnly && targetUserIdentifiers != nil {
-			isTargetUser := targetUserIdentifiers[commit.AuthorEmail] || targetUserIdentifiers[commit.AuthorName]
-			if !isTargetUser {
-				continue
+		commit.RepoName = displayName
+		for _, id := range commitIdentities(commit, targetUserIdentifiers, showTargetOnly, cfg) {
+			if _, exists := emails[id.key]; !exists {
+				emails[id.key] = &models.EmailDetails{
+					Names:   make(map[string]struct{}),
+					Commits: make(map[string][]models.CommitInfo),
+				}
 			}
+
+			details := emails[id.key]
+			details.Names[id.name] = struct{}{}
+			details.Commits[repoName] = append(details.Commits[repoName], commit)
+			details.CommitCount++
 		}
+	}
+}
 
-		email := commit.AuthorEmail
-		if _, exists := emails[email]; !exists {
-			emails[email] = &models.EmailDetails{
-				Names:   make(map[string]struct{}),
-				Commits: make(map[string][]models.CommitInfo),
-			}
+// commitIdentity is a key a commit is filed under and the name it carries.
+type commitIdentity struct {
+	key, name string
+}
+
+// commitIdentities returns the author's identity, each co-author from the
+// message trailers and, with --include-committers, the committer's when it is
+// a different address. GitHub's own web-flow committer is left out. With
+// showTargetOnly only identities belonging to the target are kept.
+func commitIdentities(commit models.CommitInfo, targetUserIdentifiers map[string]bool, showTargetOnly bool, cfg *Config) []commitIdentity {
+	keep := func(email, name string) bool {
+		return !showTargetOnly || targetUserIdentifiers == nil || targetUserIdentifiers[email] || targetUserIdentifiers[name]
+	}
+
+	var ids []commitIdentity
+	if key := identityKey(commit, cfg.IncludeAnonymous); key != "" && keep(commit.AuthorEmail, commit.AuthorName) {
+		ids = append(ids, commitIdentity{key, commit.AuthorName})
+	}
+	if cfg.IncludeCommitters && isCommitterIdentity(commit) && keep(commit.CommitterEmail, commit.CommitterName) {
+		ids = append(ids, commitIdentity{commit.CommitterEmail, commit.CommitterName})
+	}
+	for _, co := range commit.CoAuthors {
+		if strings.EqualFold(co.Email, commit.AuthorEmail) || keyed(ids, co.Email) || !keep(co.Email, co.Name) {
+			continue
 		}
+		ids = append(ids, commitIdentity{co.Email, co.Name})
+	}
+	return ids
+}
 
-		details := emails[email]
-		details.Names[commit.AuthorName] = struct{}{}
-		details.Commits[repoName] = append(details.Commits[repoName], commit)
-		details.CommitCount++
+func keyed(ids []commitIdentity, email string) bool {
+	for _, id := range ids {
+		if strings.EqualFold(id.key, email) {
+			return true
+		}
 	}
+	return false
+}
+
+// isCommitterIdentity reports whether the committer is an address of its own:
+// set, not the author's, and not GitHub's web-flow committer.
+func isCommitterIdentity(commit models.CommitInfo) bool {
+	email := commit.CommitterEmail
+	return email != "" && !strings.EqualFold(email, commit.AuthorEmail) && !strings.EqualFold(email, webFlowEmail)
 }
+
+// webFlowEmail commits everything made through the GitHub web UI.
+const webFlowEmail = "noreply@github.com"
@@ -0,0 +1,113 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"path"
+	"reflect"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	gh "github.com/google/go-github/v57/github"
+)
+
+func TestRepoConcurrency(t *testing.T) {
+	tests := []struct {
+		repo, legacy, want int
+	}{
+		{8, 5, 8},
+		{0, 5, 5},
+		{0, 0, 1},
+	}
+	for _, tt := range tests {
+		cfg := DefaultConfig()
+		cfg.RepoConcurrency = tt.repo
+		cfg.MaxConcurrentRequests = tt.legacy
+		if got := repoConcurrency(&cfg); got != tt.want {
+			t.Errorf("repoConcurrency(%d, %d) = %d, want %d", tt.repo, tt.legacy, got, tt.want)
+		}
+	}
+}
+
+func TestProcessCommitLinks(t *testing.T) {
+	commit := &gh.RepositoryCommit{
+		SHA:    gh.String("abc123"),
+		Commit: &gh.Commit{Message: gh.String("Add blog link, see https://octo.dev/post."), Author: &gh.CommitAuthor{Email: gh.String("octo@octo.dev")}},
+		Files: []*gh.CommitFile{{
+			Filename: gh.String("README.md"),
+			Patch:    gh.String("@@ -1,2 +1,2 @@\n-Old site: https://old.octo.dev\n+New site: https://octo.dev/post\n+Mirror: https://mirror.octo.dev\n"),
+		}},
+	}
+
+	tests := []struct {
+		findLinks bool
+		want      []string
+	}{
+		{false, nil},
+		// removed lines are not the author's current links
+		{true, []string{"https://mirror.octo.dev", "https://octo.dev/post"}},
+	}
+	for _, tt := range tests {
+		cfg := DefaultConfig()
+		cfg.FindLinks = tt.findLinks
+		if got := ProcessCommit(commit, false, &cfg).Links; !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("links with FindLinks %v = %q, want %q", tt.findLinks, got, tt.want)
+		}
+	}
+}
+
+func TestFetchFullCommitsBounded(t *testing.T) {
+	var mu sync.Mutex
+	inFlight, maxInFlight, maxGoroutines := 0, 0, 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/octo/tool/commits/", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		inFlight++
+		maxInFlight = max(maxInFlight, inFlight)
+		maxGoroutines = max(maxGoroutines, runtime.NumGoroutine())
+		mu.Unlock()
+		time.Sleep(time.Millisecond)
+		fmt.Fprintf(w, `{"sha": "%s", "files": [{"filename": "a.go", "patch": "+x"}]}`, path.Base(r.URL.Path))
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+	})
+	pool := testPool(t, mux)
+	mc := pool.GetClient()
+	repo := &gh.Repository{Name: gh.String("tool"), FullName: gh.String("octo/tool"), Owner: &gh.User{Login: gh.String("octo")}}
+
+	commits := make([]*gh.RepositoryCommit, 500)
+	for i := range commits {
+		commits[i] = &gh.RepositoryCommit{SHA: gh.String(fmt.Sprint(i))}
+	}
+	limiter := make(chan time.Time)
+	close(limiter)
+
+	tests := []int{1, 4}
+	for _, workers := range tests {
+		t.Run(fmt.Sprintf("%d workers", workers), func(t *testing.T) {
+			maxInFlight, maxGoroutines = 0, 0
+			base := runtime.NumGoroutine()
+			cfg := DefaultConfig()
+			cfg.CommitConcurrency = workers
+
+			full := fetchFullCommits(context.Background(), mc, repo, commits, &cfg, limiter)
+
+			for i, commit := range full {
+				if len(commit.Files) != 1 {
+					t.Fatalf("commit %d has no patch", i)
+				}
+			}
+			if maxInFlight > workers {
+				t.Errorf("%d requests in flight, want at most %d", maxInFlight, workers)
+			}
+			// the test server and HTTP connections add a few of their own
+			if extra := maxGoroutines - base; extra > 10*workers+20 {
+				t.Errorf("%d goroutines above the baseline for %d commits, want them bounded by the workers", extra, len(commits))
+			}
+		})
+	}
+}
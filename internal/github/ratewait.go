@@ -0,0 +1,123 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/gnomegl/gitslurp/v2/internal/status"
+	gh "github.com/google/go-github/v57/github"
+)
+
+// rateWaiter is shared by a pool's clients. When enabled, hitting the primary
+// rate limit sleeps until the reset instead of giving up on the request. The
+// mutex makes concurrent workers share one wait and one countdown.
+type rateWaiter struct {
+	mu      sync.Mutex
+	enabled bool
+	maxWait time.Duration // 0 waits for any reset
+	waited  time.Time     // the last reset waited out
+}
+
+// staleResetBackoff is slept before retrying when the reported reset has
+// already passed and was not just waited out by another worker, so a limit
+// GitHub keeps enforcing past its reset is not retried in a hot loop.
+const staleResetBackoff = 5 * time.Second
+
+// SetWaitOnRateLimit makes the pool's clients wait for the rate limit to
+// reset and retry, rather than returning partial results (--wait).
+func (p *ClientPool) SetWaitOnRateLimit(enabled bool) {
+	if p == nil || p.waiter == nil {
+		return
+	}
+	p.waiter.mu.Lock()
+	p.waiter.enabled = enabled
+	p.waiter.mu.Unlock()
+}
+
+// SetMaxRateLimitWait caps a single wait for the rate limit to reset
+// (--max-wait). Resets further away than d are not waited for.
+func (p *ClientPool) SetMaxRateLimitWait(d time.Duration) {
+	if p == nil || p.waiter == nil {
+		return
+	}
+	p.waiter.mu.Lock()
+	p.waiter.maxWait = d
+	p.waiter.mu.Unlock()
+}
+
+// waitForReset reports whether err is a primary rate-limit error that was
+// waited out, in which case the caller should retry the request. It gives up
+// when waiting is disabled, the reset falls after the context deadline, or
+// the context is cancelled, or the reset is further away than --max-wait.
+// A missing reset time is never retried, and a reset that has already
+// passed is retried after staleResetBackoff.
+func (mc *ManagedClient) waitForReset(ctx context.Context, err error) bool {
+	var rateErr *gh.RateLimitError
+	if mc.waiter == nil || err == nil || !errors.As(err, &rateErr) {
+		return false
+	}
+
+	w := mc.waiter
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if !w.enabled {
+		return false
+	}
+
+	reset := rateErr.Rate.Reset.Time
+	if reset.IsZero() {
+		reset = mc.ResetAt()
+	}
+	if reset.IsZero() {
+		return false
+	}
+	if !time.Now().Before(reset) {
+		// another worker may have just waited this reset out
+		if reset.Equal(w.waited) && time.Since(reset) < staleResetBackoff {
+			return true
+		}
+		// back off without holding up the other workers
+		w.mu.Unlock()
+		defer w.mu.Lock()
+		select {
+		case <-ctx.Done():
+			return false
+		case <-time.After(staleResetBackoff):
+			return true
+		}
+	}
+	if deadline, ok := ctx.Deadline(); ok && reset.After(deadline) {
+		status.Yellow("\n[!] Rate limit resets at %s, after the run deadline; not waiting", reset.Local().Format("15:04:05"))
+		return false
+	}
+	if w.maxWait > 0 && time.Until(reset) > w.maxWait {
+		status.Yellow("\n[!] Rate limit resets at %s, more than --max-wait %s away; not waiting", reset.Local().Format("15:04:05"), w.maxWait)
+		return false
+	}
+
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		left := time.Until(reset).Round(time.Second)
+		if left <= 0 {
+			break
+		}
+		fmt.Fprintf(status.Writer(), "\r%s", color.YellowString("[*] Rate limit exhausted, resuming in %s (--wait)   ", left))
+		select {
+		case <-ctx.Done():
+			fmt.Fprintln(status.Writer())
+			return false
+		case <-ticker.C:
+		}
+	}
+	fmt.Fprintf(status.Writer(), "\r%s\n", color.GreenString("[+] Rate limit reset, resuming%40s", ""))
+	w.waited = reset
+
+	// a little slack so the first retried request lands after the reset
+	time.Sleep(time.Second)
+	return true
+}
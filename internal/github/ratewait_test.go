@@ -0,0 +1,58 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	gh "github.com/google/go-github/v57/github"
+)
+
+func TestWaitForResetGivesUp(t *testing.T) {
+	rateErr := func(reset time.Time) error {
+		return &gh.RateLimitError{Rate: gh.Rate{Reset: gh.Timestamp{Time: reset}}}
+	}
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name    string
+		enabled bool
+		ctx     context.Context
+		err     error
+	}{
+		{"disabled", false, context.Background(), rateErr(time.Now().Add(time.Minute))},
+		{"not a rate limit error", true, context.Background(), errors.New("boom")},
+		{"no reset time", true, context.Background(), rateErr(time.Time{})},
+		{"past reset, cancelled during backoff", true, cancelled, rateErr(time.Now().Add(-time.Minute))},
+		{"future reset, cancelled while waiting", true, cancelled, rateErr(time.Now().Add(time.Hour))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mc := &ManagedClient{waiter: &rateWaiter{enabled: tt.enabled}}
+			start := time.Now()
+			if mc.waitForReset(tt.ctx, tt.err) {
+				t.Error("waitForReset = true, want false")
+			}
+			if elapsed := time.Since(start); elapsed > time.Second {
+				t.Errorf("waitForReset took %s", elapsed)
+			}
+		})
+	}
+}
+
+func TestWaitForResetSharesWait(t *testing.T) {
+	// a reset another worker just waited out is retried at once
+	reset := time.Now().Add(-time.Second)
+	mc := &ManagedClient{waiter: &rateWaiter{enabled: true, waited: reset}}
+	err := &gh.RateLimitError{Rate: gh.Rate{Reset: gh.Timestamp{Time: reset}}}
+
+	start := time.Now()
+	if !mc.waitForReset(context.Background(), err) {
+		t.Fatal("waitForReset = false, want true")
+	}
+	if elapsed := time.Since(start); elapsed >= staleResetBackoff {
+		t.Errorf("waitForReset backed off for %s", elapsed)
+	}
+}
@@ -0,0 +1,51 @@
+package github
+
+import "strings"
+
+var repoURLPrefixes = []string{
+	"https://api.github.com/repos/",
+	"https://github.com/",
+	"http://github.com/",
+	"github.com/",
+}
+
+// RepoName cleans a repository name to the owner/name form, keeping its
+// case for display: URL forms and a .git suffix are stripped. Synthetic
+// names such as gist:ID, issues:owner/name and local:dir are returned
+// unchanged.
+func RepoName(name string) string {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return name
+	}
+
+	for _, prefix := range []string{"gist:", "issues:", "local:"} {
+		if strings.HasPrefix(name, prefix) {
+			return name
+		}
+	}
+
+	for _, prefix := range repoURLPrefixes {
+		if len(name) >= len(prefix) && strings.EqualFold(name[:len(prefix)], prefix) {
+			name = name[len(prefix):]
+			break
+		}
+	}
+	name = strings.Trim(name, "/")
+	return strings.TrimSuffix(name, ".git")
+}
+
+// RepoKey is the lowercase RepoName used as the key of EmailDetails.Commits,
+// so the repo crawl, events and search paths group the same repository
+// together whatever case each reports it in; GitHub names are
+// case-insensitive. Commits keep RepoName in CommitInfo.RepoName for
+// display. Synthetic keys are returned unchanged.
+func RepoKey(name string) string {
+	key := RepoName(name)
+	for _, prefix := range []string{"gist:", "issues:", "local:"} {
+		if strings.HasPrefix(key, prefix) {
+			return key
+		}
+	}
+	return strings.ToLower(key)
+}
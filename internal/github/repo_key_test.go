@@ -0,0 +1,58 @@
+package github
+
+import (
+	"testing"
+
+	"github.com/gnomegl/gitslurp/v2/internal/models"
+)
+
+func TestRepoKey(t *testing.T) {
+	tests := []struct {
+		in, name, key string
+	}{
+		{"Owner/Repo", "Owner/Repo", "owner/repo"},
+		{" https://github.com/Owner/Repo.git ", "Owner/Repo", "owner/repo"},
+		{"https://api.github.com/repos/Owner/Repo", "Owner/Repo", "owner/repo"},
+		{"GitHub.com/Owner/Repo/", "Owner/Repo", "owner/repo"},
+		{"gist:AbC123", "gist:AbC123", "gist:AbC123"},
+		{"issues:Owner/Repo", "issues:Owner/Repo", "issues:Owner/Repo"},
+		{"local:My-Clone", "local:My-Clone", "local:My-Clone"},
+		{"", "", ""},
+	}
+	for _, tt := range tests {
+		if got := RepoName(tt.in); got != tt.name {
+			t.Errorf("RepoName(%q) = %q, want %q", tt.in, got, tt.name)
+		}
+		if got := RepoKey(tt.in); got != tt.key {
+			t.Errorf("RepoKey(%q) = %q, want %q", tt.in, got, tt.key)
+		}
+	}
+}
+
+func TestAggregateCommitsGroupsRepoCase(t *testing.T) {
+	cfg := DefaultConfig()
+	emails := make(map[string]*models.EmailDetails)
+	commit := func(hash string) models.CommitInfo {
+		return models.CommitInfo{Hash: hash, AuthorName: "Dev", AuthorEmail: "dev@example.org"}
+	}
+
+	// the repo crawl reports repo.GetFullName(), the events path
+	// event.Repo.GetName(), and the two may differ in case
+	aggregateCommits(emails, []models.CommitInfo{commit("a1")}, "Owner/Repo", nil, false, &cfg)
+	aggregateCommits(emails, []models.CommitInfo{commit("b2")}, "owner/REPO", nil, false, &cfg)
+
+	details := emails["dev@example.org"]
+	if details == nil {
+		t.Fatal("no details filed for dev@example.org")
+	}
+	if len(details.Commits) != 1 {
+		t.Fatalf("commits filed under %d keys, want 1: %v", len(details.Commits), details.Commits)
+	}
+	commits, ok := details.Commits["owner/repo"]
+	if !ok || len(commits) != 2 {
+		t.Fatalf(`Commits["owner/repo"] = %v, want both commits`, commits)
+	}
+	if commits[0].RepoName != "Owner/Repo" {
+		t.Errorf("RepoName = %q, want the crawl's spelling Owner/Repo", commits[0].RepoName)
+	}
+}
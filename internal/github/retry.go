@@ -0,0 +1,51 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	gh "github.com/google/go-github/v57/github"
+)
+
+const retryBaseDelay = 500 * time.Millisecond
+
+// withRetry runs call up to 1+retries times, doubling the wait between
+// attempts. Only transient failures are retried: network errors, 5xx
+// responses and secondary rate limits. A 4xx such as 404 fails immediately.
+func withRetry(ctx context.Context, retries int, call func() (*gh.Response, error)) error {
+	delay := retryBaseDelay
+	var err error
+	for attempt := 0; ; attempt++ {
+		var resp *gh.Response
+		resp, err = call()
+		if err == nil {
+			return nil
+		}
+		if attempt >= retries || !isRetryable(resp, err) {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
+
+func isRetryable(resp *gh.Response, err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var abuseErr *gh.AbuseRateLimitError
+	if errors.As(err, &abuseErr) {
+		return true
+	}
+	if resp == nil {
+		return true
+	}
+	return resp.StatusCode >= http.StatusInternalServerError
+}
@@ -0,0 +1,93 @@
+package github
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"strings"
+
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/ProtonMail/go-crypto/openpgp/packet"
+	"github.com/gnomegl/gitslurp/v2/internal/models"
+	gh "github.com/google/go-github/v57/github"
+	"golang.org/x/crypto/ssh"
+)
+
+const sshSigArmorStart = "-----BEGIN SSH SIGNATURE-----"
+
+// commitVerification converts the API's verification block. It returns nil
+// when the API sent none, so exports can tell "unknown" from "unsigned".
+func commitVerification(v *gh.SignatureVerification) *models.Verification {
+	if v == nil || v.Verified == nil {
+		return nil
+	}
+	return &models.Verification{
+		Verified:    v.GetVerified(),
+		Reason:      v.GetReason(),
+		SignerKeyID: signerKeyID(v.GetSignature()),
+	}
+}
+
+// signerKeyID extracts the key that made a commit signature: the issuer key
+// ID (or fingerprint) for GPG, the SHA256 key fingerprint for SSH. It returns
+// "" for unsigned commits or signatures it cannot parse.
+func signerKeyID(signature string) string {
+	signature = strings.TrimSpace(signature)
+	switch {
+	case signature == "":
+		return ""
+	case strings.HasPrefix(signature, sshSigArmorStart):
+		return sshSignerFingerprint(signature)
+	default:
+		return pgpSignerKeyID(signature)
+	}
+}
+
+func pgpSignerKeyID(signature string) string {
+	block, err := armor.Decode(strings.NewReader(signature))
+	if err != nil {
+		return ""
+	}
+
+	reader := packet.NewReader(block.Body)
+	for {
+		p, err := reader.Next()
+		if err != nil {
+			return ""
+		}
+		sig, ok := p.(*packet.Signature)
+		if !ok {
+			continue
+		}
+		if sig.IssuerKeyId != nil {
+			return fmt.Sprintf("%016X", *sig.IssuerKeyId)
+		}
+		if len(sig.IssuerFingerprint) > 0 {
+			return fmt.Sprintf("%X", sig.IssuerFingerprint)
+		}
+		return ""
+	}
+}
+
+// sshSignerFingerprint reads the public key from an SSHSIG blob
+// ("SSHSIG", uint32 version, string publickey, ...).
+func sshSignerFingerprint(signature string) string {
+	body := strings.TrimPrefix(signature, sshSigArmorStart)
+	body = strings.TrimSuffix(strings.TrimSpace(body), "-----END SSH SIGNATURE-----")
+	blob, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(body), ""))
+	if err != nil || !bytes.HasPrefix(blob, []byte("SSHSIG")) || len(blob) < 14 {
+		return ""
+	}
+
+	rest := blob[10:] // magic + version
+	n := binary.BigEndian.Uint32(rest[:4])
+	if uint64(len(rest)-4) < uint64(n) {
+		return ""
+	}
+	pub, err := ssh.ParsePublicKey(rest[4 : 4+n])
+	if err != nil {
+		return ""
+	}
+	return ssh.FingerprintSHA256(pub)
+}
@@ -0,0 +1,71 @@
+package github
+
+import (
+	"path"
+	"strings"
+)
+
+// vendoredDirs are path segments whose contents are third-party or build
+// output and only produce noise when scanned.
+var vendoredDirs = []string{"vendor", "dist"}
+
+// vendoredSuffixes are generated files skipped wherever they live.
+var vendoredSuffixes = []string{".min.js", ".min.css", ".map"}
+
+// isVendoredFile reports whether filename is under a vendored directory or
+// is a minified bundle or source map.
+func isVendoredFile(filename string) bool {
+	lower := strings.ToLower(filename)
+	for _, suffix := range vendoredSuffixes {
+		if strings.HasSuffix(lower, suffix) {
+			return true
+		}
+	}
+	for _, segment := range strings.Split(path.Dir(lower), "/") {
+		for _, dir := range vendoredDirs {
+			if segment == dir {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// minMinifiedSize is the smallest content isMinified considers. Below it a
+// long line is more likely a secret, such as a JWT in a .env file or a
+// one-line PEM key, than a bundle.
+const minMinifiedSize = 5000
+
+// isMinified reports whether content is at least minMinifiedSize bytes and
+// its average line length exceeds maxAvg. Diff markers are ignored; maxAvg
+// <= 0 disables the check.
+func isMinified(content string, maxAvg int) bool {
+	if maxAvg <= 0 || len(content) < minMinifiedSize {
+		return false
+	}
+	total, lines := 0, 0
+	for _, line := range strings.Split(content, "\n") {
+		if strings.HasPrefix(line, "@@") {
+			continue
+		}
+		total += len(strings.TrimLeft(line, "+- "))
+		lines++
+	}
+	return lines > 0 && total/lines > maxAvg
+}
+
+// skipScanFile reports whether a file should be left out of secret scanning:
+// node_modules and package manager files always, vendored and minified files
+// unless cfg.ScanVendored is set.
+func skipScanFile(filename, content string, cfg *Config) bool {
+	if cfg.SkipNodeModules && (strings.Contains(filename, "/node_modules/") || strings.HasPrefix(filename, "node_modules/") || filename == "Cargo.lock") {
+		return true
+	}
+	if isPackageManagerFile(filename) {
+		return true
+	}
+	if cfg.ScanVendored {
+		return false
+	}
+	return isVendoredFile(filename) || isMinified(content, cfg.MinifiedLineLength)
+}
@@ -0,0 +1,40 @@
+package github
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSkipScanFile(t *testing.T) {
+	jwt := "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9." + strings.Repeat("eyJzdWIiOiIxMjM0NTY3ODkwIn0", 20) + ".SflKxwRJSMeKKF2QT4fwpMeJf36POk6yJV_adQssw5c"
+	bundle := "+" + strings.Repeat("function(a,b){return a+b};var x=1;", 400)
+
+	cfg := DefaultConfig()
+	tests := []struct {
+		name     string
+		filename string
+		content  string
+		want     bool
+	}{
+		{"minified bundle", "static/app.js", bundle, true},
+		{"min.js by name", "static/app.min.js", "+var a=1;", true},
+		{"source map", "static/app.js.map", "+{}", true},
+		{"vendored directory", "vendor/lib/x.go", "+package x", true},
+		{"node_modules", "web/node_modules/x/index.js", "+module.exports = 1", true},
+		{"env file with a long JWT", ".env", "+API_TOKEN=" + jwt + "\n+DEBUG=1", false},
+		{"one-line PEM blob", "deploy/key.b64", "+" + strings.Repeat("MIIEvQIBADANBgkqhkiG9w0BAQEFAASC", 40), false},
+		{"ordinary source", "main.go", "+package main\n+func main() {}", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := skipScanFile(tt.filename, tt.content, &cfg); got != tt.want {
+				t.Errorf("skipScanFile(%q) = %v, want %v", tt.filename, got, tt.want)
+			}
+		})
+	}
+
+	cfg.ScanVendored = true
+	if skipScanFile("static/app.js", bundle, &cfg) {
+		t.Error("skipScanFile with ScanVendored skipped a minified bundle")
+	}
+}
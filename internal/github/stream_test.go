@@ -0,0 +1,67 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gnomegl/gitslurp/v2/internal/status"
+	gh "github.com/google/go-github/v57/github"
+)
+
+func TestStreamedDetailsAreSnapshots(t *testing.T) {
+	status.Quiet = true
+	defer func() { status.Quiet = false }()
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name := strings.Split(r.URL.Path, "/")[3]
+		fmt.Fprintf(w, `[{"sha": "%[1]s-1", "commit": {"author": {"name": "Dev %[1]s", "email": "dev@example.com"}}},
+			{"sha": "%[1]s-2", "commit": {"author": {"name": "Dev", "email": "dev@example.com"}}}]`, name)
+	})
+	pool := testPool(t, handler)
+
+	var repos []*gh.Repository
+	for _, name := range []string{"a", "b", "c", "d", "e", "f"} {
+		repos = append(repos, &gh.Repository{Name: gh.String(name), FullName: gh.String("octo/" + name), Owner: &gh.User{Login: gh.String("octo")}})
+	}
+	cfg := DefaultConfig()
+	cfg.RepoConcurrency = 4
+
+	// a slow consumer keeps reading what it was sent while the crawl goes on
+	updates := make(chan EmailUpdate, 10)
+	done := make(chan struct{})
+	counts := make(chan [2]int, 10)
+	go func() {
+		for update := range updates {
+			sent := update.Details.CommitCount
+			for {
+				n := 0
+				for _, commits := range update.Details.Commits {
+					n += len(commits)
+				}
+				for range update.Details.Names {
+				}
+				select {
+				case <-done:
+					counts <- [2]int{sent, n}
+					return
+				default:
+				}
+			}
+		}
+	}()
+
+	emails := RateLimitedProcessRepos(context.Background(), pool, repos, false, &cfg, nil, false, updates)
+	close(done)
+	close(updates)
+
+	got := <-counts
+	if got[0] != 2 || got[1] != 2 {
+		t.Errorf("streamed details went from %d to %d commits during the crawl, want a snapshot of 2", got[0], got[1])
+	}
+	if n := emails["dev@example.com"].CommitCount; n != 12 {
+		t.Errorf("aggregated %d commits, want 12", n)
+	}
+}
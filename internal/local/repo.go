@@ -0,0 +1,181 @@
+// Package local collects commit history from a git clone on disk, producing
+// the same models the GitHub collectors do so display and export work
+// unchanged. No network requests are made.
+package local
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/gnomegl/gitslurp/v2/internal/github"
+	"github.com/gnomegl/gitslurp/v2/internal/models"
+	"github.com/gnomegl/gitslurp/v2/internal/status"
+	"github.com/gnomegl/gitslurp/v2/internal/utils"
+	git "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/format/diff"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// Collect walks every commit reachable from HEAD of the repository at path
+// and returns them grouped by author identity, along with the name the
+// commits are filed under.
+func Collect(ctx context.Context, path string, checkSecrets bool, cfg *github.Config) (map[string]*models.EmailDetails, string, error) {
+	repo, err := git.PlainOpenWithOptions(path, &git.PlainOpenOptions{DetectDotGit: true})
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to open git repository %s: %v", path, err)
+	}
+
+	head, err := repo.Head()
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to resolve HEAD in %s: %v", path, err)
+	}
+
+	iter, err := repo.Log(&git.LogOptions{From: head.Hash()})
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read history of %s: %v", path, err)
+	}
+	defer iter.Close()
+
+	repoName := repoNameFor(path)
+	scan := checkSecrets || cfg.ShowInteresting
+
+	var commits []models.CommitInfo
+	for {
+		if ctx.Err() != nil {
+			return nil, "", ctx.Err()
+		}
+
+		commit, err := iter.Next()
+		if err != nil {
+			break
+		}
+
+		info := commitInfo(commit, repoName, cfg)
+		if scan || cfg.FindLinks {
+			patches, err := commitPatches(commit)
+			if err != nil {
+				status.Yellow("[!]  Warning: could not diff commit %s: %v", commit.Hash.String()[:7], err)
+			}
+			if scan {
+				github.ScanCommitContent(&info, commit.Message, patches, checkSecrets, cfg)
+			}
+			if cfg.FindLinks {
+				info.Links = github.CommitLinks(commit.Message, patches)
+			}
+		}
+		commits = append(commits, info)
+	}
+
+	emails := make(map[string]*models.EmailDetails)
+	github.AggregateCommits(emails, commits, repoName, cfg)
+	return emails, repoName, nil
+}
+
+func repoNameFor(path string) string {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		abs = path
+	}
+	return "local:" + filepath.Base(abs)
+}
+
+func commitInfo(commit *object.Commit, repoName string, cfg *github.Config) models.CommitInfo {
+	info := models.CommitInfo{
+		Hash:           commit.Hash.String(),
+		AuthorName:     commit.Author.Name,
+		AuthorEmail:    commit.Author.Email,
+		AuthorDate:     commit.Author.When,
+		CommitterName:  commit.Committer.Name,
+		CommitterEmail: commit.Committer.Email,
+		CommitterDate:  commit.Committer.When,
+		Message:        commit.Message,
+		CoAuthors:      github.ParseCoAuthors(commit.Message),
+		IsOwnRepo:      true,
+		IsMerge:        commit.NumParents() > 1,
+		RepoName:       repoName,
+	}
+
+	if cfg.TimestampAnalysis {
+		info.TimestampAnalysis = utils.AnalyzeTimestamp(info.AuthorDate)
+	}
+
+	if info.AuthorName == "" && info.AuthorEmail == "" {
+		info.AuthorName = "Anonymous"
+	}
+	if info.CommitterName == "" && info.CommitterEmail == "" {
+		info.CommitterName = "Anonymous"
+	}
+
+	return info
+}
+
+// commitPatches diffs a commit against its first parent (or the empty tree for
+// a root commit) and renders each text file's changed lines in unified-diff
+// style, matching the per-file patches the API returns.
+func commitPatches(commit *object.Commit) ([]models.FilePatch, error) {
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, err
+	}
+
+	var parentTree *object.Tree
+	if commit.NumParents() > 0 {
+		parent, err := commit.Parent(0)
+		if err != nil {
+			return nil, err
+		}
+		if parentTree, err = parent.Tree(); err != nil {
+			return nil, err
+		}
+	}
+
+	changes, err := object.DiffTree(parentTree, tree)
+	if err != nil {
+		return nil, err
+	}
+	patch, err := changes.Patch()
+	if err != nil {
+		return nil, err
+	}
+
+	var patches []models.FilePatch
+	for _, fp := range patch.FilePatches() {
+		if fp.IsBinary() {
+			continue
+		}
+		from, to := fp.Files()
+		filename := ""
+		if to != nil {
+			filename = to.Path()
+		} else if from != nil {
+			filename = from.Path()
+		}
+
+		var sb strings.Builder
+		for _, chunk := range fp.Chunks() {
+			var prefix string
+			switch chunk.Type() {
+			case diff.Add:
+				prefix = "+"
+			case diff.Delete:
+				prefix = "-"
+			default:
+				continue
+			}
+			for _, line := range strings.SplitAfter(chunk.Content(), "\n") {
+				if line == "" {
+					continue
+				}
+				sb.WriteString(prefix)
+				sb.WriteString(line)
+			}
+		}
+
+		if sb.Len() > 0 {
+			patches = append(patches, models.FilePatch{Filename: filename, Patch: sb.String()})
+		}
+	}
+	return patches, nil
+}
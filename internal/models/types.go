@@ -1,6 +1,10 @@
 package models
 
-import "time"
+import (
+	"fmt"
+	"strings"
+	"time"
+)
 
 type CommitInfo struct {
 	Hash              string
@@ -12,16 +16,73 @@ type CommitInfo struct {
 	CommitterName     string
 	CommitterEmail    string
 	CommitterDate     time.Time
+	CoAuthors         []CoAuthor // from Co-authored-by trailers in the message
 	Message           string
-	Secrets           []string
+	Secrets           []SecretFinding
+	Patches           []FilePatch
 	Links             []string
 	IsOwnRepo         bool
 	IsFork            bool
 	IsExternal        bool
+	IsOrgRepo         bool   // in a repo owned by an org the target is a member of
+	IsMerge           bool   // more than one parent; dropped with --exclude-merges
+	RepoVisibility    string // public, private or internal; empty when unknown
 	RepoName          string
+	Verification      *Verification // nil when the API returned no verification data
 	TimestampAnalysis *TimestampAnalysis
 }
 
+// CoAuthor is a person credited in a commit's Co-authored-by trailer.
+type CoAuthor struct {
+	Name  string
+	Email string
+}
+
+// Verification is GitHub's signature check for a commit.
+type Verification struct {
+	Verified    bool
+	Reason      string // GitHub's reason code, e.g. "valid", "unsigned", "unknown_key"
+	SignerKeyID string // GPG key ID or SSH key fingerprint; empty when unsigned
+}
+
+// SecretFinding is a secret or interesting string matched in a commit.
+type SecretFinding struct {
+	Name        string // pattern name
+	Value       string
+	Location    string // "commit message" or a file path; empty when unknown
+	LineNumber  int    // line of the match, in the new file for patches; 0 when unknown
+	Context     string // lines surrounding the match
+	Interesting bool
+	Verdict     string // VALID, INVALID or UNKNOWN with --verify-secrets
+}
+
+// String formats the finding as one line:
+// "[INTERESTING: ]Name: value[ (in location[:line])][ [VERDICT]]".
+func (f SecretFinding) String() string {
+	s := fmt.Sprintf("%s: %s", f.Name, f.Value)
+	if f.Interesting {
+		s = "INTERESTING: " + s
+	}
+	if f.Location != "" {
+		if f.LineNumber > 0 {
+			s += fmt.Sprintf(" (in %s:%d)", f.Location, f.LineNumber)
+		} else {
+			s += fmt.Sprintf(" (in %s)", f.Location)
+		}
+	}
+	if f.Verdict != "" {
+		s += " [" + f.Verdict + "]"
+	}
+	return s
+}
+
+// FilePatch is the diff of a single file that produced a finding. It is only
+// kept for flagged files so memory stays bounded on large histories.
+type FilePatch struct {
+	Filename string
+	Patch    string
+}
+
 type TimestampAnalysis struct {
 	IsUnusualHour  bool
 	IsWeekend      bool
@@ -42,4 +103,131 @@ type EmailDetails struct {
 	CommitCount    int
 	IsUserEmail    bool
 	GithubUsername string
+
+	// hashes holds the commit hashes Merge has seen in Commits, and indexed
+	// how many commits of each repository they cover, so commits appended
+	// to Commits directly are picked up by the next Merge.
+	hashes  map[string]bool
+	indexed map[string]int
+}
+
+// FirstCommit returns the earliest AuthorDate across the email's commits, or
+// the zero time when none carry a date.
+func (d *EmailDetails) FirstCommit() time.Time {
+	var first time.Time
+	for _, commits := range d.Commits {
+		for _, commit := range commits {
+			if !commit.AuthorDate.IsZero() && (first.IsZero() || commit.AuthorDate.Before(first)) {
+				first = commit.AuthorDate
+			}
+		}
+	}
+	return first
+}
+
+// LastCommit returns the most recent AuthorDate across the email's commits,
+// or the zero time when none carry a date.
+func (d *EmailDetails) LastCommit() time.Time {
+	var last time.Time
+	for _, commits := range d.Commits {
+		for _, commit := range commits {
+			if commit.AuthorDate.After(last) {
+				last = commit.AuthorDate
+			}
+		}
+	}
+	return last
+}
+
+// CommitterCommits counts the commits filed under email that someone else
+// authored, i.e. where email only appears as the committer.
+func (d *EmailDetails) CommitterCommits(email string) int {
+	n := 0
+	for _, commits := range d.Commits {
+		for _, commit := range commits {
+			if strings.EqualFold(commit.CommitterEmail, email) && !strings.EqualFold(commit.AuthorEmail, email) {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+// CoAuthorCommits counts the commits filed under email that credit it as a
+// co-author rather than the author.
+func (d *EmailDetails) CoAuthorCommits(email string) int {
+	n := 0
+	for _, commits := range d.Commits {
+		for _, commit := range commits {
+			if strings.EqualFold(commit.AuthorEmail, email) {
+				continue
+			}
+			for _, co := range commit.CoAuthors {
+				if strings.EqualFold(co.Email, email) {
+					n++
+					break
+				}
+			}
+		}
+	}
+	return n
+}
+
+// Merge adds other's names and commits to d. A commit whose hash d already
+// has, under any repository, is skipped, so CommitCount stays a count of
+// unique commits when the same commit was found by more than one source.
+func (d *EmailDetails) Merge(other *EmailDetails) {
+	for name := range other.Names {
+		d.Names[name] = struct{}{}
+	}
+
+	d.indexHashes()
+	for repoName, commits := range other.Commits {
+		for _, commit := range commits {
+			if commit.Hash != "" {
+				if d.hashes[commit.Hash] {
+					continue
+				}
+				d.hashes[commit.Hash] = true
+			}
+			d.Commits[repoName] = append(d.Commits[repoName], commit)
+			d.CommitCount++
+		}
+		d.indexed[repoName] = len(d.Commits[repoName])
+	}
+}
+
+// Snapshot returns a copy of d's names and commits that later aggregation
+// and merges do not touch, for handing to a reader on another goroutine.
+func (d *EmailDetails) Snapshot() *EmailDetails {
+	c := &EmailDetails{
+		Names:          make(map[string]struct{}, len(d.Names)),
+		Commits:        make(map[string][]CommitInfo, len(d.Commits)),
+		CommitCount:    d.CommitCount,
+		IsUserEmail:    d.IsUserEmail,
+		GithubUsername: d.GithubUsername,
+	}
+	for name := range d.Names {
+		c.Names[name] = struct{}{}
+	}
+	for repoName, commits := range d.Commits {
+		c.Commits[repoName] = append([]CommitInfo(nil), commits...)
+	}
+	return c
+}
+
+// indexHashes adds the hashes of commits appended since the last Merge.
+func (d *EmailDetails) indexHashes() {
+	if d.hashes == nil {
+		d.hashes = make(map[string]bool)
+		d.indexed = make(map[string]int)
+	}
+	for repoName, commits := range d.Commits {
+		for _, commit := range commits[min(d.indexed[repoName], len(commits)):] {
+			if commit.Hash != "" {
+				d.hashes[commit.Hash] = true
+			}
+		}
+		d.indexed[repoName] = len(commits)
+	}
 }
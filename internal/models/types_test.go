@@ -0,0 +1,116 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func details(repo string, hashes ...string) *EmailDetails {
+	d := &EmailDetails{
+		Names:   map[string]struct{}{"Dev": {}},
+		Commits: make(map[string][]CommitInfo),
+	}
+	for _, h := range hashes {
+		d.Commits[repo] = append(d.Commits[repo], CommitInfo{Hash: h, RepoName: repo})
+		d.CommitCount++
+	}
+	return d
+}
+
+func TestMergeOverlappingCommits(t *testing.T) {
+	tests := []struct {
+		name   string
+		merges []*EmailDetails
+		want   int
+	}{
+		{"disjoint", []*EmailDetails{details("owner/b", "c", "d")}, 4},
+		{"same commits from another source", []*EmailDetails{details("owner/a", "a", "b")}, 2},
+		// the search path can file a crawled commit under another repo key
+		{"same hash under another repository", []*EmailDetails{details("owner/b", "b", "c")}, 3},
+		{"repeated merges", []*EmailDetails{details("owner/b", "c"), details("owner/b", "c", "d"), details("owner/a", "a", "d")}, 4},
+		{"anonymous hashes are all kept", []*EmailDetails{details("owner/b", "", "")}, 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := details("owner/a", "a", "b")
+			for _, other := range tt.merges {
+				d.Merge(other)
+			}
+
+			if d.CommitCount != tt.want {
+				t.Errorf("CommitCount = %d, want %d", d.CommitCount, tt.want)
+			}
+			total := 0
+			for _, commits := range d.Commits {
+				total += len(commits)
+			}
+			if total != d.CommitCount {
+				t.Errorf("%d commits filed, CommitCount %d", total, d.CommitCount)
+			}
+		})
+	}
+}
+
+func TestMergeSeesDirectAppends(t *testing.T) {
+	d := details("owner/a", "a")
+	d.Merge(details("owner/b", "b"))
+
+	// aggregation appends to Commits without going through Merge
+	d.Commits["owner/a"] = append(d.Commits["owner/a"], CommitInfo{Hash: "c"})
+	d.CommitCount++
+
+	d.Merge(details("owner/c", "a", "b", "c", "d"))
+	if d.CommitCount != 4 {
+		t.Errorf("CommitCount = %d, want 4", d.CommitCount)
+	}
+}
+
+func TestFirstAndLastCommit(t *testing.T) {
+	day := func(y, m, d int) time.Time { return time.Date(y, time.Month(m), d, 0, 0, 0, 0, time.UTC) }
+	tests := []struct {
+		name        string
+		commits     map[string][]CommitInfo
+		first, last time.Time
+	}{
+		{"no commits", nil, time.Time{}, time.Time{}},
+		{"undated commits", map[string][]CommitInfo{"a": {{Hash: "1"}}}, time.Time{}, time.Time{}},
+		{
+			name: "across repositories",
+			commits: map[string][]CommitInfo{
+				"owner/a": {{AuthorDate: day(2021, 5, 1)}, {AuthorDate: day(2019, 2, 3)}},
+				"owner/b": {{AuthorDate: day(2023, 11, 30)}, {}},
+				"gist:1":  {{AuthorDate: day(2020, 1, 1)}},
+			},
+			first: day(2019, 2, 3),
+			last:  day(2023, 11, 30),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &EmailDetails{Commits: tt.commits}
+			if got := d.FirstCommit(); !got.Equal(tt.first) {
+				t.Errorf("FirstCommit = %v, want %v", got, tt.first)
+			}
+			if got := d.LastCommit(); !got.Equal(tt.last) {
+				t.Errorf("LastCommit = %v, want %v", got, tt.last)
+			}
+		})
+	}
+}
+
+func TestSnapshot(t *testing.T) {
+	d := details("owner/a", "a", "b")
+	snap := d.Snapshot()
+
+	d.Names["Other"] = struct{}{}
+	d.Commits["owner/a"] = append(d.Commits["owner/a"], CommitInfo{Hash: "c"})
+	d.Commits["owner/a"][0].Hash = "changed"
+	d.Merge(details("owner/b", "d"))
+
+	if snap.CommitCount != 2 || len(snap.Names) != 1 || len(snap.Commits) != 1 {
+		t.Errorf("snapshot changed with the original: %+v", snap)
+	}
+	if got := snap.Commits["owner/a"]; len(got) != 2 || got[0].Hash != "a" {
+		t.Errorf("snapshot commits = %+v, want a and b", got)
+	}
+}
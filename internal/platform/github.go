@@ -175,13 +175,7 @@ func (g *GitHubProvider) ListCommits(ctx context.Context, owner, repo string, cf
 			if cfg.CheckSecrets || cfg.ShowInteresting {
 				secretScanner := scanner.NewScanner(cfg.ShowInteresting)
 				if info.Message != "" {
-					for _, match := range secretScanner.ScanText(info.Message) {
-						if match.Type == "Secret" && cfg.CheckSecrets {
-							info.Secrets = append(info.Secrets, fmt.Sprintf("%s: %s (in commit message)", match.Name, match.Value))
-						} else if match.Type == "Interesting" && cfg.ShowInteresting {
-							info.Secrets = append(info.Secrets, fmt.Sprintf("INTERESTING: %s: %s (in commit message)", match.Name, match.Value))
-						}
-					}
+					info.Secrets = append(info.Secrets, scanFindings(secretScanner, info.Message, "commit message", info.Hash, cfg)...)
 				}
 
 				files, _, err := g.GetCommitDetail(ctx, owner, repo, c.GetSHA())
@@ -191,13 +185,7 @@ func (g *GitHubProvider) ListCommits(ctx context.Context, owner, repo string, cf
 							continue
 						}
 						if file.Patch != "" {
-							for _, match := range secretScanner.ScanText(file.Patch) {
-								if match.Type == "Secret" && cfg.CheckSecrets {
-									info.Secrets = append(info.Secrets, fmt.Sprintf("%s: %s (in %s)", match.Name, match.Value, file.Filename))
-								} else if match.Type == "Interesting" && cfg.ShowInteresting {
-									info.Secrets = append(info.Secrets, fmt.Sprintf("INTERESTING: %s: %s (in %s)", match.Name, match.Value, file.Filename))
-								}
-							}
+							info.Secrets = append(info.Secrets, scanFindings(secretScanner, file.Patch, file.Filename, info.Hash, cfg)...)
 						}
 					}
 				}
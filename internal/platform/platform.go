@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/gnomegl/gitslurp/v2/internal/models"
+	"github.com/gnomegl/gitslurp/v2/internal/scanner"
 )
 
 type Platform string
@@ -81,3 +82,21 @@ type CommitFile struct {
 	Filename string
 	Patch    string
 }
+
+// scanFindings returns the matches in text the scan config asks for, with
+// secrets verified and located. Matches in the --baseline are left out.
+func scanFindings(secretScanner *scanner.Scanner, text, location, hash string, cfg ScanConfig) []models.SecretFinding {
+	var findings []models.SecretFinding
+	for _, match := range secretScanner.ScanText(text) {
+		if scanner.Suppressed(hash, match) {
+			continue
+		}
+		if match.Type == "Secret" && cfg.CheckSecrets {
+			match.Verdict = scanner.Verify(match, text)
+			findings = append(findings, match.Located(location, text))
+		} else if match.Type == "Interesting" && cfg.ShowInteresting {
+			findings = append(findings, match.Located(location, text))
+		}
+	}
+	return findings
+}
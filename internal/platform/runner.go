@@ -3,13 +3,13 @@ package platform
 import (
 	"context"
 	"fmt"
-	"os"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/fatih/color"
 	"github.com/gnomegl/gitslurp/v2/internal/models"
+	"github.com/gnomegl/gitslurp/v2/internal/status"
 	"github.com/schollz/progressbar/v3"
 )
 
@@ -91,10 +91,13 @@ func (r *Runner) FetchAndProcessRepos(ctx context.Context, username string, isOr
 	var err error
 
 	fmt.Println()
-	color.Blue("Enumerating %s repositories...", r.provider.Name())
+	status.Blue("Enumerating %s repositories...", r.provider.Name())
 
 	if isOrg {
 		repos, err = r.provider.ListOrgRepos(ctx, username)
+		if !r.config.IncludeForks {
+			repos = withoutForks(repos)
+		}
 	} else {
 		repos, err = r.provider.ListUserRepos(ctx, username, r.config.IncludeForks)
 	}
@@ -106,7 +109,7 @@ func (r *Runner) FetchAndProcessRepos(ctx context.Context, username string, isOr
 		return nil, fmt.Errorf("no repositories found for %s on %s", username, r.provider.Name())
 	}
 
-	color.Green("[+] Found %d repositories", len(repos))
+	status.Green("[+] Found %d repositories", len(repos))
 	fmt.Println()
 
 	emails := make(map[string]*models.EmailDetails)
@@ -125,7 +128,7 @@ func (r *Runner) FetchAndProcessRepos(ctx context.Context, username string, isOr
 		progressbar.OptionShowCount(),
 		progressbar.OptionSetWidth(10),
 		progressbar.OptionSetDescription("[cyan]Processing repositories[reset]"),
-		progressbar.OptionSetWriter(os.Stderr),
+		progressbar.OptionSetWriter(status.Writer()),
 		progressbar.OptionSetTheme(progressbar.Theme{
 			Saucer:        "[green]#[reset]",
 			SaucerHead:    "[green]>[reset]",
@@ -178,13 +181,7 @@ func (r *Runner) FetchAndProcessRepos(ctx context.Context, username string, isOr
 	if err == nil && len(extEmails) > 0 {
 		for email, details := range extEmails {
 			if existing, ok := emails[email]; ok {
-				for name := range details.Names {
-					existing.Names[name] = struct{}{}
-				}
-				for repoName, commits := range details.Commits {
-					existing.Commits[repoName] = append(existing.Commits[repoName], commits...)
-				}
-				existing.CommitCount += details.CommitCount
+				existing.Merge(details)
 			} else {
 				emails[email] = details
 			}
@@ -193,3 +190,13 @@ func (r *Runner) FetchAndProcessRepos(ctx context.Context, username string, isOr
 
 	return emails, nil
 }
+
+func withoutForks(repos []*Repository) []*Repository {
+	kept := repos[:0]
+	for _, repo := range repos {
+		if !repo.Fork {
+			kept = append(kept, repo)
+		}
+	}
+	return kept
+}
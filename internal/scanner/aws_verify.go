@@ -0,0 +1,132 @@
+package scanner
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	stsEndpoint = "https://sts.amazonaws.com/"
+	stsHost     = "sts.amazonaws.com"
+	stsRegion   = "us-east-1"
+	stsBody     = "Action=GetCallerIdentity&Version=2011-06-15"
+
+	// awsPairWindow is how far around an access key ID to look for its secret.
+	awsPairWindow = 500
+)
+
+var (
+	awsKeyedSecret = regexp.MustCompile(`(?i)secret[\w\-]*["']?\s*[:=]\s*["']?([A-Za-z0-9/+]{40})\b`)
+	awsBareSecret  = regexp.MustCompile(`(?:^|[^A-Za-z0-9/+])([A-Za-z0-9/+]{40})(?:[^A-Za-z0-9/+=]|$)`)
+)
+
+// awsVerifier calls sts:GetCallerIdentity, which any valid key pair may call
+// regardless of its policies. An access key ID alone cannot be checked, so
+// keys without a secret nearby are UNKNOWN.
+type awsVerifier struct{}
+
+func (awsVerifier) Verify(ctx context.Context, m Match, text string) Verdict {
+	secret := findAWSSecret(m.Value, text)
+	if secret == "" {
+		return VerdictUnknown
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, stsEndpoint, strings.NewReader(stsBody))
+	if err != nil {
+		return VerdictUnknown
+	}
+	signSTSRequest(req, m.Value, secret, time.Now().UTC())
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return VerdictUnknown
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+
+	switch {
+	case resp.StatusCode == http.StatusOK:
+		return VerdictValid
+	case resp.StatusCode == http.StatusForbidden &&
+		(strings.Contains(string(body), "InvalidClientTokenId") || strings.Contains(string(body), "SignatureDoesNotMatch")):
+		return VerdictInvalid
+	default:
+		return VerdictUnknown
+	}
+}
+
+// findAWSSecret looks for a 40-character secret access key near keyID,
+// preferring one assigned to a "secret" variable.
+func findAWSSecret(keyID, text string) string {
+	i := strings.Index(text, keyID)
+	if i < 0 {
+		return ""
+	}
+	start, end := i-awsPairWindow, i+len(keyID)+awsPairWindow
+	if start < 0 {
+		start = 0
+	}
+	if end > len(text) {
+		end = len(text)
+	}
+	window := text[start:end]
+
+	if m := awsKeyedSecret.FindStringSubmatch(window); m != nil {
+		return m[1]
+	}
+	if m := awsBareSecret.FindStringSubmatch(window); m != nil {
+		return m[1]
+	}
+	return ""
+}
+
+// signSTSRequest adds an AWS Signature Version 4 Authorization header.
+func signSTSRequest(req *http.Request, keyID, secret string, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	contentType := "application/x-www-form-urlencoded; charset=utf-8"
+
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("X-Amz-Date", amzDate)
+
+	payloadHash := sha256.Sum256([]byte(stsBody))
+	signedHeaders := "content-type;host;x-amz-date"
+	canonicalRequest := strings.Join([]string{
+		http.MethodPost,
+		"/",
+		"",
+		"content-type:" + contentType,
+		"host:" + stsHost,
+		"x-amz-date:" + amzDate,
+		"",
+		signedHeaders,
+		hex.EncodeToString(payloadHash[:]),
+	}, "\n")
+
+	scope := fmt.Sprintf("%s/%s/sts/aws4_request", date, stsRegion)
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{"AWS4-HMAC-SHA256", amzDate, scope, hex.EncodeToString(requestHash[:])}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+secret), date)
+	key = hmacSHA256(key, stsRegion)
+	key = hmacSHA256(key, "sts")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		keyID, scope, signedHeaders, signature))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,101 @@
+package scanner
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+)
+
+// BaselineEntry is a reviewed finding to suppress on later scans. Only the
+// fingerprint is matched; Name and Location are kept for the reviewer.
+type BaselineEntry struct {
+	Commit      string `json:"commit"`
+	Fingerprint string `json:"fingerprint"`
+	Name        string `json:"name,omitempty"`
+	Location    string `json:"location,omitempty"`
+}
+
+// Baseline is the --baseline file format.
+type Baseline struct {
+	Findings []BaselineEntry `json:"findings"`
+}
+
+var (
+	baselineMu  sync.RWMutex
+	baselineSet map[string]bool
+)
+
+// Fingerprint identifies a finding without storing its value: the hex
+// sha256 of the pattern name followed by the matched value.
+func Fingerprint(name, value string) string {
+	sum := sha256.Sum256([]byte(name + value))
+	return hex.EncodeToString(sum[:])
+}
+
+// LoadBaseline reads a baseline file written by --write-baseline.
+func LoadBaseline(path string) (*Baseline, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read baseline file: %v", err)
+	}
+
+	var b Baseline
+	if err := json.Unmarshal(data, &b); err != nil {
+		return nil, fmt.Errorf("failed to parse baseline file %s: %v", path, err)
+	}
+	for i, e := range b.Findings {
+		if e.Commit == "" || e.Fingerprint == "" {
+			return nil, fmt.Errorf("baseline entry %d: commit and fingerprint are required", i+1)
+		}
+	}
+	return &b, nil
+}
+
+// WriteBaseline saves b to path, sorted by commit and fingerprint so that
+// rewriting an unchanged baseline gives an identical file.
+func WriteBaseline(path string, b *Baseline) error {
+	sort.Slice(b.Findings, func(i, j int) bool {
+		if b.Findings[i].Commit != b.Findings[j].Commit {
+			return b.Findings[i].Commit < b.Findings[j].Commit
+		}
+		return b.Findings[i].Fingerprint < b.Findings[j].Fingerprint
+	})
+	if b.Findings == nil {
+		b.Findings = []BaselineEntry{}
+	}
+
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode baseline: %v", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write file %s: %v", path, err)
+	}
+	return nil
+}
+
+// UseBaseline suppresses every finding in b from later scans.
+func UseBaseline(b *Baseline) {
+	set := make(map[string]bool, len(b.Findings))
+	for _, e := range b.Findings {
+		set[e.Commit+"\x00"+e.Fingerprint] = true
+	}
+
+	baselineMu.Lock()
+	defer baselineMu.Unlock()
+	baselineSet = set
+}
+
+// Suppressed reports whether the match found in commit is in the baseline.
+func Suppressed(commit string, m Match) bool {
+	baselineMu.RLock()
+	defer baselineMu.RUnlock()
+	if len(baselineSet) == 0 {
+		return false
+	}
+	return baselineSet[commit+"\x00"+Fingerprint(m.Name, m.Value)]
+}
@@ -0,0 +1,69 @@
+package scanner
+
+import (
+	"strconv"
+	"strings"
+)
+
+// contextLines is how many lines before and after a match are kept.
+const contextLines = 2
+
+// lineContext returns the line number of offset in text and the lines around
+// it. For unified diffs the number is counted from the enclosing hunk header,
+// in the new file, or in the old file for removed lines.
+func lineContext(text string, offset int) (int, string) {
+	if offset < 0 || offset > len(text) {
+		return 0, ""
+	}
+	lines := strings.Split(text, "\n")
+	idx := strings.Count(text[:offset], "\n")
+
+	line := idx + 1
+	for i := idx - 1; i >= 0; i-- {
+		if !strings.HasPrefix(lines[i], "@@") {
+			continue
+		}
+		oldStart, newStart, ok := parseHunkHeader(lines[i])
+		if !ok {
+			break
+		}
+		removed := strings.HasPrefix(lines[idx], "-")
+		line = newStart
+		if removed {
+			line = oldStart
+		}
+		for _, l := range lines[i+1 : idx] {
+			if removed && !strings.HasPrefix(l, "+") || !removed && !strings.HasPrefix(l, "-") {
+				line++
+			}
+		}
+		break
+	}
+
+	from, to := idx-contextLines, idx+contextLines+1
+	if from < 0 {
+		from = 0
+	}
+	if to > len(lines) {
+		to = len(lines)
+	}
+	return line, strings.Join(lines[from:to], "\n")
+}
+
+// parseHunkHeader reads the start lines of "@@ -a,b +c,d @@".
+func parseHunkHeader(header string) (oldStart, newStart int, ok bool) {
+	fields := strings.Fields(header)
+	if len(fields) < 3 || !strings.HasPrefix(fields[1], "-") || !strings.HasPrefix(fields[2], "+") {
+		return 0, 0, false
+	}
+	start := func(r string) (int, error) {
+		n, _, _ := strings.Cut(r[1:], ",")
+		return strconv.Atoi(n)
+	}
+	o, err1 := start(fields[1])
+	n, err2 := start(fields[2])
+	if err1 != nil || err2 != nil {
+		return 0, 0, false
+	}
+	return o, n, true
+}
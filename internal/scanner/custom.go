@@ -0,0 +1,108 @@
+package scanner
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// CustomPattern is a user-supplied pattern loaded with --patterns. Type is
+// "secret" (the default) or "interesting"; interesting patterns only report
+// with --interesting.
+type CustomPattern struct {
+	Name  string `json:"name" yaml:"name"`
+	Regex string `json:"regex" yaml:"regex"`
+	Type  string `json:"type" yaml:"type"`
+
+	re *regexp.Regexp
+}
+
+var (
+	customMu       sync.RWMutex
+	customPatterns []CustomPattern
+)
+
+// LoadPatterns reads custom patterns from a YAML or JSON file, either as a
+// top-level list or under a "patterns" key. Every entry needs a unique name
+// that does not shadow a built-in pattern and a regex that compiles.
+func LoadPatterns(path string) ([]CustomPattern, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read patterns file: %v", err)
+	}
+
+	var patterns []CustomPattern
+	var wrapped struct {
+		Patterns []CustomPattern `json:"patterns" yaml:"patterns"`
+	}
+	unmarshal := yaml.Unmarshal
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		unmarshal = json.Unmarshal
+	}
+	if err := unmarshal(data, &patterns); err != nil {
+		if werr := unmarshal(data, &wrapped); werr != nil {
+			return nil, fmt.Errorf("failed to parse patterns file %s: %v", path, err)
+		}
+		patterns = wrapped.Patterns
+	}
+	if len(patterns) == 0 {
+		return nil, fmt.Errorf("patterns file %s contains no patterns", path)
+	}
+
+	seen := make(map[string]bool)
+	for i := range patterns {
+		p := &patterns[i]
+		p.Name = strings.TrimSpace(p.Name)
+		p.Type = strings.ToLower(strings.TrimSpace(p.Type))
+
+		if p.Name == "" {
+			return nil, fmt.Errorf("pattern %d: missing name", i+1)
+		}
+		if _, builtin := SecretPatterns[p.Name]; builtin {
+			return nil, fmt.Errorf("pattern %q: name conflicts with a built-in pattern", p.Name)
+		}
+		if seen[p.Name] {
+			return nil, fmt.Errorf("pattern %q: duplicate name", p.Name)
+		}
+		seen[p.Name] = true
+
+		switch p.Type {
+		case "":
+			p.Type = "secret"
+		case "secret", "interesting":
+		default:
+			return nil, fmt.Errorf("pattern %q: unknown type %q (valid: secret, interesting)", p.Name, p.Type)
+		}
+
+		if p.Regex == "" {
+			return nil, fmt.Errorf("pattern %q: missing regex", p.Name)
+		}
+		re, err := regexp.Compile(p.Regex)
+		if err != nil {
+			return nil, fmt.Errorf("pattern %q: invalid regex: %v", p.Name, err)
+		}
+		p.re = re
+	}
+
+	return patterns, nil
+}
+
+// UseCustomPatterns makes every scanner created afterwards match patterns in
+// addition to the built-in ones.
+func UseCustomPatterns(patterns []CustomPattern) {
+	customMu.Lock()
+	defer customMu.Unlock()
+	customPatterns = patterns
+}
+
+func loadedCustomPatterns() []CustomPattern {
+	customMu.RLock()
+	defer customMu.RUnlock()
+	return customPatterns
+}
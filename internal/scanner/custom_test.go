@@ -0,0 +1,94 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadPatterns(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		err     string // substring of the expected error; empty for success
+		count   int
+	}{
+		{name: "yaml list", file: "p.yaml", content: "- name: Acme Key\n  regex: 'acme_[a-z0-9]{16}'\n- name: Acme Host\n  regex: 'internal\\.acme\\.corp'\n  type: interesting\n", count: 2},
+		{name: "yaml under patterns", file: "p.yml", content: "patterns:\n  - name: Acme Key\n    regex: 'acme_[a-z0-9]{16}'\n", count: 1},
+		{name: "json", file: "p.json", content: `[{"name": "Acme Key", "regex": "acme_[a-z0-9]{16}", "type": "secret"}]`, count: 1},
+		{name: "invalid regex", file: "p.yaml", content: "- name: Broken\n  regex: 'acme_[a-z'\n", err: "invalid regex"},
+		{name: "missing name", file: "p.yaml", content: "- regex: 'x'\n", err: "missing name"},
+		{name: "missing regex", file: "p.yaml", content: "- name: Empty\n", err: "missing regex"},
+		{name: "built-in name", file: "p.yaml", content: "- name: AWS Access Key\n  regex: 'x'\n", err: "built-in"},
+		{name: "duplicate name", file: "p.yaml", content: "- name: A\n  regex: 'x'\n- name: A\n  regex: 'y'\n", err: "duplicate"},
+		{name: "unknown type", file: "p.yaml", content: "- name: A\n  regex: 'x'\n  type: loud\n", err: "unknown type"},
+		{name: "malformed", file: "p.json", content: `{"patterns": [`, err: "failed to parse"},
+		{name: "empty", file: "p.yaml", content: "[]\n", err: "no patterns"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), tt.file)
+			if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+			patterns, err := LoadPatterns(path)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("LoadPatterns error = %v, want one containing %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(patterns) != tt.count {
+				t.Errorf("%d patterns loaded, want %d", len(patterns), tt.count)
+			}
+		})
+	}
+
+	if _, err := LoadPatterns(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Error("LoadPatterns of a missing file succeeded")
+	}
+}
+
+func TestCustomPatternsInScanText(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "patterns.yaml")
+	content := "- name: Acme Key\n  regex: 'acme_[a-z0-9]{16}'\n- name: Acme Host\n  regex: 'internal\\.acme\\.corp'\n  type: interesting\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	patterns, err := LoadPatterns(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	UseCustomPatterns(patterns)
+	defer UseCustomPatterns(nil)
+
+	text := "key = acme_0123456789abcdef\nhost = internal.acme.corp"
+	tests := []struct {
+		showInteresting bool
+		want            map[string]string // pattern name to match type
+	}{
+		{false, map[string]string{"Acme Key": "Secret"}},
+		{true, map[string]string{"Acme Key": "Secret", "Acme Host": "Interesting"}},
+	}
+	for _, tt := range tests {
+		got := make(map[string]string)
+		for _, m := range NewScanner(tt.showInteresting).ScanText(text) {
+			if strings.HasPrefix(m.Name, "Acme") {
+				got[m.Name] = m.Type
+			}
+		}
+		if len(got) != len(tt.want) {
+			t.Errorf("interesting %v: matched %v, want %v", tt.showInteresting, got, tt.want)
+		}
+		for name, typ := range tt.want {
+			if got[name] != typ {
+				t.Errorf("interesting %v: %s matched as %q, want %q", tt.showInteresting, name, got[name], typ)
+			}
+		}
+	}
+}
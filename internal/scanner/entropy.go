@@ -0,0 +1,79 @@
+package scanner
+
+import (
+	"encoding/base64"
+	"math"
+	"regexp"
+	"strings"
+)
+
+// MinEntropy is the Shannon entropy, in bits per character, a Generic Secret
+// value needs to be reported. Placeholders like "changeme_changeme" fall well
+// below it; random 16+ character tokens sit around 3.8-4.2.
+var MinEntropy = 3.5
+
+// awsMinEntropy only rejects degenerate keys (AKIAAAAA...). Real 20-character
+// keys are too short for MinEntropy: about 6% of them score below 3.5.
+const awsMinEntropy = 3.0
+
+var genericKeywordPrefix = regexp.MustCompile(`^(pass|token|cred|secret|key)`)
+
+var placeholderWords = []string{
+	"example", "sample", "placeholder", "changeme", "dummy", "your_", "your-",
+	"xxxx", "****", "<", "${", "{{",
+}
+
+func shannonEntropy(s string) float64 {
+	if s == "" {
+		return 0
+	}
+	freq := make(map[rune]int)
+	n := 0
+	for _, r := range s {
+		freq[r]++
+		n++
+	}
+
+	var entropy float64
+	for _, count := range freq {
+		p := float64(count) / float64(n)
+		entropy -= p * math.Log2(p)
+	}
+	return entropy
+}
+
+func isPlaceholder(s string) bool {
+	lower := strings.ToLower(s)
+	for _, word := range placeholderWords {
+		if strings.Contains(lower, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// decodesToWords reports whether s is base64 for plain text, which is how
+// ordinary words and sentences end up looking random in config files.
+func decodesToWords(s string) bool {
+	var decoded []byte
+	for _, enc := range []*base64.Encoding{base64.StdEncoding, base64.RawStdEncoding, base64.URLEncoding, base64.RawURLEncoding} {
+		if b, err := enc.DecodeString(s); err == nil {
+			decoded = b
+			break
+		}
+	}
+	if len(decoded) < 4 {
+		return false
+	}
+
+	letters := 0
+	for _, c := range decoded {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c == ' ':
+			letters++
+		case c < 0x20 || c > 0x7e:
+			return false
+		}
+	}
+	return float64(letters)/float64(len(decoded)) >= 0.8
+}
@@ -0,0 +1,114 @@
+package scanner
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Handle is an account on another service named in free text.
+type Handle struct {
+	Service string
+	Name    string
+}
+
+// profileURLPatterns capture the account name in profile links. Paths that
+// are pages of the service rather than accounts are rejected in FindHandles.
+var profileURLPatterns = []struct {
+	service string
+	re      *regexp.Regexp
+}{
+	{"Twitter", regexp.MustCompile(`(?i)\b(?:twitter|x)\.com/@?([A-Za-z0-9_]{1,15})\b`)},
+	{"Keybase", regexp.MustCompile(`(?i)\bkeybase\.io/([A-Za-z0-9_]{2,16})\b`)},
+	{"Telegram", regexp.MustCompile(`(?i)\b(?:t|telegram)\.me/([A-Za-z0-9_]{5,32})\b`)},
+	{"LinkedIn", regexp.MustCompile(`(?i)\blinkedin\.com/in/([A-Za-z0-9_-]{3,100})`)},
+	{"Bluesky", regexp.MustCompile(`(?i)\bbsky\.app/profile/([A-Za-z0-9.-]+\.[A-Za-z]{2,})`)},
+	{"Instagram", regexp.MustCompile(`(?i)\binstagram\.com/([A-Za-z0-9_.]{1,30})\b`)},
+	{"YouTube", regexp.MustCompile(`(?i)\byoutube\.com/@([A-Za-z0-9_.-]{3,30})`)},
+	{"Reddit", regexp.MustCompile(`(?i)\breddit\.com/(?:u|user)/([A-Za-z0-9_-]{3,20})\b`)},
+	{"GitLab", regexp.MustCompile(`(?i)\bgitlab\.com/([A-Za-z0-9_.-]{2,255})\b`)},
+	{"Codeberg", regexp.MustCompile(`(?i)\bcodeberg\.org/([A-Za-z0-9_.-]{2,40})\b`)},
+	{"Medium", regexp.MustCompile(`(?i)\bmedium\.com/@([A-Za-z0-9_.]{1,30})\b`)},
+	{"Dev.to", regexp.MustCompile(`(?i)\bdev\.to/([A-Za-z0-9_]{2,30})\b`)},
+	// Mastodon and other fediverse servers link profiles as https://host/@name
+	{"Mastodon", regexp.MustCompile(`(?i)https?://([a-z0-9.-]+\.[a-z]{2,})/@([A-Za-z0-9_]{1,30})\b`)},
+}
+
+var textHandlePatterns = []struct {
+	service string
+	re      *regexp.Regexp
+}{
+	// @name@server.tld, the fediverse address form
+	{"Mastodon", regexp.MustCompile(`(?:^|[\s(])@([A-Za-z0-9_]{1,30})@([a-z0-9.-]+\.[a-z]{2,})\b`)},
+	// "twitter: @name", "keybase - name", "tg: @name"
+	{"Twitter", regexp.MustCompile(`(?i)\btwitter\s*[:\-]\s*@?([A-Za-z0-9_]{1,15})\b`)},
+	{"Keybase", regexp.MustCompile(`(?i)\bkeybase\s*[:\-]\s*@?([A-Za-z0-9_]{2,16})\b`)},
+	{"Telegram", regexp.MustCompile(`(?i)\b(?:telegram|tg)\s*[:\-]\s*@?([A-Za-z0-9_]{5,32})\b`)},
+	{"Discord", regexp.MustCompile(`(?i)\bdiscord\s*[:\-]\s*@?([A-Za-z0-9_.]{2,32}(?:#[0-9]{4})?)`)},
+	{"Matrix", regexp.MustCompile(`(?:^|[\s(])(@[A-Za-z0-9._=-]+:[a-z0-9.-]+\.[a-z]{2,})\b`)},
+}
+
+// reservedHandlePaths are first path segments that belong to the service,
+// not to an account.
+var reservedHandlePaths = map[string]bool{
+	"home": true, "search": true, "share": true, "intent": true, "i": true, "hashtag": true,
+	"explore": true, "about": true, "help": true, "login": true, "signup": true, "settings": true,
+	"p": true, "reel": true, "watch": true, "channel": true, "users": true, "groups": true,
+	"explore-projects": true, "dashboard": true, "tag": true, "joinchat": true, "s": true,
+}
+
+// nonFediverseHosts use /@name profile paths too but have patterns of their
+// own or are not social accounts.
+var nonFediverseHosts = map[string]bool{
+	"twitter.com": true, "x.com": true, "medium.com": true, "youtube.com": true,
+	"tiktok.com": true, "threads.net": true,
+}
+
+// FindHandles returns the accounts on other services named in text, through
+// profile links or "service: name" mentions, in order of appearance and
+// without repeats.
+func FindHandles(text string) []Handle {
+	type found struct {
+		at     int
+		handle Handle
+	}
+	var all []found
+	for _, p := range profileURLPatterns {
+		for _, m := range p.re.FindAllStringSubmatchIndex(text, -1) {
+			name := text[m[2]:m[3]]
+			if p.service == "Mastodon" {
+				host := strings.TrimPrefix(strings.ToLower(name), "www.")
+				if nonFediverseHosts[host] {
+					continue
+				}
+				name = "@" + text[m[4]:m[5]] + "@" + host
+			} else if reservedHandlePaths[strings.ToLower(name)] {
+				continue
+			}
+			all = append(all, found{m[0], Handle{p.service, name}})
+		}
+	}
+	for _, p := range textHandlePatterns {
+		for _, m := range p.re.FindAllStringSubmatchIndex(text, -1) {
+			name := text[m[2]:m[3]]
+			if p.service == "Mastodon" {
+				name = "@" + name + "@" + text[m[4]:m[5]]
+			}
+			all = append(all, found{m[0], Handle{p.service, name}})
+		}
+	}
+
+	sort.SliceStable(all, func(i, j int) bool { return all[i].at < all[j].at })
+
+	var handles []Handle
+	seen := make(map[string]bool)
+	for _, f := range all {
+		key := f.handle.Service + "\x00" + strings.ToLower(f.handle.Name)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		handles = append(handles, f.handle)
+	}
+	return handles
+}
@@ -0,0 +1,48 @@
+package scanner
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFindHandles(t *testing.T) {
+	tests := []struct {
+		name string
+		bio  string
+		want []Handle
+	}{
+		{"no handles", "Backend engineer. Coffee and compilers.", nil},
+		{
+			name: "profile links",
+			bio:  "twitter.com/octo_dev · https://x.com/@octo_dev · keybase.io/octodev · t.me/octo_chat · linkedin.com/in/octo-dev",
+			want: []Handle{{"Twitter", "octo_dev"}, {"Keybase", "octodev"}, {"Telegram", "octo_chat"}, {"LinkedIn", "octo-dev"}},
+		},
+		{
+			name: "service: name mentions",
+			bio:  "Twitter: @octo_dev | keybase - octodev | tg: @octo_chat | discord: octo#1234",
+			want: []Handle{{"Twitter", "octo_dev"}, {"Keybase", "octodev"}, {"Telegram", "octo_chat"}, {"Discord", "octo#1234"}},
+		},
+		{
+			name: "fediverse and matrix",
+			bio:  "Find me at @octo@hachyderm.io or https://fosstodon.org/@octo, chat @octo:matrix.org",
+			want: []Handle{{"Mastodon", "@octo@hachyderm.io"}, {"Mastodon", "@octo@fosstodon.org"}, {"Matrix", "@octo:matrix.org"}},
+		},
+		{
+			name: "service pages are not accounts",
+			bio:  "https://twitter.com/search?q=go https://twitter.com/home https://medium.com/@octo https://www.youtube.com/@octo",
+			want: []Handle{{"Medium", "octo"}, {"YouTube", "octo"}},
+		},
+		{
+			name: "mixed formats and repeats",
+			bio:  "🐙 @octo@mastodon.social · bsky.app/profile/octo.bsky.social · gitlab.com/octo · Keybase: OctoDev · keybase.io/octodev",
+			want: []Handle{{"Mastodon", "@octo@mastodon.social"}, {"Bluesky", "octo.bsky.social"}, {"GitLab", "octo"}, {"Keybase", "OctoDev"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FindHandles(tt.bio); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FindHandles =\n %v\nwant\n %v", got, tt.want)
+			}
+		})
+	}
+}
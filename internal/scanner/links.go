@@ -1,16 +1,39 @@
 package scanner
 
 import (
+	"net/url"
 	"regexp"
 	"sort"
+	"strings"
 )
 
 var urlPattern = regexp.MustCompile(`https?://[^\s<>"]+|www\.[^\s<>"]+`)
 
+// noiseHosts are hosts whose links say nothing about the author: placeholders,
+// loopback addresses, XML namespaces and badge services.
+var noiseHosts = map[string]bool{
+	"127.0.0.1":           true,
+	"0.0.0.0":             true,
+	"example.com":         true,
+	"example.org":         true,
+	"example.net":         true,
+	"www.w3.org":          true,
+	"schemas.xmlsoap.org": true,
+	"json-schema.org":     true,
+	"img.shields.io":      true,
+	"badge.fury.io":       true,
+}
+
+// ExtractLinks returns the distinct links in content, sorted. Trailing
+// punctuation from the surrounding prose is dropped, and links to
+// placeholder, local or schema hosts and hosts without a dot are skipped.
 func ExtractLinks(content string) []string {
-	matches := urlPattern.FindAllString(content, -1)
 	uniqueLinks := make(map[string]struct{})
-	for _, link := range matches {
+	for _, link := range urlPattern.FindAllString(content, -1) {
+		link = trimLink(link)
+		if isNoiseLink(link) {
+			continue
+		}
 		uniqueLinks[link] = struct{}{}
 	}
 
@@ -21,3 +44,33 @@ func ExtractLinks(content string) []string {
 	sort.Strings(links)
 	return links
 }
+
+// trimLink strips sentence punctuation and closing brackets that have no
+// opening bracket inside the link, as in "(see https://x.io/a)".
+func trimLink(link string) string {
+	for link != "" {
+		last := link[len(link)-1]
+		switch {
+		case strings.IndexByte(".,;:!?'`*", last) >= 0:
+		case last == ')' && strings.Count(link, "(") < strings.Count(link, ")"):
+		case last == ']' && strings.Count(link, "[") < strings.Count(link, "]"):
+		case last == '}' && strings.Count(link, "{") < strings.Count(link, "}"):
+		default:
+			return link
+		}
+		link = link[:len(link)-1]
+	}
+	return link
+}
+
+func isNoiseLink(link string) bool {
+	if !strings.Contains(link, "://") {
+		link = "http://" + link
+	}
+	u, err := url.Parse(link)
+	if err != nil || !strings.Contains(u.Hostname(), ".") {
+		return true
+	}
+	host := strings.ToLower(u.Hostname())
+	return noiseHosts[host] || strings.HasSuffix(host, ".example.com") || strings.HasSuffix(host, ".local")
+}
@@ -0,0 +1,34 @@
+package scanner
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExtractLinks(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []string
+	}{
+		{"no links", "fix typo in README", []string{}},
+		{"sorted and deduplicated", "docs at https://octo.dev/b and https://octo.dev/a, again https://octo.dev/b", []string{"https://octo.dev/a", "https://octo.dev/b"}},
+		{"bare www link", "blog: www.octo.dev/posts", []string{"www.octo.dev/posts"}},
+		{"trailing punctuation", "See https://octo.dev/post. Or (https://octo.dev/about)!", []string{"https://octo.dev/about", "https://octo.dev/post"}},
+		{"balanced brackets kept", "https://en.wikipedia.org/wiki/Go_(programming_language)", []string{"https://en.wikipedia.org/wiki/Go_(programming_language)"}},
+		{"quoted and tagged", `<a href="https://octo.dev/x">x</a>`, []string{"https://octo.dev/x"}},
+		{
+			name: "noise hosts",
+			content: "http://localhost:8080 http://127.0.0.1/x https://example.com/a https://api.example.com " +
+				"http://www.w3.org/2000/svg https://img.shields.io/badge/x http://printer.local/ https://octo.dev",
+			want: []string{"https://octo.dev"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExtractLinks(tt.content); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ExtractLinks = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -8,6 +8,21 @@ var SecretPatterns = map[string]string{
 	// GitHub Tokens
 	"GitHub Token": `\b((?:ghp|gho|ghu|ghs|ghr|github_pat)_[a-zA-Z0-9_]{36,255})\b`,
 
+	// GitLab Personal Access Tokens
+	"GitLab Token": `\b(glpat-[0-9a-zA-Z_\-]{20})\b`,
+
+	// npm Access Tokens
+	"npm Token": `\b(npm_[a-zA-Z0-9]{36})\b`,
+
+	// Google API Keys
+	"Google API Key": `\b(AIza[0-9A-Za-z\-_]{35})`,
+
+	// Twilio API Keys
+	"Twilio API Key": `\b(SK[0-9a-fA-F]{32})\b`,
+
+	// SendGrid API Keys
+	"SendGrid API Key": `\b(SG\.[\w\-]{22}\.[\w\-]{43})`,
+
 	// Private Keys
 	"Private Key": `(?i)-----\s*?BEGIN[ A-Z0-9_-]*?PRIVATE KEY\s*?-----[\s\S]*?----\s*?END[ A-Z0-9_-]*? PRIVATE KEY\s*?-----`,
 
@@ -73,6 +88,26 @@ var ValidationRules = map[string][]string{
 		"Must start with ghp_, gho_, ghu_, ghs_, ghr_, or github_pat_",
 		"Must be between 36 and 255 characters",
 	},
+	"GitLab Token": {
+		"Must start with glpat-",
+		"Must be followed by 20 characters",
+	},
+	"npm Token": {
+		"Must start with npm_",
+		"Must be followed by 36 alphanumeric characters",
+	},
+	"Google API Key": {
+		"Must start with AIza",
+		"Must be 39 characters long",
+	},
+	"Twilio API Key": {
+		"Must start with SK",
+		"Must be followed by 32 hex characters",
+	},
+	"SendGrid API Key": {
+		"Must start with SG.",
+		"Must have a 22 character ID and a 43 character secret separated by a dot",
+	},
 	"Private Key": {
 		"Must be a valid PEM format",
 		"Must contain BEGIN and END markers",
@@ -131,3 +166,45 @@ var ValidationRules = map[string][]string{
 		"Must specify port or use default 5432",
 	},
 }
+
+// SecretSeverity weights each secret pattern for ranking affected
+// repositories. Credentials that grant direct access score highest; patterns
+// prone to false positives score lowest.
+var SecretSeverity = map[string]int{
+	"Private Key":                   10,
+	"GCP Service Account":           9,
+	"AWS Access Key":                8,
+	"Azure Storage Key":             8,
+	"GitHub Token":                  8,
+	"GitLab Token":                  8,
+	"npm Token":                     8,
+	"Stripe Key":                    8,
+	"MongoDB URI":                   7,
+	"SendGrid API Key":              7,
+	"Twilio API Key":                7,
+	"Google API Key":                6,
+	"PostgreSQL URI":                6,
+	"Slack Bot Token":               6,
+	"Slack User Token":              6,
+	"Slack Workspace Access Token":  6,
+	"Slack Workspace Refresh Token": 6,
+	"Generic Secret":                3,
+	"Azure Storage Account Name":    2,
+}
+
+const (
+	defaultSecretSeverity = 5
+	interestingSeverity   = 1
+)
+
+// Severity returns the ranking weight of a finding's pattern. Patterns
+// without an explicit weight get a middling score.
+func Severity(patternName string, interesting bool) int {
+	if interesting {
+		return interestingSeverity
+	}
+	if w, ok := SecretSeverity[patternName]; ok {
+		return w
+	}
+	return defaultSecretSeverity
+}
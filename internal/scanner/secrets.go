@@ -2,6 +2,9 @@ package scanner
 
 import (
 	"regexp"
+	"strings"
+
+	"github.com/gnomegl/gitslurp/v2/internal/models"
 )
 
 type PatternGroup struct {
@@ -12,11 +15,13 @@ type PatternGroup struct {
 
 type Scanner struct {
 	showInteresting bool
+	custom          []CustomPattern
 }
 
 func NewScanner(showInteresting bool) *Scanner {
 	return &Scanner{
 		showInteresting: showInteresting,
+		custom:          loadedCustomPatterns(),
 	}
 }
 
@@ -25,12 +30,33 @@ func (s *Scanner) ScanText(text string) []Match {
 
 	for name, pattern := range SecretPatterns {
 		re := regexp.MustCompile(pattern)
-		found := re.FindAllString(text, -1)
-		for _, match := range found {
+		for _, loc := range re.FindAllStringIndex(text, -1) {
+			m := Match{
+				Type:   "Secret",
+				Name:   name,
+				Value:  text[loc[0]:loc[1]],
+				Offset: loc[0],
+			}
+			if m.Validate() {
+				matches = append(matches, m)
+			}
+		}
+	}
+
+	for _, p := range s.custom {
+		matchType := "Secret"
+		if p.Type == "interesting" {
+			if !s.showInteresting {
+				continue
+			}
+			matchType = "Interesting"
+		}
+		for _, loc := range p.re.FindAllStringIndex(text, -1) {
 			matches = append(matches, Match{
-				Type:  "Secret",
-				Name:  name,
-				Value: match,
+				Type:   matchType,
+				Name:   p.Name,
+				Value:  text[loc[0]:loc[1]],
+				Offset: loc[0],
 			})
 		}
 	}
@@ -38,12 +64,12 @@ func (s *Scanner) ScanText(text string) []Match {
 	if s.showInteresting {
 		for _, pattern := range InterestingStrings {
 			re := regexp.MustCompile(pattern)
-			found := re.FindAllString(text, -1)
-			for _, match := range found {
+			for _, loc := range re.FindAllStringIndex(text, -1) {
 				matches = append(matches, Match{
-					Type:  "Interesting",
-					Name:  "Interesting String",
-					Value: match,
+					Type:   "Interesting",
+					Name:   "Interesting String",
+					Value:  text[loc[0]:loc[1]],
+					Offset: loc[0],
 				})
 			}
 		}
@@ -53,15 +79,53 @@ func (s *Scanner) ScanText(text string) []Match {
 }
 
 type Match struct {
-	Type  string // "Secret" or "Interesting"
-	Name  string // Pattern name
-	Value string // The actual matched string
+	Type   string // "Secret" or "Interesting"
+	Name   string // Pattern name
+	Value  string // The actual matched string
+	Offset int    // Byte offset of Value in the scanned text
+
+	Verdict Verdict // Set by the caller with Verify, for --verify-secrets
 }
 
+// Finding converts the match into a commit finding at location, without line
+// information; see Located.
+func (m Match) Finding(location string) models.SecretFinding {
+	return models.SecretFinding{
+		Name:        m.Name,
+		Value:       m.Value,
+		Location:    location,
+		Interesting: m.Type == "Interesting",
+		Verdict:     string(m.Verdict),
+	}
+}
+
+// Located is Finding plus the line number and surrounding lines of the match
+// in text, the content it was scanned from.
+func (m Match) Located(location, text string) models.SecretFinding {
+	f := m.Finding(location)
+	f.LineNumber, f.Context = lineContext(text, m.Offset)
+	return f
+}
+
+// Validate filters likely false positives. Generic Secret values must reach
+// MinEntropy and must not be placeholders or base64-encoded text; AWS keys
+// must not be degenerate. Other patterns are trusted as matched.
 func (m *Match) Validate() bool {
 	if _, ok := ValidationRules[m.Name]; !ok {
 		return true // No validation rules defined
 	}
 
+	switch m.Name {
+	case "Generic Secret":
+		value := genericKeywordPrefix.ReplaceAllString(m.Value, "")
+		value = strings.Trim(value, `=:"' `)
+		if shannonEntropy(value) < MinEntropy || isPlaceholder(value) {
+			return false
+		}
+		return !decodesToWords(value)
+	case "AWS Access Key":
+		return shannonEntropy(m.Value) >= awsMinEntropy
+	}
+
 	return true
 }
@@ -0,0 +1,109 @@
+package scanner
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Verdict is the outcome of checking a matched secret against its issuer.
+type Verdict string
+
+const (
+	VerdictValid   Verdict = "VALID"
+	VerdictInvalid Verdict = "INVALID"
+	VerdictUnknown Verdict = "UNKNOWN"
+)
+
+// VerifySecrets enables live verification of matches that have a registered
+// verifier (--verify-secrets). It is off by default because verifying sends
+// the credential to its issuer.
+var VerifySecrets = false
+
+const verifyTimeout = 10 * time.Second
+
+// verifyInterval spaces live checks: at most one is started per interval.
+var verifyInterval = time.Second
+
+// Verifier checks whether a matched secret is live. text is the content the
+// match was found in, so verifiers can look for a paired credential nearby.
+// Network or service errors must be reported as VerdictUnknown.
+type Verifier interface {
+	Verify(ctx context.Context, m Match, text string) Verdict
+}
+
+var (
+	verifyMu   sync.Mutex
+	verifiers  = map[string]Verifier{"AWS Access Key": awsVerifier{}}
+	verdicts   = make(map[string]Verdict)
+	pending    = make(map[string]chan struct{}) // checks in flight, by key
+	lastVerify time.Time
+)
+
+// RegisterVerifier sets the verifier used for matches of the named pattern.
+func RegisterVerifier(name string, v Verifier) {
+	verifyMu.Lock()
+	defer verifyMu.Unlock()
+	verifiers[name] = v
+}
+
+// Verify returns the verdict for m, or "" when verification is disabled or
+// no verifier handles the pattern. Callers verify only the matches they
+// report, after deduplication and baseline suppression, so suppressed keys
+// are never sent. Checks start at most once per verifyInterval but run
+// concurrently, and verdicts are cached per value, so a key repeated across
+// commits is only sent once.
+func Verify(m Match, text string) Verdict {
+	if !VerifySecrets {
+		return ""
+	}
+
+	verifyMu.Lock()
+	v, ok := verifiers[m.Name]
+	if !ok {
+		verifyMu.Unlock()
+		return ""
+	}
+	key := m.Name + "\x00" + m.Value
+	if verdict, ok := verdicts[key]; ok {
+		verifyMu.Unlock()
+		return verdict
+	}
+	if done, ok := pending[key]; ok {
+		verifyMu.Unlock()
+		<-done
+		verifyMu.Lock()
+		defer verifyMu.Unlock()
+		if verdict, ok := verdicts[key]; ok {
+			return verdict
+		}
+		return VerdictUnknown
+	}
+	done := make(chan struct{})
+	pending[key] = done
+	// reserve the next free slot, then wait for it without holding the lock
+	start := lastVerify.Add(verifyInterval)
+	if now := time.Now(); start.Before(now) {
+		start = now
+	}
+	lastVerify = start
+	verifyMu.Unlock()
+
+	time.Sleep(time.Until(start))
+	ctx, cancel := context.WithTimeout(context.Background(), verifyTimeout)
+	verdict := v.Verify(ctx, m, text)
+	cancel()
+
+	if verdict == "" {
+		verdict = VerdictUnknown
+	}
+	verifyMu.Lock()
+	// an unpaired key may verify later once its secret turns up
+	if verdict != VerdictUnknown {
+		verdicts[key] = verdict
+	}
+	delete(pending, key)
+	close(done)
+	verifyMu.Unlock()
+	return verdict
+}
@@ -0,0 +1,73 @@
+package scanner
+
+import (
+	"context"
+	"sync"
+	"testing"
+)
+
+type stubVerifier struct {
+	mu      sync.Mutex
+	results map[string]Verdict
+	calls   map[string]int
+}
+
+func (s *stubVerifier) Verify(ctx context.Context, m Match, text string) Verdict {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls[m.Value]++
+	return s.results[m.Value]
+}
+
+func TestVerify(t *testing.T) {
+	stub := &stubVerifier{
+		results: map[string]Verdict{
+			"live":     VerdictValid,
+			"revoked":  VerdictInvalid,
+			"unpaired": VerdictUnknown,
+			"errored":  "",
+		},
+		calls: make(map[string]int),
+	}
+	RegisterVerifier("Stub Key", stub)
+	savedEnabled, savedInterval := VerifySecrets, verifyInterval
+	defer func() {
+		VerifySecrets, verifyInterval = savedEnabled, savedInterval
+		verifyMu.Lock()
+		delete(verifiers, "Stub Key")
+		verifyMu.Unlock()
+	}()
+	VerifySecrets, verifyInterval = true, 0
+
+	tests := []struct {
+		value string
+		want  Verdict
+		calls int
+	}{
+		{"live", VerdictValid, 1},
+		{"revoked", VerdictInvalid, 1},
+		{"unpaired", VerdictUnknown, 1},
+		{"errored", VerdictUnknown, 1},
+		// VALID and INVALID are cached, UNKNOWN is checked again
+		{"live", VerdictValid, 1},
+		{"revoked", VerdictInvalid, 1},
+		{"unpaired", VerdictUnknown, 2},
+	}
+	for _, tt := range tests {
+		got := Verify(Match{Type: "Secret", Name: "Stub Key", Value: tt.value}, "")
+		if got != tt.want {
+			t.Errorf("Verify(%q) = %q, want %q", tt.value, got, tt.want)
+		}
+		if calls := stub.calls[tt.value]; calls != tt.calls {
+			t.Errorf("Verify(%q): verifier called %d times, want %d", tt.value, calls, tt.calls)
+		}
+	}
+
+	if got := Verify(Match{Type: "Secret", Name: "No Verifier", Value: "live"}, ""); got != "" {
+		t.Errorf("Verify without a verifier = %q, want empty", got)
+	}
+	VerifySecrets = false
+	if got := Verify(Match{Type: "Secret", Name: "Stub Key", Value: "other"}, ""); got != "" {
+		t.Errorf("Verify while disabled = %q, want empty", got)
+	}
+}
@@ -1,9 +1,12 @@
 package service
 
 import (
+	"bufio"
 	"context"
+	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 	"sync"
 
@@ -11,11 +14,16 @@ import (
 	"github.com/gnomegl/gitslurp/v2/internal/config"
 	"github.com/gnomegl/gitslurp/v2/internal/display"
 	"github.com/gnomegl/gitslurp/v2/internal/github"
+	"github.com/gnomegl/gitslurp/v2/internal/local"
 	"github.com/gnomegl/gitslurp/v2/internal/models"
 	"github.com/gnomegl/gitslurp/v2/internal/platform"
+	"github.com/gnomegl/gitslurp/v2/internal/scanner"
 	"github.com/gnomegl/gitslurp/v2/internal/spider"
+	"github.com/gnomegl/gitslurp/v2/internal/status"
 	"github.com/gnomegl/gitslurp/v2/internal/trufflehog"
+	"github.com/gnomegl/gitslurp/v2/internal/utils"
 	gh "github.com/google/go-github/v57/github"
+	"golang.org/x/term"
 )
 
 type Orchestrator struct {
@@ -39,6 +47,66 @@ func NewOrchestrator(pool *github.ClientPool, cfg *config.AppConfig, dataWriter
 }
 
 func (o *Orchestrator) Run(ctx context.Context) error {
+	if o.config.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, o.config.Timeout)
+		defer cancel()
+	}
+	defer o.reportStopped(ctx)
+
+	if o.config.OutputDir != "" {
+		if err := os.MkdirAll(o.config.OutputDir, 0755); err != nil {
+			return fmt.Errorf("failed to create output directory %s: %v", o.config.OutputDir, err)
+		}
+	}
+
+	if o.config.OutputFile != "" {
+		path := o.outputPath(o.config.OutputFile)
+		f, err := os.Create(path)
+		if err != nil {
+			return fmt.Errorf("failed to create output file %s: %v", path, err)
+		}
+		defer func() {
+			f.Close()
+			color.Green("[+] Wrote %s results to %s", strings.ToUpper(o.config.OutputFormat), path)
+		}()
+		o.dataWriter = f
+	}
+
+	if o.config.MinEntropy > 0 {
+		scanner.MinEntropy = o.config.MinEntropy
+	}
+	if o.config.VerifySecrets && o.config.CheckSecrets {
+		scanner.VerifySecrets = true
+		status.Yellow("[!] --verify-secrets: detected AWS keys with a paired secret will be sent to AWS STS")
+	}
+	if o.config.BaselineFindings != nil {
+		scanner.UseBaseline(o.config.BaselineFindings)
+		status.Green("[+] Suppressing %d baseline findings", len(o.config.BaselineFindings.Findings))
+	}
+	utils.UseHolidays(o.config.Holidays)
+	if len(o.config.CustomPatterns) > 0 {
+		scanner.UseCustomPatterns(o.config.CustomPatterns)
+		status.Green("[+] Loaded %d custom patterns", len(o.config.CustomPatterns))
+	}
+
+	if n := github.PruneCommitCache(o.config.CacheDir, o.config.CacheTTL); n > 0 {
+		status.Blue("Removed %d expired entries from the commit cache", n)
+	}
+
+	if o.config.LocalPath != "" {
+		return o.RunLocal(ctx)
+	}
+
+	if o.config.MaxAPICalls > 0 && o.pool != nil {
+		o.pool.Budget().SetLimit(o.config.MaxAPICalls)
+		defer o.reportBudget()
+	}
+	if o.config.WaitOnRateLimit {
+		o.pool.SetWaitOnRateLimit(true)
+		o.pool.SetMaxRateLimitWait(o.config.MaxWait)
+	}
+
 	if o.config.SpiderMode {
 		return o.RunSpider(ctx)
 	}
@@ -63,17 +131,81 @@ func (o *Orchestrator) Run(ctx context.Context) error {
 		o.config.ShowTargetOnly = false
 	}
 
-	display.UserInfo(user, isOrg)
+	var accountEmails []*gh.UserEmail
+	if user != nil && !isOrg {
+		accountEmails = github.FetchAccountEmails(ctx, o.pool, user.GetLogin())
+	}
+
+	display.UserInfo(user, isOrg, accountEmails)
+	pivotLinks := o.collectPivotLinks(ctx, user, isOrg)
+	display.PivotLinks(pivotLinks)
 
 	if o.config.ProfileOnly {
 		return o.maybeRunTrufflehog(ctx, username, isOrg)
 	}
 
+	if err := o.preflightLargeTarget(user, isOrg); err != nil {
+		return err
+	}
+
 	cfg := github.DefaultConfig()
 	cfg.ShowInteresting = o.config.ShowInteresting
 	cfg.QuickMode = o.config.QuickMode
 	cfg.TimestampAnalysis = o.config.TimestampAnalysis
 	cfg.IncludeForks = o.config.IncludeForks
+	cfg.IncludePatches = o.config.IncludePatches
+	cfg.ScanVendored = o.config.ScanVendored
+	cfg.Since = o.config.Since
+	cfg.Until = o.config.Until
+	cfg.CacheDir = o.config.CacheDir
+	cfg.CacheTTL = o.config.CacheTTL
+	cfg.GraphQL = o.config.GraphQL
+	cfg.MaxRepos = o.config.MaxRepos
+	cfg.MaxCommits = o.config.MaxCommitsPerRepo
+	cfg.Branch = o.config.Branch
+	cfg.AllBranches = o.config.AllBranches
+	cfg.IncludeAnonymous = o.config.IncludeAnonymous
+	cfg.IncludeCommitters = o.config.IncludeCommitters
+	cfg.StrictOrgDomain = o.config.StrictOrgDomain
+	cfg.ExcludeMerges = o.config.ExcludeMerges
+	cfg.TopContributors = o.config.TopContributors
+	cfg.Redact = o.config.Redact
+	cfg.HashLength = o.config.HashLength
+	cfg.MaxNames = o.config.MaxNames
+	cfg.SortBy = o.config.SortBy
+	cfg.FilterDomains = o.config.FilterDomains
+	cfg.ExcludeDomains = o.config.ExcludeDomains
+	cfg.HideNoreply = o.config.HideNoreply
+	cfg.RepoType = o.config.RepoType
+	cfg.FindLinks = o.config.FindLinks
+	if o.config.RepoConcurrency > 0 {
+		cfg.RepoConcurrency = o.config.RepoConcurrency
+	}
+	if o.config.CommitConcurrency > 0 {
+		cfg.CommitConcurrency = o.config.CommitConcurrency
+	}
+	if o.config.GistConcurrency > 0 {
+		cfg.GistConcurrency = o.config.GistConcurrency
+	}
+	if o.config.GistRetries >= 0 {
+		cfg.GistRetries = o.config.GistRetries
+	}
+
+	cfg.PivotLinks = pivotLinks
+
+	if o.config.IncludePrivate {
+		cfg.IncludePrivate = o.isTokenOwner(ctx, user, isOrg)
+	}
+
+	if isOrg && o.config.OrgMembers {
+		members, err := github.FetchOrgMembers(ctx, o.pool.GetClient().Client, username)
+		if err != nil {
+			status.Yellow("[!] Could not list organization members: %v", err)
+		} else {
+			status.Green("[+] Found %d public organization members", len(members))
+			cfg.OrgMembers = members
+		}
+	}
 
 	repos, gists, err := o.fetchReposAndGists(ctx, username, isOrg, &cfg, user)
 	if err != nil {
@@ -92,37 +224,56 @@ func (o *Orchestrator) Run(ctx context.Context) error {
 	}
 
 	userIdentifiers := o.buildUserIdentifiers(username, lookupEmail, user)
+	for _, e := range accountEmails {
+		userIdentifiers[e.GetEmail()] = true
+	}
 
 	if o.config.OutputFormat == "json" {
-		if err := o.runStreamingJSON(ctx, repos, gists, username, lookupEmail, user, isOrg, userIdentifiers, &cfg); err != nil {
+		emails, err := o.runStreamingJSON(ctx, repos, gists, username, lookupEmail, user, accountEmails, isOrg, userIdentifiers, &cfg)
+		if err != nil {
 			return err
 		}
+		display.WriteJSONAnalysis(o.dataWriter, &display.Context{
+			Emails:          emails,
+			KnownUsername:   username,
+			User:            user,
+			AccountEmails:   accountEmails,
+			Cfg:             &cfg,
+			UserIdentifiers: userIdentifiers,
+		})
+		o.writeExports(emails, lookupEmail, username, user, accountEmails, isOrg, &cfg)
+		o.writePatches(emails)
+		o.writeBaseline(emails)
 		return o.maybeRunTrufflehog(ctx, username, isOrg)
 	}
 
-	emails := github.RateLimitedProcessRepos(ctx, o.pool, repos, o.config.CheckSecrets, &cfg, userIdentifiers, o.config.ShowTargetOnly, nil)
+	emails := o.processRepos(ctx, username, isOrg, repos, &cfg, userIdentifiers, nil)
 
-	if len(gists) > 0 && (o.config.CheckSecrets || cfg.ShowInteresting) {
+	if len(gists) > 0 && !o.config.NoGists && (o.config.CheckSecrets || cfg.ShowInteresting) {
 		emails = o.processGists(ctx, gists, emails, &cfg)
 	}
 
-	externalEmails, err := github.FetchExternalContributions(ctx, o.pool, username, o.config.CheckSecrets, &cfg)
+	for email, details := range o.scanIssues(ctx, username, isOrg, repos, &cfg) {
+		if existing, ok := emails[email]; ok {
+			existing.Merge(details)
+		} else {
+			emails[email] = details
+		}
+	}
+
+	externalEmails, err := o.fetchExternalContributions(ctx, username, isOrg, &cfg)
 	if err == nil && len(externalEmails) > 0 {
 		for email, details := range externalEmails {
 			if existing, ok := emails[email]; ok {
-				for name := range details.Names {
-					existing.Names[name] = struct{}{}
-				}
-				for repoName, commits := range details.Commits {
-					existing.Commits[repoName] = append(existing.Commits[repoName], commits...)
-				}
-				existing.CommitCount += details.CommitCount
+				existing.Merge(details)
 			} else {
 				emails[email] = details
 			}
 		}
 	}
 
+	o.mergeForkContributions(ctx, emails, username, isOrg, userIdentifiers, &cfg, nil)
+
 	if len(emails) == 0 {
 		if err := o.handleNoEmails(isOrg, username, len(repos)); err != nil {
 			// Still try trufflehog even if no emails found
@@ -133,162 +284,269 @@ func (o *Orchestrator) Run(ctx context.Context) error {
 		}
 	}
 
-	display.Results(emails, o.config.ShowDetails, o.config.CheckSecrets, lookupEmail, username, user, o.config.ShowTargetOnly, isOrg, &cfg, o.config.OutputFormat, o.dataWriter)
+	display.Results(emails, o.config.ShowDetails, o.config.CheckSecrets, lookupEmail, username, user, accountEmails, o.config.ShowTargetOnly, isOrg, &cfg, o.config.OutputFormat, o.dataWriter)
+	o.writeExports(emails, lookupEmail, username, user, accountEmails, isOrg, &cfg)
+
+	o.writePatches(emails)
+	o.writeBaseline(emails)
 
-	o.pool.DisplayPoolRateLimit(ctx)
+	if !o.config.Quiet && ctx.Err() == nil {
+		o.pool.DisplayPoolRateLimit(ctx)
+	}
 
 	return o.maybeRunTrufflehogWithEmails(ctx, username, isOrg, emails)
 }
 
-func (o *Orchestrator) runStreamingJSON(ctx context.Context, repos []*gh.Repository, gists []*gh.Gist, username, lookupEmail string, user *gh.User, isOrg bool, userIdentifiers map[string]bool, cfg *github.Config) error {
+// processRepos crawls every repository's full history with --deep, reads
+// only the user's recent push events with --quick, and otherwise runs the
+// light scan of the most recently pushed repositories. Organizations have no
+// event stream of their own, so --quick falls back to the light scan.
+func (o *Orchestrator) processRepos(ctx context.Context, username string, isOrg bool, repos []*gh.Repository, cfg *github.Config, userIdentifiers map[string]bool, updateChan chan<- github.EmailUpdate) map[string]*models.EmailDetails {
+	switch {
+	case o.config.Deep:
+		return github.RateLimitedProcessRepos(ctx, o.pool, repos, o.config.CheckSecrets, cfg, userIdentifiers, o.config.ShowTargetOnly, updateChan)
+	case o.config.QuickMode && !isOrg:
+		emails := github.ProcessUserEvents(ctx, o.pool, username, o.config.CheckSecrets, cfg, userIdentifiers, o.config.ShowTargetOnly)
+		if updateChan != nil {
+			for email, details := range emails {
+				updateChan <- github.EmailUpdate{Email: email, Details: details.Snapshot()}
+			}
+		}
+		return emails
+	default:
+		return github.ProcessReposLimited(ctx, o.pool, repos, o.config.CheckSecrets, cfg, userIdentifiers, o.config.ShowTargetOnly, updateChan)
+	}
+}
+
+func (o *Orchestrator) runStreamingJSON(ctx context.Context, repos []*gh.Repository, gists []*gh.Gist, username, lookupEmail string, user *gh.User, accountEmails []*gh.UserEmail, isOrg bool, userIdentifiers map[string]bool, cfg *github.Config) (map[string]*models.EmailDetails, error) {
 	updateChan := make(chan github.EmailUpdate, 100)
 	var wg sync.WaitGroup
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		display.StreamJSON(o.dataWriter, username, lookupEmail, user, isOrg, o.config.ShowTargetOnly, updateChan)
+		display.StreamJSON(o.dataWriter, username, lookupEmail, user, accountEmails, isOrg, o.config.ShowTargetOnly, cfg, updateChan)
 	}()
 
-	emails := github.RateLimitedProcessRepos(ctx, o.pool, repos, o.config.CheckSecrets, cfg, userIdentifiers, o.config.ShowTargetOnly, updateChan)
+	emails := o.processRepos(ctx, username, isOrg, repos, cfg, userIdentifiers, updateChan)
 
-	if len(gists) > 0 && (o.config.CheckSecrets || cfg.ShowInteresting) {
+	if len(gists) > 0 && !o.config.NoGists && (o.config.CheckSecrets || cfg.ShowInteresting) {
 		gistEmails := github.ProcessGists(ctx, o.pool, gists, o.config.CheckSecrets, cfg)
 		for email, details := range gistEmails {
 			if existing, ok := emails[email]; ok {
-				for name := range details.Names {
-					existing.Names[name] = struct{}{}
-				}
-				for repoName, commits := range details.Commits {
-					existing.Commits[repoName] = append(existing.Commits[repoName], commits...)
-				}
-				existing.CommitCount += details.CommitCount
+				existing.Merge(details)
 			} else {
 				emails[email] = details
-				updateChan <- github.EmailUpdate{Email: email, Details: details}
+				updateChan <- github.EmailUpdate{Email: email, Details: details.Snapshot()}
 			}
 		}
 	}
 
-	externalEmails, err := github.FetchExternalContributions(ctx, o.pool, username, o.config.CheckSecrets, cfg)
+	for email, details := range o.scanIssues(ctx, username, isOrg, repos, cfg) {
+		if existing, ok := emails[email]; ok {
+			existing.Merge(details)
+		} else {
+			emails[email] = details
+			updateChan <- github.EmailUpdate{Email: email, Details: details.Snapshot()}
+		}
+	}
+
+	externalEmails, err := o.fetchExternalContributions(ctx, username, isOrg, cfg)
 	if err == nil && len(externalEmails) > 0 {
 		for email, details := range externalEmails {
 			if existing, ok := emails[email]; ok {
-				for name := range details.Names {
-					existing.Names[name] = struct{}{}
-				}
-				for repoName, commits := range details.Commits {
-					existing.Commits[repoName] = append(existing.Commits[repoName], commits...)
-				}
-				existing.CommitCount += details.CommitCount
+				existing.Merge(details)
 			} else {
 				emails[email] = details
-				updateChan <- github.EmailUpdate{Email: email, Details: details}
+				updateChan <- github.EmailUpdate{Email: email, Details: details.Snapshot()}
 			}
 		}
 	}
 
+	o.mergeForkContributions(ctx, emails, username, isOrg, userIdentifiers, cfg, updateChan)
+
 	close(updateChan)
 	wg.Wait()
 
-	return nil
+	return emails, nil
+}
+
+// fetchExternalContributions searches for the target's commits outside its own
+// repositories. Organizations never author commits, so they are skipped.
+func (o *Orchestrator) fetchExternalContributions(ctx context.Context, username string, isOrg bool, cfg *github.Config) (map[string]*models.EmailDetails, error) {
+	if isOrg {
+		return nil, nil
+	}
+	return github.FetchExternalContributions(ctx, o.pool, username, o.config.CheckSecrets, cfg)
+}
+
+// mergeForkContributions adds the target's commits that sit ahead of upstream
+// in their forks. Commits already collected from a fork (--include-forks) are
+// not counted twice.
+func (o *Orchestrator) mergeForkContributions(ctx context.Context, emails map[string]*models.EmailDetails, username string, isOrg bool, userIdentifiers map[string]bool, cfg *github.Config, updateChan chan<- github.EmailUpdate) {
+	if !o.config.ForkNetwork || isOrg {
+		return
+	}
+
+	forkEmails, _, err := github.FetchForkContributions(ctx, o.pool, username, userIdentifiers, o.config.CheckSecrets, cfg)
+	if err != nil {
+		status.Yellow("[!] Could not analyze fork network: %v", err)
+		return
+	}
+
+	for email, details := range forkEmails {
+		existing, ok := emails[email]
+		if !ok {
+			emails[email] = details
+			if updateChan != nil {
+				updateChan <- github.EmailUpdate{Email: email, Details: details.Snapshot()}
+			}
+			continue
+		}
+
+		existing.Merge(details)
+	}
 }
 
 func (o *Orchestrator) resolveTarget(ctx context.Context) (username, lookupEmail string, err error) {
+	if o.config.ByID > 0 {
+		user, _, err := o.pool.GetClient().Client.Users.GetByID(ctx, o.config.ByID)
+		if err != nil {
+			return "", "", fmt.Errorf("failed to look up user ID %d: %v", o.config.ByID, err)
+		}
+		o.config.Target = user.GetLogin()
+		fmt.Println()
+		status.Blue("Target User ID: %d (currently %s)", o.config.ByID, user.GetLogin())
+		return user.GetLogin(), "", nil
+	}
+
 	username = o.config.Target
 
 	if github.IsValidEmail(o.config.Target) {
 		lookupEmail = o.config.Target
 		fmt.Println()
-		color.Blue("Target Email: %s", o.config.Target)
+		status.Blue("Target Email: %s", o.config.Target)
 
 		client := o.pool.GetClient().Client
-		hasDeleteRepo, permErr := github.CheckDeleteRepoPermissions(ctx, client)
-		if permErr != nil {
-			color.Yellow("[!] Warning: Could not check token permissions: %v", permErr)
-		} else if !hasDeleteRepo {
-			color.Red("\n[x] Your GitHub token lacks delete_repo permissions required for email-based investigations")
-			color.Yellow("[!] To update your token permissions:")
-			fmt.Println("1. Visit: https://github.com/settings/tokens")
-			fmt.Println("2. Click on your existing gitslurp token")
-			fmt.Println("3. Check the 'delete_repo' scope")
-			fmt.Println("4. Click 'Update token' at the bottom")
-			color.Blue("\nAlternatively, create a new token with delete_repo permissions:")
-			fmt.Println("https://github.com/settings/tokens/new?description=gitslurp&scopes=repo,read:user,user:email,delete_repo")
-			return "", "", fmt.Errorf("insufficient token permissions for email investigation")
+		if login, id, ok := github.ResolveNoreplyEmail(o.config.Target); ok {
+			// the ID survives renames, so it wins over a possibly stale login
+			if id > 0 {
+				if user, _, err := client.Users.GetByID(ctx, id); err == nil {
+					login = user.GetLogin()
+				}
+			}
+			status.Green("[+] Found GitHub account via noreply address: %s", login)
+			return login, lookupEmail, nil
 		}
 
 		user, err := github.GetUserByEmail(ctx, client, o.config.Target)
+		if err == nil && user != nil {
+			username = user.GetLogin()
+			status.Green("[+] Found GitHub account via API: %s", username)
+			return username, lookupEmail, nil
+		}
+
 		if err != nil {
 			color.Red("[x] API search error: %v", err)
 			fmt.Println()
-			color.Yellow("Attempting email spoofing method...")
-
-			spoofedUsername, spoofErr := github.GetUsernameFromEmailSpoof(ctx, client, o.config.Target, o.token)
-			if spoofErr != nil {
-				color.Red("[x] Email spoofing failed: %v", spoofErr)
-				return "", "", fmt.Errorf("failed to resolve email %s: %v", o.config.Target, spoofErr)
-			}
-
-			username = spoofedUsername
-			color.Green("[+] Found GitHub account via spoofing: %s", username)
-		} else if user == nil {
+		} else {
 			fmt.Println()
-			color.Yellow("[!] No user found via API search")
-			color.Yellow("Attempting email spoofing method...")
-
-			spoofedUsername, spoofErr := github.GetUsernameFromEmailSpoof(ctx, client, o.config.Target, o.token)
-			if spoofErr != nil {
-				color.Red("[x] Email spoofing failed: %v", spoofErr)
-				return "", "", fmt.Errorf("no GitHub user found for email: %s", o.config.Target)
-			}
+			status.Yellow("[!] No user found via API search")
+		}
 
-			username = spoofedUsername
-			color.Green("[+] Found GitHub account via spoofing: %s", username)
+		login, err := github.GetUserByCommitEmail(ctx, client, o.config.Target)
+		if err == nil && login != "" {
+			status.Green("[+] Found GitHub account via commit search: %s", login)
+			return login, lookupEmail, nil
+		}
+		if err != nil {
+			status.Yellow("[!] Commit search error: %v", err)
 		} else {
-			username = user.GetLogin()
-			color.Green("[+] Found GitHub account via API: %s", username)
+			status.Yellow("[!] No public commits found for this email")
+		}
+
+		username, err = o.resolveEmailBySpoof(ctx, client)
+		if err != nil {
+			return "", "", err
 		}
+		status.Green("[+] Found GitHub account via spoofing: %s", username)
 	} else {
 		fmt.Println()
-		color.Blue("Target Username: %s", username)
+		status.Blue("Target Username: %s", username)
 	}
 
 	return username, lookupEmail, nil
 }
 
+// resolveEmailBySpoof is the fallback when search cannot map the target email
+// to an account. Only this method needs the delete_repo scope, since it creates
+// and removes a temporary repository.
+func (o *Orchestrator) resolveEmailBySpoof(ctx context.Context, client *gh.Client) (string, error) {
+	if o.pool.IsAppAuth() {
+		return "", fmt.Errorf("no GitHub user found for email %s via search, and the spoofing fallback requires a user token (not supported with GitHub App authentication)", o.config.Target)
+	}
+
+	hasDeleteRepo, permErr := github.CheckDeleteRepoPermissions(ctx, client)
+	if permErr != nil {
+		status.Yellow("[!] Warning: Could not check token permissions: %v", permErr)
+	} else if !hasDeleteRepo {
+		color.Red("\n[x] The email spoofing fallback needs a token with the delete_repo scope")
+		status.Yellow("[!] To update your token permissions:")
+		fmt.Println("1. Visit: https://github.com/settings/tokens")
+		fmt.Println("2. Click on your existing gitslurp token")
+		fmt.Println("3. Check the 'delete_repo' scope")
+		fmt.Println("4. Click 'Update token' at the bottom")
+		status.Blue("\nAlternatively, create a new token with delete_repo permissions:")
+		fmt.Println("https://github.com/settings/tokens/new?description=gitslurp&scopes=repo,read:user,user:email,delete_repo")
+		return "", fmt.Errorf("no GitHub user found for email %s via search, and the token lacks delete_repo for the spoofing fallback", o.config.Target)
+	}
+
+	status.Yellow("Attempting email spoofing method...")
+	spoofedUsername, spoofErr := github.GetUsernameFromEmailSpoof(ctx, client, o.config.Target, o.token, github.SpoofOptions{
+		RepoPrefix: o.config.SpoofRepoPrefix,
+		SyncWait:   o.config.SpoofSyncWait,
+	})
+	if spoofErr != nil {
+		color.Red("[x] Email spoofing failed: %v", spoofErr)
+		return "", fmt.Errorf("failed to resolve email %s: %v", o.config.Target, spoofErr)
+	}
+	return spoofedUsername, nil
+}
+
 func (o *Orchestrator) fetchUserInfo(ctx context.Context, username, lookupEmail string) (*gh.User, bool, error) {
 	if lookupEmail != "" {
 		return nil, false, nil
 	}
 
 	fmt.Println()
-	color.Yellow("Checking account type...")
+	status.Yellow("Checking account type...")
 
 	client := o.pool.GetClient().Client
 	isOrg, err := github.IsOrganization(ctx, client, username)
 	if err != nil {
+		err = github.DescribeAccountError(username, err)
 		color.Red("[x] Error checking organization status: %v", err)
 		return nil, false, err
 	}
 
 	if isOrg {
-		color.Green("[+] Organization account detected")
-		color.Blue("Fetching organization profile...")
+		status.Green("[+] Organization account detected")
+		status.Blue("Fetching organization profile...")
 	} else {
-		color.Green("[+] User account detected")
-		color.Blue("Fetching user profile...")
+		status.Green("[+] User account detected")
+		status.Blue("Fetching user profile...")
 	}
 
 	user, _, err := o.pool.GetClient().Client.Users.Get(ctx, username)
 	if err != nil {
+		err = github.DescribeAccountError(username, err)
 		color.Red("[x] Error fetching profile details: %v", err)
 		return nil, false, err
 	}
 
 	if isOrg {
-		color.Green("[+] Organization profile loaded: %s", user.GetLogin())
+		status.Green("[+] Organization profile loaded: %s", user.GetLogin())
 	} else {
-		color.Green("[+] User profile loaded: %s", user.GetLogin())
+		status.Green("[+] User profile loaded: %s", user.GetLogin())
 	}
 
 	return user, isOrg, nil
@@ -308,6 +566,7 @@ func (o *Orchestrator) fetchReposAndGists(ctx context.Context, username string,
 			color.Red("[x] Error: %v", err)
 			return nil, nil, err
 		}
+		gists = o.fetchGists(ctx, client, username, cfg, user)
 	}
 
 	if err != nil {
@@ -332,6 +591,30 @@ func (o *Orchestrator) processRepoEvents(ctx context.Context, repos []*gh.Reposi
 	return processor.Process(ctx, repos, o.config.ShowStargazers, o.config.ShowForkers)
 }
 
+// fetchGists pulls gist contents only when they are going to be scanned, since
+// each gist costs an extra request.
+func (o *Orchestrator) fetchGists(ctx context.Context, client *gh.Client, username string, cfg *github.Config, user *gh.User) []*gh.Gist {
+	if o.config.NoGists {
+		if n := user.GetPublicGists(); n > 0 {
+			status.Yellow("[!] Skipping %d public gists (--no-gists)", n)
+		}
+		return nil
+	}
+	if !o.config.CheckSecrets && !cfg.ShowInteresting {
+		return nil
+	}
+
+	gists, skipped, err := github.FetchGists(ctx, client, username, cfg)
+	if err != nil {
+		status.Yellow("[!]  Warning: %v", err)
+		return nil
+	}
+	if skipped > 0 {
+		status.Yellow("[!] Skipping %d of %d public gists (content could not be fetched)", skipped, skipped+len(gists))
+	}
+	return gists
+}
+
 func (o *Orchestrator) buildUserIdentifiers(username, lookupEmail string, user *gh.User) map[string]bool {
 	identifiers := map[string]bool{
 		username:    true,
@@ -357,18 +640,12 @@ func (o *Orchestrator) processGists(ctx context.Context, gists []*gh.Gist, email
 		scanType = "interesting patterns"
 	}
 
-	color.Blue("\nProcessing %d public gists for %s...", len(gists), scanType)
+	status.Blue("\nProcessing %d public gists for %s...", len(gists), scanType)
 	gistEmails := github.ProcessGists(ctx, o.pool, gists, o.config.CheckSecrets, cfg)
 
 	for email, details := range gistEmails {
 		if existing, ok := emails[email]; ok {
-			for name := range details.Names {
-				existing.Names[name] = struct{}{}
-			}
-			for repoName, commits := range details.Commits {
-				existing.Commits[repoName] = append(existing.Commits[repoName], commits...)
-			}
-			existing.CommitCount += details.CommitCount
+			existing.Merge(details)
 		} else {
 			emails[email] = details
 		}
@@ -377,10 +654,106 @@ func (o *Orchestrator) processGists(ctx context.Context, gists []*gh.Gist, email
 	return emails
 }
 
+// collectPivotLinks gathers the profile's external links, reading the profile
+// README of users, and checks them with --resolve-links.
+func (o *Orchestrator) collectPivotLinks(ctx context.Context, user *gh.User, isOrg bool) []github.PivotLink {
+	if user == nil {
+		return nil
+	}
+	var readme string
+	if !isOrg {
+		readme = github.FetchProfileReadme(ctx, o.pool.GetClient().Client, user.GetLogin())
+	}
+	links := github.CollectPivotLinks(user, readme)
+	if o.config.ResolveLinks && len(links) > 0 {
+		status.Blue("Checking %d profile links...", len(links))
+		github.CheckPivotLinks(ctx, links)
+	}
+	return links
+}
+
+// scanIssues scans what the target wrote in their repositories' issues and
+// pull requests with --scan-issues. Like gists, this only runs when secrets
+// or interesting strings are being looked for.
+func (o *Orchestrator) scanIssues(ctx context.Context, username string, isOrg bool, repos []*gh.Repository, cfg *github.Config) map[string]*models.EmailDetails {
+	if !o.config.ScanIssues || len(repos) == 0 {
+		return nil
+	}
+	if isOrg {
+		status.Yellow("[!] Skipping --scan-issues: %s is an organization and writes no comments of its own", username)
+		return nil
+	}
+	if !o.config.CheckSecrets && !cfg.ShowInteresting {
+		status.Yellow("[!] Skipping --scan-issues: it needs --secrets or --interesting")
+		return nil
+	}
+
+	status.Blue("\nScanning %s's issues, pull requests and comments in %d repositories...", username, len(repos))
+	return github.ProcessIssueComments(ctx, o.pool, repos, username, o.config.CheckSecrets, cfg)
+}
+
+// writeExports saves extra copies of the results in the formats requested with
+// --json-out and --csv-out, reusing the data already collected for this run.
+func (o *Orchestrator) writeExports(emails map[string]*models.EmailDetails, lookupEmail, username string, user *gh.User, accountEmails []*gh.UserEmail, isOrg bool, cfg *github.Config) {
+	exports := []struct {
+		format string
+		path   string
+	}{
+		{"json", o.config.JSONOut},
+		{"csv", o.config.CSVOut},
+	}
+
+	for _, export := range exports {
+		if export.path == "" {
+			continue
+		}
+		path := o.outputPath(export.path)
+		f, err := os.Create(path)
+		if err != nil {
+			color.Red("[x] Error creating %s output %s: %v", export.format, path, err)
+			continue
+		}
+		display.Results(emails, o.config.ShowDetails, o.config.CheckSecrets, lookupEmail, username, user, accountEmails, o.config.ShowTargetOnly, isOrg, cfg, export.format, f)
+		f.Close()
+		color.Green("[+] Wrote %s results to %s", strings.ToUpper(export.format), path)
+	}
+}
+
+func (o *Orchestrator) writePatches(emails map[string]*models.EmailDetails) {
+	if o.config.PatchesDir == "" {
+		return
+	}
+
+	dir := o.outputPath(o.config.PatchesDir)
+	written, err := display.WritePatches(dir, emails, o.config.Redact)
+	if err != nil {
+		color.Red("[x] Error writing patches: %v", err)
+		return
+	}
+	if written > 0 {
+		color.Green("[+] Wrote %d flagged commit patches to %s", written, dir)
+	}
+}
+
+// writeBaseline saves this run's findings to the --baseline file when
+// --write-baseline is set, replacing its previous contents.
+func (o *Orchestrator) writeBaseline(emails map[string]*models.EmailDetails) {
+	if !o.config.WriteBaseline {
+		return
+	}
+
+	b := display.BaselineFindings(emails)
+	if err := scanner.WriteBaseline(o.config.Baseline, b); err != nil {
+		color.Red("[x] Error writing baseline: %v", err)
+		return
+	}
+	color.Green("[+] Wrote %d findings to baseline %s", len(b.Findings), o.config.Baseline)
+}
+
 func (o *Orchestrator) handleNoEmails(isOrg bool, username string, repoCount int) error {
 	if isOrg {
 		if repoCount > 0 {
-			color.Yellow("\nAll commits in this organization's repositories are anonymous")
+			status.Yellow("\nAll commits in this organization's repositories are anonymous")
 			return nil
 		}
 		return fmt.Errorf("no repositories found for organization: %s", username)
@@ -393,7 +766,7 @@ func (o *Orchestrator) maybeRunTrufflehog(ctx context.Context, username string,
 }
 
 func (o *Orchestrator) maybeRunTrufflehogWithEmails(ctx context.Context, username string, isOrg bool, emails map[string]*models.EmailDetails) error {
-	if o.config.SecretsScope == "" {
+	if o.config.SecretsScope == "" || ctx.Err() != nil {
 		return nil
 	}
 
@@ -420,6 +793,7 @@ func (o *Orchestrator) maybeRunTrufflehogWithEmails(ctx context.Context, usernam
 
 	runner := trufflehog.NewRunner(o.pool, scope)
 	runner.SetDiscoveredUsers(discoveredLogins)
+	runner.SetOutputDir(o.outputPath("trufflehog_results"))
 	return runner.Run(ctx, username, isOrg)
 }
 
@@ -450,24 +824,24 @@ func (o *Orchestrator) RunMultiPlatform(ctx context.Context, plat string) error
 
 	username := o.config.Target
 	fmt.Println()
-	color.Blue("Target: %s (%s)", username, provider.Name())
+	status.Blue("Target: %s (%s)", username, provider.Name())
 
 	isOrg, err := provider.IsOrganization(ctx, username)
 	if err != nil {
-		color.Yellow("[!] Could not check organization status: %v", err)
+		status.Yellow("[!] Could not check organization status: %v", err)
 	}
 
 	if isOrg {
-		color.Green("[+] Organization account detected")
+		status.Green("[+] Organization account detected")
 	} else {
-		color.Green("[+] User account detected")
+		status.Green("[+] User account detected")
 	}
 
 	var userInfo *platform.UserInfo
 	if !isOrg {
 		userInfo, err = provider.GetUser(ctx, username)
 		if err != nil {
-			color.Yellow("[!] Could not fetch user profile: %v", err)
+			status.Yellow("[!] Could not fetch user profile: %v", err)
 		}
 	}
 
@@ -481,6 +855,9 @@ func (o *Orchestrator) RunMultiPlatform(ctx context.Context, plat string) error
 		PerPage:           100,
 		MaxConcurrent:     5,
 	}
+	if o.config.RepoConcurrency > 0 {
+		cfg.MaxConcurrent = o.config.RepoConcurrency
+	}
 
 	runner := platform.NewRunner(provider, cfg)
 
@@ -498,7 +875,7 @@ func (o *Orchestrator) RunMultiPlatform(ctx context.Context, plat string) error
 	}
 
 	if len(emails) == 0 {
-		color.Yellow("\n[!] No emails found in commit history")
+		status.Yellow("\n[!] No emails found in commit history")
 		return nil
 	}
 
@@ -516,37 +893,215 @@ func (o *Orchestrator) RunMultiPlatform(ctx context.Context, plat string) error
 	ghCfg := github.DefaultConfig()
 	ghCfg.ShowInteresting = o.config.ShowInteresting
 	ghCfg.TimestampAnalysis = o.config.TimestampAnalysis
+	ghCfg.StrictOrgDomain = o.config.StrictOrgDomain
+	ghCfg.TopContributors = o.config.TopContributors
+	ghCfg.Redact = o.config.Redact
+	ghCfg.HashLength = o.config.HashLength
+	ghCfg.MaxNames = o.config.MaxNames
+	ghCfg.SortBy = o.config.SortBy
+	ghCfg.FilterDomains = o.config.FilterDomains
+	ghCfg.ExcludeDomains = o.config.ExcludeDomains
+	ghCfg.HideNoreply = o.config.HideNoreply
 
 	display.Results(emails, o.config.ShowDetails, o.config.CheckSecrets,
-		"", username, ghUser, o.config.ShowTargetOnly, isOrg, &ghCfg, o.config.OutputFormat, o.dataWriter)
+		"", username, ghUser, nil, o.config.ShowTargetOnly, isOrg, &ghCfg, o.config.OutputFormat, o.dataWriter)
+	o.writeExports(emails, "", username, ghUser, nil, isOrg, &ghCfg)
+	o.writeBaseline(emails)
+
+	return nil
+}
+
+// RunLocal analyzes a clone on disk. It never touches the network, so the pool
+// is nil here; an optional username or email marks the target's commits.
+func (o *Orchestrator) RunLocal(ctx context.Context) error {
+	fmt.Println()
+	status.Blue("Local Repository: %s", o.config.LocalPath)
+
+	username := o.config.Target
+	lookupEmail := ""
+	if github.IsValidEmail(username) {
+		lookupEmail = username
+	}
+
+	cfg := github.DefaultConfig()
+	cfg.ShowInteresting = o.config.ShowInteresting
+	cfg.TimestampAnalysis = o.config.TimestampAnalysis
+	cfg.IncludePatches = o.config.IncludePatches
+	cfg.ScanVendored = o.config.ScanVendored
+	cfg.Since = o.config.Since
+	cfg.Until = o.config.Until
+	cfg.CacheDir = o.config.CacheDir
+	cfg.CacheTTL = o.config.CacheTTL
+	cfg.IncludeAnonymous = o.config.IncludeAnonymous
+	cfg.IncludeCommitters = o.config.IncludeCommitters
+	cfg.StrictOrgDomain = o.config.StrictOrgDomain
+	cfg.ExcludeMerges = o.config.ExcludeMerges
+	cfg.TopContributors = o.config.TopContributors
+	cfg.Redact = o.config.Redact
+	cfg.HashLength = o.config.HashLength
+	cfg.MaxNames = o.config.MaxNames
+	cfg.SortBy = o.config.SortBy
+	cfg.FilterDomains = o.config.FilterDomains
+	cfg.ExcludeDomains = o.config.ExcludeDomains
+	cfg.HideNoreply = o.config.HideNoreply
+	cfg.FindLinks = o.config.FindLinks
+
+	emails, repoName, err := local.Collect(ctx, o.config.LocalPath, o.config.CheckSecrets, &cfg)
+	if err != nil {
+		return err
+	}
+	if len(emails) == 0 {
+		return fmt.Errorf("no commits found in %s", o.config.LocalPath)
+	}
+	status.Green("[+] Read %s from %s", repoName, o.config.LocalPath)
 
+	display.Results(emails, o.config.ShowDetails, o.config.CheckSecrets, lookupEmail, username, nil, nil, o.config.ShowTargetOnly, false, &cfg, o.config.OutputFormat, o.dataWriter)
+	o.writeExports(emails, lookupEmail, username, nil, nil, false, &cfg)
+	o.writePatches(emails)
+	o.writeBaseline(emails)
 	return nil
 }
 
 func (o *Orchestrator) RunSpider(ctx context.Context) error {
 	username := o.config.Target
 	fmt.Println()
-	color.Blue("Target Username: %s", username)
+	status.Blue("Target Username: %s", username)
 	fmt.Println()
 
-	spiderCfg := spider.SpiderConfig{
-		Depth:        o.config.SpiderDepth,
-		MaxNodes:     o.config.MaxNodes,
-		MinRepos:     o.config.MinRepos,
-		MinFollowers: o.config.MinFollowers,
-		MaxWorkers:   5 * o.pool.Size(),
-		OutputFile:   o.config.SpiderOutput,
-	}
-
-	s := spider.NewSpider(o.pool, spiderCfg)
+	s := spider.NewSpider(o.pool, o.spiderConfig())
 	if err := s.Run(ctx, username); err != nil {
 		return err
 	}
 
-	o.pool.DisplayPoolRateLimit(ctx)
+	if !o.config.Quiet && ctx.Err() == nil {
+		o.pool.DisplayPoolRateLimit(ctx)
+	}
+	return nil
+}
+
+// spiderConfig maps the spider subcommand's flags onto the spider's config.
+// With no --workers, each pooled token gets 5 workers.
+func (o *Orchestrator) spiderConfig() spider.SpiderConfig {
+	if o.config.SpiderWorkers <= 0 {
+		o.config.SpiderWorkers = 5 * o.pool.Size()
+	}
+	return spider.SpiderConfig{
+		Depth:          o.config.SpiderDepth,
+		MaxNodes:       o.config.MaxNodes,
+		MinRepos:       o.config.MinRepos,
+		MinFollowers:   o.config.MinFollowers,
+		MaxWorkers:     o.config.SpiderWorkers,
+		OutputFile:     o.config.SpiderOutput,
+		OutputDir:      o.config.OutputDir,
+		CommitterPages: o.config.CommitterPages,
+		GraphFormat:    o.config.GraphFormat,
+		CollapseEdges:  o.config.CollapseEdges,
+		SkipBots:       o.config.SkipBots,
+		BotLogins:      o.config.BotLogins,
+		Resume:         o.config.Resume,
+	}
+}
+
+// isTokenOwner reports whether the target is the account the token belongs
+// to, the only case --include-private can list private repositories for.
+func (o *Orchestrator) isTokenOwner(ctx context.Context, user *gh.User, isOrg bool) bool {
+	if user == nil || isOrg {
+		status.Yellow("[!] --include-private only applies to your own user account; scanning public repositories")
+		return false
+	}
+	// every pooled token would need access to the private repositories
+	if o.pool.Size() > 1 {
+		status.Yellow("[!] --include-private needs a single token, not a token pool; scanning public repositories")
+		return false
+	}
+	me, _, err := o.pool.GetClient().Client.Users.Get(ctx, "")
+	if err != nil {
+		status.Yellow("[!] --include-private: could not identify the token's account (%v); scanning public repositories", err)
+		return false
+	}
+	if !strings.EqualFold(me.GetLogin(), user.GetLogin()) {
+		status.Yellow("[!] --include-private only applies to the token's own account (%s), not %s; scanning public repositories", me.GetLogin(), user.GetLogin())
+		return false
+	}
+	return true
+}
+
+// largeTargetRepos is the public repository count above which a run asks for
+// confirmation before crawling.
+const largeTargetRepos = 500
+
+// preflightLargeTarget warns before crawling a prolific account or big org.
+// Interactive runs are asked to confirm; scripted runs and structured output
+// need --yes to proceed. A --max-repos at or below largeTargetRepos skips
+// the check.
+func (o *Orchestrator) preflightLargeTarget(user *gh.User, isOrg bool) error {
+	if user == nil || o.config.AssumeYes || !o.config.Deep {
+		return nil
+	}
+	repos := user.GetPublicRepos()
+	crawled := repos
+	// --max-repos already bounds the crawl
+	if max := o.config.MaxRepos; max > 0 && max < crawled {
+		crawled = max
+	}
+	if crawled <= largeTargetRepos {
+		return nil
+	}
+
+	kind := "User"
+	if isOrg {
+		kind = "Organization"
+	}
+
+	// one commit listing per repo at the shared 5 requests/second limiter;
+	// --secrets adds a request for every commit on top of that
+	minutes := (crawled + 299) / 300
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, color.YellowString("[!] %s %s has %d public repositories. This run makes at least %d API requests (~%d+ minutes).",
+		kind, user.GetLogin(), repos, crawled, minutes))
+	fmt.Fprintln(os.Stderr, color.YellowString("    Consider dropping --deep, --repo-type owner, or more tokens via --token-file to speed it up."))
+
+	if (o.config.OutputFormat != "text" && o.config.OutputFile == "") || !term.IsTerminal(int(os.Stdin.Fd())) {
+		return fmt.Errorf("refusing to analyze %d repositories non-interactively; re-run with --yes to proceed", crawled)
+	}
+
+	fmt.Fprint(os.Stderr, color.YellowString("    Continue? [y/N] "))
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	answer = strings.TrimSpace(strings.ToLower(answer))
+	if answer != "y" && answer != "yes" {
+		return fmt.Errorf("aborted")
+	}
 	return nil
 }
 
+// reportStopped flags the results as partial when --timeout or Ctrl-C cut
+// the run short.
+func (o *Orchestrator) reportStopped(ctx context.Context) {
+	switch {
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		color.Yellow("[!] Results are partial: the --timeout of %s was reached", o.config.Timeout)
+	case errors.Is(ctx.Err(), context.Canceled):
+		color.Yellow("[!] Results are partial: the run was interrupted")
+	}
+}
+
+func (o *Orchestrator) reportBudget() {
+	budget := o.pool.Budget()
+	if budget.Exhausted() {
+		color.Yellow("[!] Results are partial: the --max-api-calls budget of %d requests was used up", budget.Limit())
+	} else {
+		status.Blue("API calls used: %d of %d", budget.Used(), budget.Limit())
+	}
+}
+
+// outputPath places a relative file name under --output-dir, if one was given.
+func (o *Orchestrator) outputPath(name string) string {
+	if o.config.OutputDir == "" || filepath.IsAbs(name) {
+		return name
+	}
+	return filepath.Join(o.config.OutputDir, name)
+}
+
 func (o *Orchestrator) outputEventList(list []string, filename, header, emoji string) error {
 	if len(list) == 0 {
 		fmt.Println("\n" + strings.Replace(header, ":", "", 1) + " - None found")
@@ -0,0 +1,312 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	appcli "github.com/gnomegl/gitslurp/v2/internal/cli"
+	"github.com/gnomegl/gitslurp/v2/internal/config"
+	"github.com/gnomegl/gitslurp/v2/internal/github"
+	"github.com/gnomegl/gitslurp/v2/internal/spider"
+	"github.com/gnomegl/gitslurp/v2/internal/status"
+	gh "github.com/google/go-github/v57/github"
+	"github.com/urfave/cli/v2"
+)
+
+func TestSpiderCommandConfig(t *testing.T) {
+	status.Quiet = true
+	defer func() { status.Quiet = false }()
+
+	tests := []struct {
+		name string
+		args []string
+		want spider.SpiderConfig
+	}{
+		{
+			name: "every flag",
+			args: []string{"--depth", "3", "--max-nodes", "250", "--min-repos", "2", "--min-followers", "10",
+				"--workers", "4", "--output", "graph.json", "--graph-format", "JSON", "--collapse-edges",
+				"--bot-logins", "release-robot,ci-user", "--committer-pages", "2", "octocat"},
+			want: spider.SpiderConfig{Depth: 3, MaxNodes: 250, MinRepos: 2, MinFollowers: 10, MaxWorkers: 4,
+				OutputFile: "graph.json", GraphFormat: "json", CollapseEdges: true, SkipBots: true,
+				BotLogins: []string{"release-robot", "ci-user"}, CommitterPages: 2},
+		},
+		{
+			name: "defaults",
+			args: []string{"--no-bots=false", "octocat"},
+			want: spider.SpiderConfig{Depth: 1, MaxNodes: 500, MaxWorkers: 5, GraphFormat: "gexf", CommitterPages: 3},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got spider.SpiderConfig
+			action := func(c *cli.Context) error {
+				appConfig, err := config.ParseSpiderCommand(c)
+				if err != nil {
+					return err
+				}
+				if appConfig.Target != "octocat" {
+					t.Errorf("target = %q, want octocat", appConfig.Target)
+				}
+				pool, err := github.NewClientPool(nil, nil)
+				if err != nil {
+					return err
+				}
+				got = NewOrchestrator(pool, appConfig, os.Stdout).spiderConfig()
+				return nil
+			}
+
+			app := appcli.NewApp(func(*cli.Context) error { return nil }, action)
+			if err := app.Run(append([]string{"gitslurp", "spider"}, tt.args...)); err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("spider config =\n %+v\nwant\n %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+// testPool returns a client pool whose API requests are served by handler.
+func testPool(t *testing.T, handler http.Handler) *github.ClientPool {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	pool, err := github.NewClientPool(nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, mc := range pool.AllClients() {
+		mc.Client.BaseURL, _ = url.Parse(server.URL + "/")
+	}
+	return pool
+}
+
+func TestQuickModeReadsEvents(t *testing.T) {
+	status.Quiet = true
+	defer func() { status.Quiet = false }()
+
+	var mu sync.Mutex
+	var requested []string
+	pool := testPool(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requested = append(requested, r.URL.Path)
+		mu.Unlock()
+		if r.URL.Path == "/users/octo/events" {
+			fmt.Fprint(w, `[{"type": "PushEvent", "repo": {"name": "octo/tool"}, "created_at": "2024-03-04T10:00:00Z",
+				"payload": {"commits": [{"sha": "a1b2c3", "message": "fix", "author": {"name": "Octo", "email": "octo@example.org"}}]}}]`)
+			return
+		}
+		fmt.Fprint(w, "[]")
+	}))
+	repos := []*gh.Repository{{Name: gh.String("tool"), FullName: gh.String("octo/tool"), Owner: &gh.User{Login: gh.String("octo")}}}
+
+	tests := []struct {
+		name       string
+		quick      bool
+		isOrg      bool
+		wantEvents bool
+	}{
+		{"--quick reads the event stream", true, false, true},
+		{"default scan lists repository commits", false, false, false},
+		{"organizations ignore --quick", true, true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requested = nil
+			o := NewOrchestrator(pool, &config.AppConfig{QuickMode: tt.quick}, os.Stdout)
+			cfg := github.DefaultConfig()
+			cfg.QuickMode = tt.quick
+
+			emails := o.processRepos(context.Background(), "octo", tt.isOrg, repos, &cfg, nil, nil)
+
+			events, commits := false, false
+			for _, path := range requested {
+				events = events || path == "/users/octo/events"
+				commits = commits || path == "/repos/octo/tool/commits"
+			}
+			if events != tt.wantEvents || commits == tt.wantEvents {
+				t.Errorf("requested %v; want the event stream %v, repository commits %v", requested, tt.wantEvents, !tt.wantEvents)
+			}
+			if tt.wantEvents {
+				details := emails["octo@example.org"]
+				if details == nil || details.CommitCount != 1 || details.Commits["octo/tool"][0].Hash != "a1b2c3" {
+					t.Errorf("push event commit not collected: %v", emails)
+				}
+			}
+		})
+	}
+}
+
+func TestResolveTargetByID(t *testing.T) {
+	status.Quiet = true
+	defer func() { status.Quiet = false }()
+
+	var requested []string
+	pool := testPool(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = append(requested, r.URL.Path)
+		if r.URL.Path == "/user/12345" {
+			fmt.Fprint(w, `{"login": "octo-renamed", "id": 12345}`)
+			return
+		}
+		http.NotFound(w, r)
+	}))
+
+	tests := []struct {
+		name      string
+		cfg       config.AppConfig
+		want      string
+		wantErr   bool
+		requested []string
+	}{
+		{"--by-id", config.AppConfig{ByID: 12345}, "octo-renamed", false, []string{"/user/12345"}},
+		{"--by-id unknown", config.AppConfig{ByID: 999}, "", true, []string{"/user/999"}},
+		// the ID in a noreply address follows renames
+		{"noreply with ID", config.AppConfig{Target: "12345+octo@users.noreply.github.com"}, "octo-renamed", false, []string{"/user/12345"}},
+		{"noreply with stale ID", config.AppConfig{Target: "999+octo@users.noreply.github.com"}, "octo", false, []string{"/user/999"}},
+		{"noreply without ID", config.AppConfig{Target: "octo@users.noreply.github.com"}, "octo", false, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requested = nil
+			cfg := tt.cfg
+			got, _, err := NewOrchestrator(pool, &cfg, os.Stdout).resolveTarget(context.Background())
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("resolveTarget error = %v, want error %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("resolveTarget = %q, want %q", got, tt.want)
+			}
+			if !reflect.DeepEqual(requested, tt.requested) {
+				t.Errorf("requested %v, want %v", requested, tt.requested)
+			}
+		})
+	}
+}
+
+func TestIsTokenOwner(t *testing.T) {
+	status.Quiet = true
+	defer func() { status.Quiet = false }()
+
+	pool := testPool(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/user" {
+			fmt.Fprint(w, `{"login": "Octo"}`)
+			return
+		}
+		http.NotFound(w, r)
+	}))
+
+	tests := []struct {
+		name  string
+		user  *gh.User
+		isOrg bool
+		want  bool
+	}{
+		{"own account", &gh.User{Login: gh.String("octo")}, false, true},
+		{"another user", &gh.User{Login: gh.String("someone")}, false, false},
+		{"organization", &gh.User{Login: gh.String("octo")}, true, false},
+		{"unknown target", nil, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := NewOrchestrator(pool, &config.AppConfig{IncludePrivate: true}, os.Stdout)
+			if got := o.isTokenOwner(context.Background(), tt.user, tt.isOrg); got != tt.want {
+				t.Errorf("isTokenOwner = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStreamingJSONAcrossRepos(t *testing.T) {
+	status.Quiet = true
+	defer func() { status.Quiet = false }()
+
+	const repoCount = 6
+	pool := testPool(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// /repos/octo/<name>/commits
+		parts := strings.Split(r.URL.Path, "/")
+		if len(parts) != 5 || parts[4] != "commits" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, "[")
+		for i := 0; i < 20; i++ {
+			if i > 0 {
+				fmt.Fprint(w, ",")
+			}
+			fmt.Fprintf(w, `{"sha": "%s-%d", "commit": {"message": "change %d", "author": {"name": "Octo %d", "email": "octo@example.org", "date": "2024-03-04T10:00:00Z"}}}`, parts[3], i, i, i)
+		}
+		fmt.Fprint(w, "]")
+	}))
+
+	var repos []*gh.Repository
+	for i := 0; i < repoCount; i++ {
+		name := fmt.Sprintf("repo%d", i)
+		repos = append(repos, &gh.Repository{Name: gh.String(name), FullName: gh.String("octo/" + name), Owner: &gh.User{Login: gh.String("octo")}})
+	}
+
+	tests := []struct {
+		name        string
+		concurrency int
+	}{
+		{"one repository at a time", 1},
+		{"repositories in parallel", 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := os.CreateTemp(t.TempDir(), "stream-*.ndjson")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer out.Close()
+
+			o := NewOrchestrator(pool, &config.AppConfig{Deep: true, NoGists: true}, out)
+			cfg := github.DefaultConfig()
+			cfg.RepoConcurrency = tt.concurrency
+
+			emails, err := o.runStreamingJSON(context.Background(), repos, nil, "octo", "", nil, nil, false, map[string]bool{"octo": true}, &cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := emails["octo@example.org"]; got == nil || got.CommitCount != 20*repoCount {
+				t.Fatalf("aggregated %v, want %d commits", got, 20*repoCount)
+			}
+
+			data, err := os.ReadFile(out.Name())
+			if err != nil {
+				t.Fatal(err)
+			}
+			records := 0
+			for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+				var record struct {
+					Email       string `json:"email"`
+					CommitCount int    `json:"commit_count"`
+				}
+				if err := json.Unmarshal([]byte(line), &record); err != nil {
+					t.Fatalf("bad record %q: %v", line, err)
+				}
+				if record.Email == "octo@example.org" {
+					records++
+					if record.CommitCount < 20 {
+						t.Errorf("streamed %d commits, want at least the first repository's 20", record.CommitCount)
+					}
+				}
+			}
+			if records != 1 {
+				t.Errorf("octo@example.org streamed %d times, want once", records)
+			}
+		})
+	}
+}
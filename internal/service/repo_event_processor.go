@@ -4,8 +4,8 @@ import (
 	"context"
 	"sort"
 
-	"github.com/fatih/color"
 	"github.com/gnomegl/gitslurp/v2/internal/github"
+	"github.com/gnomegl/gitslurp/v2/internal/status"
 	gh "github.com/google/go-github/v57/github"
 )
 
@@ -49,14 +49,14 @@ func (p *RepoEventProcessor) Process(ctx context.Context, repos []*gh.Repository
 
 	if showForkers {
 		forkersList := sortedKeys(forkers)
-		if err := orchestrator.outputEventList(forkersList, p.target+"_forkers.txt", "Repository Forkers:", ""); err != nil {
+		if err := orchestrator.outputEventList(forkersList, orchestrator.outputPath(p.target+"_forkers.txt"), "Repository Forkers:", ""); err != nil {
 			return err
 		}
 	}
 
 	if showStargazers {
 		stargazersList := sortedKeys(stargazers)
-		if err := orchestrator.outputEventList(stargazersList, p.target+"_stargazers.txt", "Repository Stargazers:", ""); err != nil {
+		if err := orchestrator.outputEventList(stargazersList, orchestrator.outputPath(p.target+"_stargazers.txt"), "Repository Stargazers:", ""); err != nil {
 			return err
 		}
 	}
@@ -67,7 +67,7 @@ func (p *RepoEventProcessor) Process(ctx context.Context, repos []*gh.Repository
 func (p *RepoEventProcessor) collectStargazers(ctx context.Context, client *gh.Client, repo *gh.Repository, stargazers map[string]struct{}, opts *gh.ListOptions) error {
 	stargazerList, _, err := client.Activity.ListStargazers(ctx, repo.GetOwner().GetLogin(), repo.GetName(), opts)
 	if err != nil {
-		color.Yellow("[!]  Warning: Could not fetch stargazers for %s: %v", repo.GetFullName(), err)
+		status.Yellow("[!]  Warning: Could not fetch stargazers for %s: %v", repo.GetFullName(), err)
 		return err
 	}
 	for _, stargazer := range stargazerList {
@@ -81,7 +81,7 @@ func (p *RepoEventProcessor) collectForkers(ctx context.Context, client *gh.Clie
 		ListOptions: *opts,
 	})
 	if err != nil {
-		color.Yellow("[!]  Warning: Could not fetch forks for %s: %v", repo.GetFullName(), err)
+		status.Yellow("[!]  Warning: Could not fetch forks for %s: %v", repo.GetFullName(), err)
 		return err
 	}
 	for _, fork := range forks {
@@ -0,0 +1,73 @@
+package spider
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// checkpoint is the spider's state after a completed depth: the graph so far
+// and the users to expand at the next depth.
+type checkpoint struct {
+	Seed     string   `json:"seed"`
+	Depth    int      `json:"depth"` // depths completed
+	Frontier []string `json:"frontier"`
+	Nodes    []*Node  `json:"nodes"`
+	Edges    []*Edge  `json:"edges"`
+}
+
+// checkpointPath puts the checkpoint next to the graph output, e.g.
+// torvalds_graph.checkpoint.json for torvalds_graph.gexf.
+func checkpointPath(outputPath string) string {
+	return strings.TrimSuffix(outputPath, filepath.Ext(outputPath)) + ".checkpoint.json"
+}
+
+func (s *Spider) saveCheckpoint(path, seed string, depth int, frontier []string) error {
+	s.graph.mu.RLock()
+	cp := checkpoint{
+		Seed:     seed,
+		Depth:    depth,
+		Frontier: frontier,
+		Nodes:    sortedNodes(s.graph),
+		Edges:    sortedEdges(s.graph),
+	}
+	data, err := json.Marshal(cp)
+	s.graph.mu.RUnlock()
+	if err != nil {
+		return err
+	}
+
+	// write then rename so an interrupted save keeps the previous checkpoint
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// loadCheckpoint returns the os.ReadFile error unwrapped, so callers can tell
+// a missing checkpoint apart.
+func loadCheckpoint(path string) (*checkpoint, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var cp checkpoint
+	if err := json.Unmarshal(data, &cp); err != nil {
+		return nil, fmt.Errorf("invalid checkpoint %s: %v", path, err)
+	}
+	return &cp, nil
+}
+
+func (cp *checkpoint) graph() *Graph {
+	g := NewGraph()
+	for _, node := range cp.Nodes {
+		g.Nodes[node.Login] = node
+	}
+	for _, edge := range cp.Edges {
+		g.Edges[edgeKey(edge.Source, edge.Target, edge.Type)] = edge
+	}
+	return g
+}
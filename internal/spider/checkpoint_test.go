@@ -0,0 +1,58 @@
+package spider
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestCheckpointRoundTrip(t *testing.T) {
+	s := &Spider{graph: NewGraph()}
+	s.graph.AddNode(&Node{Login: "seed", Name: "Seed User", Followers: 10, Depth: 0})
+	s.graph.AddNode(&Node{Login: "friend", Company: "Acme", Location: "Berlin", Depth: 1})
+	s.graph.AddNode(&Node{Login: "peer", Bio: "hi", PublicRepos: 3, Depth: 1})
+	s.graph.AddEdge("seed", "friend", "follows", "", 1)
+	s.graph.AddEdge("friend", "seed", "commits", "seed/tool", 3)
+	s.graph.AddEdge("friend", "seed", "commits", "seed/tool", 2)
+	s.graph.AddEdge("peer", "seed", "stars", "seed/tool", 0)
+
+	path := checkpointPath(filepath.Join(t.TempDir(), "seed_graph.gexf"))
+	if filepath.Base(path) != "seed_graph.checkpoint.json" {
+		t.Errorf("checkpointPath = %s", path)
+	}
+	if err := s.saveCheckpoint(path, "seed", 1, []string{"friend", "peer"}); err != nil {
+		t.Fatal(err)
+	}
+
+	cp, err := loadCheckpoint(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cp.Seed != "seed" || cp.Depth != 1 || !reflect.DeepEqual(cp.Frontier, []string{"friend", "peer"}) {
+		t.Errorf("checkpoint = seed %q, depth %d, frontier %v", cp.Seed, cp.Depth, cp.Frontier)
+	}
+
+	g := cp.graph()
+	if !reflect.DeepEqual(g.Nodes, s.graph.Nodes) {
+		t.Errorf("nodes after reload:\n got %v\nwant %v", g.Nodes, s.graph.Nodes)
+	}
+	if !reflect.DeepEqual(g.Edges, s.graph.Edges) {
+		t.Errorf("edges after reload:\n got %v\nwant %v", g.Edges, s.graph.Edges)
+	}
+	commits := g.Edges[edgeKey("friend", "seed", "commits")]
+	if commits == nil || commits.Weight != 5 || commits.Repo != "seed/tool" {
+		t.Errorf("commits edge = %+v, want weight 5 in seed/tool", commits)
+	}
+
+	// edges added after reload merge into the restored ones by key
+	g.AddEdge("seed", "friend", "follows", "", 1)
+	if n := len(g.Edges); n != 3 {
+		t.Errorf("%d edges after re-adding a restored one, want 3", n)
+	}
+}
+
+func TestLoadCheckpointMissing(t *testing.T) {
+	if _, err := loadCheckpoint(filepath.Join(t.TempDir(), "none.checkpoint.json")); err == nil {
+		t.Error("loadCheckpoint of a missing file succeeded")
+	}
+}
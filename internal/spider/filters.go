@@ -1,9 +1,39 @@
 package spider
 
+import "strings"
+
+// defaultBotLogins are automation accounts that do not carry the "[bot]"
+// suffix GitHub App accounts have.
+var defaultBotLogins = []string{
+	"dependabot", "dependabot-preview", "renovate-bot", "renovatebot", "greenkeeperio-bot",
+	"snyk-bot", "codecov-io", "imgbot", "allcontributors", "pre-commit-ci", "github-actions", "web-flow",
+}
+
 type Filters struct {
 	MinRepos     int
 	MinFollowers int
 	MaxNodes     int
+	SkipBots     bool
+	BotLogins    map[string]bool // lowercased denylist, on top of the "[bot]" suffix
+}
+
+// NewBotDenylist lowercases extra logins into a denylist that also holds the
+// built-in bot accounts.
+func NewBotDenylist(extra []string) map[string]bool {
+	denylist := make(map[string]bool, len(defaultBotLogins)+len(extra))
+	for _, login := range append(append([]string{}, defaultBotLogins...), extra...) {
+		if login = strings.ToLower(strings.TrimSpace(login)); login != "" {
+			denylist[login] = true
+		}
+	}
+	return denylist
+}
+
+// IsBot reports whether login is a GitHub App account ("name[bot]") or on the
+// denylist.
+func (f *Filters) IsBot(login string) bool {
+	login = strings.ToLower(login)
+	return strings.HasSuffix(login, "[bot]") || f.BotLogins[login]
 }
 
 func (f *Filters) PassesUserFilter(followers, publicRepos int) bool {
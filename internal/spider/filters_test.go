@@ -0,0 +1,34 @@
+package spider
+
+import "testing"
+
+func TestIsBot(t *testing.T) {
+	f := &Filters{SkipBots: true, BotLogins: NewBotDenylist([]string{" Release-Robot ", ""})}
+	tests := []struct {
+		login string
+		want  bool
+	}{
+		{"github-actions[bot]", true},
+		{"renovate[bot]", true},
+		{"Dependabot[BOT]", true},
+		{"dependabot", true},
+		{"web-flow", true},
+		{"release-robot", true},
+		{"RELEASE-ROBOT", true},
+		{"octocat", false},
+		{"botanist", false},
+		{"bot-fan", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := f.IsBot(tt.login); got != tt.want {
+			t.Errorf("IsBot(%q) = %v, want %v", tt.login, got, tt.want)
+		}
+	}
+
+	// without a denylist only the [bot] suffix is recognised
+	bare := &Filters{}
+	if !bare.IsBot("app[bot]") || bare.IsBot("dependabot") {
+		t.Error("IsBot without a denylist should only match the [bot] suffix")
+	}
+}
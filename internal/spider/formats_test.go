@@ -0,0 +1,83 @@
+package spider
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"testing"
+)
+
+func testGraph() *Graph {
+	g := NewGraph()
+	g.AddNode(&Node{Login: "seed", Name: "Seed & Co", Followers: 10, Depth: 0})
+	g.AddNode(&Node{Login: "friend", Company: "Acme", Location: "Berlin", Depth: 1})
+	g.AddNode(&Node{Login: "peer", Depth: 1})
+	g.AddEdge("seed", "friend", "follows", "", 1)
+	g.AddEdge("friend", "seed", "commits", "seed/tool", 3)
+	g.AddEdge("peer", "seed", "stars", "seed/tool", 0)
+	g.AddEdge("peer", "friend", "follows", "", 1)
+	return g
+}
+
+// countXML counts the node and edge elements of an XML graph document.
+func countXML(t *testing.T, data []byte) (nodes, edges int) {
+	t.Helper()
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		tok, err := decoder.Token()
+		if err == io.EOF {
+			return nodes, edges
+		}
+		if err != nil {
+			t.Fatalf("invalid XML: %v", err)
+		}
+		if start, ok := tok.(xml.StartElement); ok {
+			switch start.Name.Local {
+			case "node":
+				nodes++
+			case "edge":
+				edges++
+			}
+		}
+	}
+}
+
+func TestGraphFormats(t *testing.T) {
+	g := testGraph()
+	tests := []struct {
+		format string
+		write  func(io.Writer, *Graph, string) error
+		count  func(*testing.T, []byte) (int, int)
+	}{
+		{"gexf", WriteGEXF, countXML},
+		{"graphml", WriteGraphML, countXML},
+		{"json", WriteJSON, func(t *testing.T, data []byte) (int, int) {
+			var doc jsonGraph
+			if err := json.Unmarshal(data, &doc); err != nil {
+				t.Fatalf("invalid JSON: %v", err)
+			}
+			if doc.Seed != "seed" {
+				t.Errorf("seed = %q", doc.Seed)
+			}
+			for _, node := range doc.Nodes {
+				if node.Login == "friend" && (node.Company != "Acme" || node.Location != "Berlin" || node.Depth != 1) {
+					t.Errorf("friend node lost attributes: %+v", node)
+				}
+			}
+			return len(doc.Nodes), len(doc.Edges)
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := tt.write(&buf, g, "seed"); err != nil {
+				t.Fatal(err)
+			}
+			nodes, edges := tt.count(t, buf.Bytes())
+			if nodes != g.NodeCount() || edges != g.EdgeCount() {
+				t.Errorf("%d nodes and %d edges written, want %d and %d", nodes, edges, g.NodeCount(), g.EdgeCount())
+			}
+		})
+	}
+}
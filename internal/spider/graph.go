@@ -2,28 +2,30 @@ package spider
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 	"sync"
 )
 
 type Node struct {
-	Login       string
-	Name        string
-	AvatarURL   string
-	Followers   int
-	Following   int
-	PublicRepos int
-	Company     string
-	Location    string
-	Bio         string
-	Depth       int
+	Login       string `json:"login"`
+	Name        string `json:"name,omitempty"`
+	AvatarURL   string `json:"avatar_url,omitempty"`
+	Followers   int    `json:"followers"`
+	Following   int    `json:"following"`
+	PublicRepos int    `json:"public_repos"`
+	Company     string `json:"company,omitempty"`
+	Location    string `json:"location,omitempty"`
+	Bio         string `json:"bio,omitempty"`
+	Depth       int    `json:"depth"`
 }
 
 type Edge struct {
-	Source string
-	Target string
-	Type   string
-	Weight int
-	Repo   string
+	Source string `json:"source"`
+	Target string `json:"target"`
+	Type   string `json:"type"`
+	Weight int    `json:"weight"`
+	Repo   string `json:"repo,omitempty"`
 }
 
 func edgeKey(source, target, edgeType string) string {
@@ -66,19 +68,24 @@ func (g *Graph) NodeCount() int {
 	return len(g.Nodes)
 }
 
-func (g *Graph) AddEdge(source, target, edgeType string, repo string) {
+// AddEdge adds weight to the (source, target, type) edge, creating it if
+// needed. A weight below 1 counts as 1.
+func (g *Graph) AddEdge(source, target, edgeType string, repo string, weight int) {
+	if weight < 1 {
+		weight = 1
+	}
 	g.mu.Lock()
 	defer g.mu.Unlock()
 	key := edgeKey(source, target, edgeType)
 	if existing, ok := g.Edges[key]; ok {
-		existing.Weight++
+		existing.Weight += weight
 		return
 	}
 	g.Edges[key] = &Edge{
 		Source: source,
 		Target: target,
 		Type:   edgeType,
-		Weight: 1,
+		Weight: weight,
 		Repo:   repo,
 	}
 }
@@ -88,3 +95,43 @@ func (g *Graph) EdgeCount() int {
 	defer g.mu.RUnlock()
 	return len(g.Edges)
 }
+
+// Collapsed returns a copy of the graph with every edge between the same
+// source and target merged into one, weighted by the sum of their weights.
+// The merged edge's type lists the original types, e.g. "commit+follower",
+// and it keeps the repo only when all merged edges share it.
+func (g *Graph) Collapsed() *Graph {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	out := NewGraph()
+	for login, node := range g.Nodes {
+		out.Nodes[login] = node
+	}
+
+	types := make(map[string]map[string]bool)
+	for _, edge := range g.Edges {
+		key := edgeKey(edge.Source, edge.Target, "")
+		merged, ok := out.Edges[key]
+		if !ok {
+			merged = &Edge{Source: edge.Source, Target: edge.Target, Repo: edge.Repo}
+			out.Edges[key] = merged
+			types[key] = make(map[string]bool)
+		}
+		merged.Weight += edge.Weight
+		if merged.Repo != edge.Repo {
+			merged.Repo = ""
+		}
+		types[key][edge.Type] = true
+	}
+
+	for key, edge := range out.Edges {
+		names := make([]string, 0, len(types[key]))
+		for t := range types[key] {
+			names = append(names, t)
+		}
+		sort.Strings(names)
+		edge.Type = strings.Join(names, "+")
+	}
+	return out
+}
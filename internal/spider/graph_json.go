@@ -0,0 +1,53 @@
+package spider
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+)
+
+type jsonGraph struct {
+	Seed  string  `json:"seed"`
+	Nodes []*Node `json:"nodes"`
+	Edges []*Edge `json:"edges"`
+}
+
+// WriteJSON writes the graph's nodes and edges with every attribute, nodes
+// sorted by login and edges by source, target and type.
+func WriteJSON(w io.Writer, graph *Graph, seedUser string) error {
+	graph.mu.RLock()
+	defer graph.mu.RUnlock()
+
+	doc := jsonGraph{
+		Seed:  seedUser,
+		Nodes: sortedNodes(graph),
+		Edges: sortedEdges(graph),
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(doc)
+}
+
+func sortedNodes(graph *Graph) []*Node {
+	nodes := make([]*Node, 0, len(graph.Nodes))
+	for _, node := range graph.Nodes {
+		nodes = append(nodes, node)
+	}
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].Login < nodes[j].Login })
+	return nodes
+}
+
+func sortedEdges(graph *Graph) []*Edge {
+	keys := make([]string, 0, len(graph.Edges))
+	for key := range graph.Edges {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	edges := make([]*Edge, 0, len(keys))
+	for _, key := range keys {
+		edges = append(edges, graph.Edges[key])
+	}
+	return edges
+}
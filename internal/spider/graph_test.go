@@ -0,0 +1,82 @@
+package spider
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestAddEdgeWeights(t *testing.T) {
+	g := NewGraph()
+	g.AddEdge("a", "b", "follower", "", 1)
+	g.AddEdge("a", "b", "follower", "", 1)
+	g.AddEdge("a", "b", "commit", "a/tool", 4)
+	g.AddEdge("a", "b", "commit", "a/tool", 0)
+	g.AddEdge("b", "a", "follower", "", 1)
+
+	tests := []struct {
+		source, target, edgeType string
+		want                     int
+	}{
+		{"a", "b", "follower", 2},
+		{"a", "b", "commit", 5}, // a zero weight still counts once
+		{"b", "a", "follower", 1},
+	}
+	if g.EdgeCount() != len(tests) {
+		t.Errorf("%d edges, want %d typed edges", g.EdgeCount(), len(tests))
+	}
+	for _, tt := range tests {
+		edge := g.Edges[edgeKey(tt.source, tt.target, tt.edgeType)]
+		if edge == nil || edge.Weight != tt.want {
+			t.Errorf("%s -%s-> %s = %+v, want weight %d", tt.source, tt.edgeType, tt.target, edge, tt.want)
+		}
+	}
+}
+
+func TestCollapsed(t *testing.T) {
+	g := NewGraph()
+	g.AddNode(&Node{Login: "a"})
+	g.AddNode(&Node{Login: "b"})
+	g.AddEdge("a", "b", "follower", "", 1)
+	g.AddEdge("a", "b", "commit", "a/tool", 4)
+	g.AddEdge("a", "b", "stargazer", "a/tool", 1)
+	g.AddEdge("b", "a", "commit", "a/tool", 2)
+	g.AddEdge("b", "a", "commit", "a/lib", 3)
+
+	c := g.Collapsed()
+	tests := []struct {
+		source, target string
+		weight         int
+		edgeType, repo string
+	}{
+		{"a", "b", 6, "commit+follower+stargazer", ""},
+		{"b", "a", 5, "commit", "a/tool"},
+	}
+	if c.EdgeCount() != len(tests) || c.NodeCount() != 2 {
+		t.Errorf("collapsed graph has %d nodes and %d edges, want 2 and %d", c.NodeCount(), c.EdgeCount(), len(tests))
+	}
+	for _, tt := range tests {
+		edge := c.Edges[edgeKey(tt.source, tt.target, "")]
+		if edge == nil {
+			t.Errorf("no collapsed edge %s -> %s", tt.source, tt.target)
+			continue
+		}
+		if edge.Weight != tt.weight || edge.Type != tt.edgeType || edge.Repo != tt.repo {
+			t.Errorf("%s -> %s = weight %d, type %q, repo %q; want %d, %q, %q",
+				tt.source, tt.target, edge.Weight, edge.Type, edge.Repo, tt.weight, tt.edgeType, tt.repo)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := WriteGEXF(&buf, c, "a"); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), `weight="6"`) {
+		t.Error("collapsed weight missing from the GEXF edge")
+	}
+
+	// the typed graph is left as it was
+	if g.EdgeCount() != 4 {
+		t.Errorf("original graph has %d edges after collapsing, want 4", g.EdgeCount())
+	}
+}
@@ -0,0 +1,122 @@
+package spider
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+)
+
+type graphmlFile struct {
+	XMLName xml.Name     `xml:"graphml"`
+	XMLNS   string       `xml:"xmlns,attr"`
+	Keys    []graphmlKey `xml:"key"`
+	Graph   graphmlGraph `xml:"graph"`
+}
+
+type graphmlKey struct {
+	ID       string `xml:"id,attr"`
+	For      string `xml:"for,attr"`
+	AttrName string `xml:"attr.name,attr"`
+	AttrType string `xml:"attr.type,attr"`
+}
+
+type graphmlGraph struct {
+	ID          string        `xml:"id,attr"`
+	EdgeDefault string        `xml:"edgedefault,attr"`
+	Nodes       []graphmlNode `xml:"node"`
+	Edges       []graphmlEdge `xml:"edge"`
+}
+
+type graphmlNode struct {
+	ID   string        `xml:"id,attr"`
+	Data []graphmlData `xml:"data"`
+}
+
+type graphmlEdge struct {
+	ID     string        `xml:"id,attr"`
+	Source string        `xml:"source,attr"`
+	Target string        `xml:"target,attr"`
+	Data   []graphmlData `xml:"data"`
+}
+
+type graphmlData struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+var graphmlKeys = []graphmlKey{
+	{ID: "name", For: "node", AttrName: "name", AttrType: "string"},
+	{ID: "followers", For: "node", AttrName: "followers", AttrType: "int"},
+	{ID: "following", For: "node", AttrName: "following", AttrType: "int"},
+	{ID: "public_repos", For: "node", AttrName: "public_repos", AttrType: "int"},
+	{ID: "company", For: "node", AttrName: "company", AttrType: "string"},
+	{ID: "location", For: "node", AttrName: "location", AttrType: "string"},
+	{ID: "depth", For: "node", AttrName: "depth", AttrType: "int"},
+	{ID: "type", For: "edge", AttrName: "type", AttrType: "string"},
+	{ID: "weight", For: "edge", AttrName: "weight", AttrType: "int"},
+	{ID: "repo", For: "edge", AttrName: "repo", AttrType: "string"},
+}
+
+// WriteGraphML writes the graph as GraphML for tools such as yEd, Cytoscape
+// and NetworkX. Empty string attributes are left out.
+func WriteGraphML(w io.Writer, graph *Graph, seedUser string) error {
+	graph.mu.RLock()
+	defer graph.mu.RUnlock()
+
+	var nodes []graphmlNode
+	for _, node := range sortedNodes(graph) {
+		nodes = append(nodes, graphmlNode{
+			ID: node.Login,
+			Data: graphmlValues(
+				"name", node.Name,
+				"followers", fmt.Sprintf("%d", node.Followers),
+				"following", fmt.Sprintf("%d", node.Following),
+				"public_repos", fmt.Sprintf("%d", node.PublicRepos),
+				"company", node.Company,
+				"location", node.Location,
+				"depth", fmt.Sprintf("%d", node.Depth),
+			),
+		})
+	}
+
+	var edges []graphmlEdge
+	for i, edge := range sortedEdges(graph) {
+		edges = append(edges, graphmlEdge{
+			ID:     fmt.Sprintf("e%d", i),
+			Source: edge.Source,
+			Target: edge.Target,
+			Data: graphmlValues(
+				"type", edge.Type,
+				"weight", fmt.Sprintf("%d", edge.Weight),
+				"repo", edge.Repo,
+			),
+		})
+	}
+
+	doc := graphmlFile{
+		XMLNS: "http://graphml.graphdrawing.org/xmlns",
+		Keys:  graphmlKeys,
+		Graph: graphmlGraph{
+			ID:          seedUser,
+			EdgeDefault: "directed",
+			Nodes:       nodes,
+			Edges:       edges,
+		},
+	}
+
+	w.Write([]byte(xml.Header))
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	return encoder.Encode(doc)
+}
+
+// graphmlValues takes key, value pairs.
+func graphmlValues(pairs ...string) []graphmlData {
+	var data []graphmlData
+	for i := 0; i+1 < len(pairs); i += 2 {
+		if pairs[i+1] != "" {
+			data = append(data, graphmlData{Key: pairs[i], Value: pairs[i+1]})
+		}
+	}
+	return data
+}
@@ -8,18 +8,29 @@ import (
 	gh "github.com/google/go-github/v57/github"
 )
 
+// RelationFetcher enumerates a user's relationships. Account-level lists
+// (followers, following, starred) are paginated fully; per-repo lists
+// (stargazers, watchers, committers) stop after repoPages pages so a handful
+// of very active repositories can't exhaust the rate limit. repoPages <= 0
+// removes the cap.
 type RelationFetcher struct {
-	pool *github.ClientPool
+	pool      *github.ClientPool
+	repoPages int
 }
 
-func NewRelationFetcher(pool *github.ClientPool) *RelationFetcher {
-	return &RelationFetcher{pool: pool}
+func NewRelationFetcher(pool *github.ClientPool, repoPages int) *RelationFetcher {
+	return &RelationFetcher{pool: pool, repoPages: repoPages}
+}
+
+func (rf *RelationFetcher) repoPageLimitReached(page int) bool {
+	return rf.repoPages > 0 && page >= rf.repoPages
 }
 
 type DiscoveredRelation struct {
 	Login string
 	Type  string
 	Repo  string
+	Count int // interactions behind the relation, e.g. commits; 0 counts as 1
 }
 
 func (rf *RelationFetcher) FetchFollowing(ctx context.Context, login string) ([]DiscoveredRelation, error) {
@@ -114,22 +125,28 @@ func (rf *RelationFetcher) FetchRepoStargazers(ctx context.Context, owner, repo
 	mc := rf.pool.GetClient()
 	opts := &gh.ListOptions{PerPage: 100}
 
-	stargazers, resp, err := mc.Client.Activity.ListStargazers(ctx, owner, repo, opts)
-	if resp != nil {
-		mc.UpdateRateLimit(resp.Rate.Remaining, resp.Rate.Reset.Time)
-	}
-	if err != nil {
-		return relations, err
-	}
-	for _, s := range stargazers {
-		login := s.User.GetLogin()
-		if login != "" && login != owner {
-			relations = append(relations, DiscoveredRelation{
-				Login: login,
-				Type:  "stargazer",
-				Repo:  owner + "/" + repo,
-			})
+	for page := 1; ; page++ {
+		stargazers, resp, err := mc.Client.Activity.ListStargazers(ctx, owner, repo, opts)
+		if resp != nil {
+			mc.UpdateRateLimit(resp.Rate.Remaining, resp.Rate.Reset.Time)
+		}
+		if err != nil {
+			return relations, err
+		}
+		for _, s := range stargazers {
+			login := s.User.GetLogin()
+			if login != "" && login != owner {
+				relations = append(relations, DiscoveredRelation{
+					Login: login,
+					Type:  "stargazer",
+					Repo:  owner + "/" + repo,
+				})
+			}
+		}
+		if resp.NextPage == 0 || rf.repoPageLimitReached(page) {
+			break
 		}
+		opts.Page = resp.NextPage
 	}
 	return relations, nil
 }
@@ -139,22 +156,28 @@ func (rf *RelationFetcher) FetchRepoWatchers(ctx context.Context, owner, repo st
 	mc := rf.pool.GetClient()
 	opts := &gh.ListOptions{PerPage: 100}
 
-	watchers, resp, err := mc.Client.Activity.ListWatchers(ctx, owner, repo, opts)
-	if resp != nil {
-		mc.UpdateRateLimit(resp.Rate.Remaining, resp.Rate.Reset.Time)
-	}
-	if err != nil {
-		return relations, err
-	}
-	for _, w := range watchers {
-		login := w.GetLogin()
-		if login != "" && login != owner {
-			relations = append(relations, DiscoveredRelation{
-				Login: login,
-				Type:  "watcher",
-				Repo:  owner + "/" + repo,
-			})
+	for page := 1; ; page++ {
+		watchers, resp, err := mc.Client.Activity.ListWatchers(ctx, owner, repo, opts)
+		if resp != nil {
+			mc.UpdateRateLimit(resp.Rate.Remaining, resp.Rate.Reset.Time)
+		}
+		if err != nil {
+			return relations, err
+		}
+		for _, w := range watchers {
+			login := w.GetLogin()
+			if login != "" && login != owner {
+				relations = append(relations, DiscoveredRelation{
+					Login: login,
+					Type:  "watcher",
+					Repo:  owner + "/" + repo,
+				})
+			}
+		}
+		if resp.NextPage == 0 || rf.repoPageLimitReached(page) {
+			break
 		}
+		opts.Page = resp.NextPage
 	}
 	return relations, nil
 }
@@ -166,27 +189,40 @@ func (rf *RelationFetcher) FetchRepoCommitters(ctx context.Context, owner, repo
 		ListOptions: gh.ListOptions{PerPage: 100},
 	}
 
-	commits, resp, err := mc.Client.Repositories.ListCommits(ctx, owner, repo, opts)
-	if resp != nil {
-		mc.UpdateRateLimit(resp.Rate.Remaining, resp.Rate.Reset.Time)
-	}
-	if err != nil {
-		return relations, err
-	}
+	// one relation per author, counting their commits in the pages fetched
+	index := make(map[string]int)
+	for page := 1; ; page++ {
+		commits, resp, err := mc.Client.Repositories.ListCommits(ctx, owner, repo, opts)
+		if resp != nil {
+			mc.UpdateRateLimit(resp.Rate.Remaining, resp.Rate.Reset.Time)
+		}
+		if err != nil {
+			return relations, err
+		}
 
-	seen := make(map[string]bool)
-	for _, c := range commits {
-		if c.Author != nil {
-			login := c.Author.GetLogin()
-			if login != "" && login != owner && !seen[login] {
-				seen[login] = true
+		for _, c := range commits {
+			if c.Author != nil {
+				login := c.Author.GetLogin()
+				if login == "" || login == owner {
+					continue
+				}
+				if i, ok := index[login]; ok {
+					relations[i].Count++
+					continue
+				}
+				index[login] = len(relations)
 				relations = append(relations, DiscoveredRelation{
 					Login: login,
 					Type:  "commit",
 					Repo:  owner + "/" + repo,
+					Count: 1,
 				})
 			}
 		}
+		if resp.NextPage == 0 || rf.repoPageLimitReached(page) {
+			break
+		}
+		opts.Page = resp.NextPage
 	}
 	return relations, nil
 }
@@ -0,0 +1,85 @@
+package spider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/gnomegl/gitslurp/v2/internal/github"
+)
+
+// testFetcher returns a relation fetcher whose API requests are served by mux.
+func testFetcher(t *testing.T, mux *http.ServeMux, repoPages int) *RelationFetcher {
+	t.Helper()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	pool, err := github.NewClientPool(nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, mc := range pool.AllClients() {
+		mc.Client.BaseURL, _ = url.Parse(server.URL + "/")
+	}
+	return NewRelationFetcher(pool, repoPages)
+}
+
+func TestFetchRepoCommittersCounts(t *testing.T) {
+	pages := map[string][]string{
+		"1": {"alice", "bob", "alice", "seed", ""},
+		"2": {"bob", "alice", "carol"},
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/seed/tool/commits", func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page")
+		if page == "" {
+			page = "1"
+		}
+		if page == "1" {
+			w.Header().Set("Link", fmt.Sprintf(`<http://%s%s?page=2>; rel="next"`, r.Host, r.URL.Path))
+		}
+		fmt.Fprint(w, "[")
+		for i, login := range pages[page] {
+			if i > 0 {
+				fmt.Fprint(w, ",")
+			}
+			fmt.Fprintf(w, `{"sha": "%s%d", "author": {"login": %q}}`, page, i, login)
+		}
+		fmt.Fprint(w, "]")
+	})
+
+	tests := []struct {
+		name      string
+		repoPages int
+		want      map[string]int
+	}{
+		{"all pages", 0, map[string]int{"alice": 3, "bob": 2, "carol": 1}},
+		{"first page only", 1, map[string]int{"alice": 2, "bob": 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rf := testFetcher(t, mux, tt.repoPages)
+			relations, err := rf.FetchRepoCommitters(context.Background(), "seed", "tool")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := make(map[string]int)
+			for _, r := range relations {
+				if r.Type != "commit" || r.Repo != "seed/tool" {
+					t.Errorf("relation %+v, want a commit relation in seed/tool", r)
+				}
+				if _, dup := got[r.Login]; dup {
+					t.Errorf("%s listed twice", r.Login)
+				}
+				got[r.Login] = r.Count
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("commit counts = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
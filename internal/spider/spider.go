@@ -2,23 +2,56 @@ package spider
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/fatih/color"
 	"github.com/gnomegl/gitslurp/v2/internal/github"
+	"github.com/gnomegl/gitslurp/v2/internal/status"
 	"github.com/schollz/progressbar/v3"
 )
 
 type SpiderConfig struct {
-	Depth        int
-	MaxNodes     int
-	MinRepos     int
-	MinFollowers int
-	MaxWorkers   int
-	OutputFile   string
+	Depth          int
+	MaxNodes       int
+	MinRepos       int
+	MinFollowers   int
+	MaxWorkers     int
+	OutputFile     string
+	OutputDir      string
+	CommitterPages int
+	GraphFormat    string // gexf (default), json or graphml
+	CollapseEdges  bool
+	SkipBots       bool
+	BotLogins      []string // extra logins treated as bots
+	Resume         bool     // continue from the checkpoint of an earlier run
+}
+
+// GraphFormats are the values accepted by --graph-format.
+var GraphFormats = []string{"gexf", "json", "graphml"}
+
+// writeGraph writes the graph in the configured format, with one weighted
+// edge per pair of users under --collapse-edges.
+func (s *Spider) writeGraph(w io.Writer, seedLogin string) error {
+	graph := s.graph
+	if s.config.CollapseEdges {
+		graph = graph.Collapsed()
+	}
+
+	switch s.config.GraphFormat {
+	case "json":
+		return WriteJSON(w, graph, seedLogin)
+	case "graphml":
+		return WriteGraphML(w, graph, seedLogin)
+	default:
+		return WriteGEXF(w, graph, seedLogin)
+	}
 }
 
 type Spider struct {
@@ -40,6 +73,9 @@ func NewSpider(pool *github.ClientPool, cfg SpiderConfig) *Spider {
 	if cfg.Depth <= 0 {
 		cfg.Depth = 1
 	}
+	if cfg.GraphFormat == "" {
+		cfg.GraphFormat = "gexf"
+	}
 
 	return &Spider{
 		pool:   pool,
@@ -49,8 +85,10 @@ func NewSpider(pool *github.ClientPool, cfg SpiderConfig) *Spider {
 			MinRepos:     cfg.MinRepos,
 			MinFollowers: cfg.MinFollowers,
 			MaxNodes:     cfg.MaxNodes,
+			SkipBots:     cfg.SkipBots,
+			BotLogins:    NewBotDenylist(cfg.BotLogins),
 		},
-		fetcher: NewRelationFetcher(pool),
+		fetcher: NewRelationFetcher(pool, cfg.CommitterPages),
 		limiter: time.NewTicker(100 * time.Millisecond),
 	}
 }
@@ -58,45 +96,82 @@ func NewSpider(pool *github.ClientPool, cfg SpiderConfig) *Spider {
 func (s *Spider) Run(ctx context.Context, seedLogin string) error {
 	defer s.limiter.Stop()
 
-	color.Cyan("Starting social graph spider for: %s", seedLogin)
+	status.Cyan("Starting social graph spider for: %s", seedLogin)
 	fmt.Printf("  Depth: %d | Max nodes: %d | Workers: %d\n", s.config.Depth, s.config.MaxNodes, s.config.MaxWorkers)
 	if s.config.MinFollowers > 0 || s.config.MinRepos > 0 {
 		fmt.Printf("  Filters: min-followers=%d min-repos=%d\n", s.config.MinFollowers, s.config.MinRepos)
 	}
 	fmt.Println()
 
-	seedNode, err := s.fetcher.FetchUserProfile(ctx, seedLogin)
-	if err != nil {
-		return fmt.Errorf("failed to fetch seed user profile: %v", err)
+	outputPath := s.config.OutputFile
+	if outputPath == "" {
+		outputPath = seedLogin + "_graph." + s.config.GraphFormat
+	}
+	if s.config.OutputDir != "" && !filepath.IsAbs(outputPath) {
+		outputPath = filepath.Join(s.config.OutputDir, outputPath)
+	}
+	cpPath := checkpointPath(outputPath)
+
+	startDepth := 0
+	var currentLevel []string
+	if s.config.Resume {
+		cp, err := loadCheckpoint(cpPath)
+		switch {
+		case errors.Is(err, os.ErrNotExist):
+			status.Yellow("[!] No checkpoint at %s, starting from the seed", cpPath)
+		case err != nil:
+			return fmt.Errorf("failed to load checkpoint: %v", err)
+		case !strings.EqualFold(cp.Seed, seedLogin):
+			return fmt.Errorf("checkpoint %s belongs to %s, not %s", cpPath, cp.Seed, seedLogin)
+		default:
+			s.graph = cp.graph()
+			startDepth = cp.Depth
+			currentLevel = cp.Frontier
+			status.Green("[+] Resuming after depth %d: %d nodes, %d edges, %d users to expand",
+				cp.Depth, s.graph.NodeCount(), s.graph.EdgeCount(), len(currentLevel))
+		}
 	}
-	seedNode.Depth = 0
-	s.graph.AddNode(seedNode)
 
-	currentLevel := []string{seedLogin}
+	if !s.graph.HasNode(seedLogin) {
+		seedNode, err := s.fetcher.FetchUserProfile(ctx, seedLogin)
+		if err != nil {
+			return fmt.Errorf("failed to fetch seed user profile: %v", err)
+		}
+		seedNode.Depth = 0
+		s.graph.AddNode(seedNode)
+		currentLevel = []string{seedLogin}
+		startDepth = 0
+	}
 
-	for depth := 0; depth < s.config.Depth; depth++ {
+	stopped := false
+	for depth := startDepth; depth < s.config.Depth; depth++ {
 		if len(currentLevel) == 0 {
-			color.Yellow("[!] No users to process at depth %d, stopping", depth+1)
+			status.Yellow("[!] No users to process at depth %d, stopping", depth+1)
 			break
 		}
 
 		if s.filters.NodeLimitReached(s.graph.NodeCount()) {
-			color.Yellow("[!] Node limit reached (%d), stopping", s.config.MaxNodes)
+			status.Yellow("[!] Node limit reached (%d), stopping", s.config.MaxNodes)
 			break
 		}
 
-		color.Blue("\nDepth %d/%d - Processing %d users...", depth+1, s.config.Depth, len(currentLevel))
+		status.Blue("\nDepth %d/%d - Processing %d users...", depth+1, s.config.Depth, len(currentLevel))
 
 		nextLevel := s.processLevel(ctx, currentLevel, depth+1)
+		if ctx.Err() != nil {
+			// the level is incomplete, so the checkpoint stays at the last full one
+			color.Yellow("[!] Stopped during depth %d (%v); writing the partial graph", depth+1, ctx.Err())
+			stopped = true
+			break
+		}
 		currentLevel = nextLevel
 
-		color.Green("[+] Depth %d complete: %d nodes, %d edges",
+		status.Green("[+] Depth %d complete: %d nodes, %d edges",
 			depth+1, s.graph.NodeCount(), s.graph.EdgeCount())
-	}
 
-	outputPath := s.config.OutputFile
-	if outputPath == "" {
-		outputPath = seedLogin + "_graph.gexf"
+		if err := s.saveCheckpoint(cpPath, seedLogin, depth+1, currentLevel); err != nil {
+			status.Yellow("[!] Could not write checkpoint %s: %v", cpPath, err)
+		}
 	}
 
 	f, err := os.Create(outputPath)
@@ -105,12 +180,19 @@ func (s *Spider) Run(ctx context.Context, seedLogin string) error {
 	}
 	defer f.Close()
 
-	if err := WriteGEXF(f, s.graph, seedLogin); err != nil {
-		return fmt.Errorf("failed to write GEXF: %v", err)
+	if err := s.writeGraph(f, seedLogin); err != nil {
+		return fmt.Errorf("failed to write %s graph: %v", strings.ToUpper(s.config.GraphFormat), err)
+	}
+	if stopped {
+		fmt.Println()
+		color.Yellow("[!] Social graph is partial (re-run with --resume to continue):")
+	} else {
+		// the graph is complete, so a later --resume starts over
+		os.Remove(cpPath)
+
+		fmt.Println()
+		color.Green("[+] Social graph complete:")
 	}
-
-	fmt.Println()
-	color.Green("[+] Social graph complete:")
 	fmt.Printf("  Nodes: %d\n", s.graph.NodeCount())
 	fmt.Printf("  Edges: %d\n", s.graph.EdgeCount())
 	fmt.Printf("  Output: %s\n", outputPath)
@@ -135,7 +217,7 @@ func (s *Spider) processLevel(ctx context.Context, logins []string, nextDepth in
 		progressbar.OptionShowCount(),
 		progressbar.OptionSetWidth(10),
 		progressbar.OptionSetDescription("[cyan]Enumerating relationships[reset]"),
-		progressbar.OptionSetWriter(os.Stderr),
+		progressbar.OptionSetWriter(status.Writer()),
 		progressbar.OptionSetTheme(progressbar.Theme{
 			Saucer:        "[green]#[reset]",
 			SaucerHead:    "[green]>[reset]",
@@ -155,6 +237,10 @@ func (s *Spider) processLevel(ctx context.Context, logins []string, nextDepth in
 			sem <- struct{}{}
 			defer func() { <-sem }()
 
+			if ctx.Err() != nil {
+				bar.Add(1)
+				return
+			}
 			relations := s.enumerateUser(ctx, login)
 			resultsChan <- discoveryResult{login: login, relations: relations}
 			bar.Add(1)
@@ -167,12 +253,17 @@ func (s *Spider) processLevel(ctx context.Context, logins []string, nextDepth in
 	}()
 
 	newUsers := make(map[string]bool)
+	skippedBots := make(map[string]bool)
 	for result := range resultsChan {
 		for _, rel := range result.relations {
+			if s.filters.SkipBots && s.filters.IsBot(rel.Login) {
+				skippedBots[rel.Login] = true
+				continue
+			}
 			if rel.Type == "follower" || rel.Type == "stargazer" || rel.Type == "watcher" {
-				s.graph.AddEdge(rel.Login, result.login, rel.Type, rel.Repo)
+				s.graph.AddEdge(rel.Login, result.login, rel.Type, rel.Repo, rel.Count)
 			} else {
-				s.graph.AddEdge(result.login, rel.Login, rel.Type, rel.Repo)
+				s.graph.AddEdge(result.login, rel.Login, rel.Type, rel.Repo, rel.Count)
 			}
 
 			if !s.graph.HasNode(rel.Login) && !s.filters.NodeLimitReached(s.graph.NodeCount()) {
@@ -183,18 +274,22 @@ func (s *Spider) processLevel(ctx context.Context, logins []string, nextDepth in
 
 	bar.Finish()
 
+	if len(skippedBots) > 0 {
+		status.Yellow("[!] Skipped %d bot accounts", len(skippedBots))
+	}
+
 	if len(newUsers) == 0 {
 		return nil
 	}
 
-	color.Blue("Fetching profiles for %d new users...", len(newUsers))
+	status.Blue("Fetching profiles for %d new users...", len(newUsers))
 
 	profileBar := progressbar.NewOptions(len(newUsers),
 		progressbar.OptionEnableColorCodes(true),
 		progressbar.OptionShowCount(),
 		progressbar.OptionSetWidth(10),
 		progressbar.OptionSetDescription("[cyan]Fetching profiles[reset]"),
-		progressbar.OptionSetWriter(os.Stderr),
+		progressbar.OptionSetWriter(status.Writer()),
 		progressbar.OptionSetTheme(progressbar.Theme{
 			Saucer:        "[green]#[reset]",
 			SaucerHead:    "[green]>[reset]",
@@ -219,6 +314,10 @@ func (s *Spider) processLevel(ctx context.Context, logins []string, nextDepth in
 			profileSem <- struct{}{}
 			defer func() { <-profileSem }()
 
+			if ctx.Err() != nil {
+				profileBar.Add(1)
+				return
+			}
 			<-s.limiter.C
 			node, err := s.fetcher.FetchUserProfile(ctx, login)
 			if err != nil {
@@ -0,0 +1,47 @@
+// Package status prints progress bars and informational messages, which
+// --quiet turns off. Results and errors are printed directly and are not
+// affected.
+package status
+
+import (
+	"io"
+	"os"
+
+	"github.com/fatih/color"
+)
+
+// Quiet is set from --quiet before the run starts.
+var Quiet bool
+
+func Blue(format string, a ...interface{}) {
+	if !Quiet {
+		color.Blue(format, a...)
+	}
+}
+
+func Cyan(format string, a ...interface{}) {
+	if !Quiet {
+		color.Cyan(format, a...)
+	}
+}
+
+func Green(format string, a ...interface{}) {
+	if !Quiet {
+		color.Green(format, a...)
+	}
+}
+
+func Yellow(format string, a ...interface{}) {
+	if !Quiet {
+		color.Yellow(format, a...)
+	}
+}
+
+// Writer is where progress bars and countdowns draw: stderr, or nowhere
+// with --quiet.
+func Writer() io.Writer {
+	if Quiet {
+		return io.Discard
+	}
+	return os.Stderr
+}
@@ -0,0 +1,50 @@
+package status
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/fatih/color"
+	"github.com/schollz/progressbar/v3"
+)
+
+func TestQuiet(t *testing.T) {
+	defer func(out io.Writer, stderr *os.File) {
+		color.Output = out
+		os.Stderr = stderr
+		Quiet = false
+	}(color.Output, os.Stderr)
+
+	for _, quiet := range []bool{false, true} {
+		var out bytes.Buffer
+		color.Output = &out
+		stderr, err := os.CreateTemp(t.TempDir(), "stderr")
+		if err != nil {
+			t.Fatal(err)
+		}
+		os.Stderr = stderr
+		Quiet = quiet
+
+		Blue("listing %d repositories", 3)
+		Cyan("fetching")
+		Green("[+] done")
+		Yellow("[>] limiting")
+		bar := progressbar.NewOptions(2, progressbar.OptionSetWriter(Writer()), progressbar.OptionThrottle(0))
+		bar.Add(1)
+		bar.Finish()
+
+		stderr.Close()
+		progress, err := os.ReadFile(stderr.Name())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if quiet && (out.Len() > 0 || len(progress) > 0) {
+			t.Errorf("--quiet printed %q and drew %q on stderr", out.String(), progress)
+		}
+		if !quiet && (out.Len() == 0 || len(progress) == 0) {
+			t.Errorf("without --quiet nothing was printed (status %q, progress %q)", out.String(), progress)
+		}
+	}
+}
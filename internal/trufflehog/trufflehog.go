@@ -14,6 +14,7 @@ import (
 
 	"github.com/fatih/color"
 	"github.com/gnomegl/gitslurp/v2/internal/github"
+	"github.com/gnomegl/gitslurp/v2/internal/status"
 	gh "github.com/google/go-github/v57/github"
 	"github.com/schollz/progressbar/v3"
 )
@@ -108,6 +109,11 @@ func (r *Runner) SetDiscoveredUsers(users []string) {
 	r.discoveredUsers = users
 }
 
+// SetOutputDir overrides where per-user results and the summary are written.
+func (r *Runner) SetOutputDir(dir string) {
+	r.outputDir = dir
+}
+
 // checkTrufflehog verifies trufflehog is installed
 func checkTrufflehog() error {
 	_, err := exec.LookPath("trufflehog")
@@ -393,7 +399,7 @@ func (r *Runner) scanUsers(ctx context.Context, users []string) []ScanResult {
 		progressbar.OptionShowCount(),
 		progressbar.OptionSetWidth(15),
 		progressbar.OptionSetDescription("[cyan]Scanning repos for secrets[reset]"),
-		progressbar.OptionSetWriter(os.Stderr),
+		progressbar.OptionSetWriter(status.Writer()),
 		progressbar.OptionSetTheme(progressbar.Theme{
 			Saucer:        "[green]#[reset]",
 			SaucerHead:    "[green]>[reset]",
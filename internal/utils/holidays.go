@@ -0,0 +1,170 @@
+package utils
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// holiday returns the date of a public holiday in the given year.
+type holiday func(year int) time.Time
+
+func fixed(month time.Month, day int) holiday {
+	return func(year int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+}
+
+// nthWeekday is the nth weekday of the month, or the last one when n is -1.
+func nthWeekday(month time.Month, weekday time.Weekday, n int) holiday {
+	return func(year int) time.Time {
+		if n < 0 {
+			last := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC)
+			return last.AddDate(0, 0, -((int(last.Weekday()) - int(weekday) + 7) % 7))
+		}
+		first := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+		return first.AddDate(0, 0, (int(weekday)-int(first.Weekday())+7)%7+7*(n-1))
+	}
+}
+
+// easter is a holiday the given number of days from Easter Sunday.
+func easter(days int) holiday {
+	return func(year int) time.Time {
+		// anonymous Gregorian algorithm
+		a, b, c := year%19, year/100, year%100
+		d, e := b/4, b%4
+		f := (b + 8) / 25
+		g := (b - f + 1) / 3
+		h := (19*a + b - d - g + 15) % 30
+		i, k := c/4, c%4
+		l := (32 + 2*e + 2*i - h - k) % 7
+		m := (a + 11*h + 22*l) / 451
+		month := (h + l - 7*m + 114) / 31
+		day := (h+l-7*m+114)%31 + 1
+		return time.Date(year, time.Month(month), day+days, 0, 0, 0, 0, time.UTC)
+	}
+}
+
+// victoriaDay is the last Monday before May 25.
+func victoriaDay(year int) time.Time {
+	d := time.Date(year, time.May, 24, 0, 0, 0, 0, time.UTC)
+	return d.AddDate(0, 0, -((int(d.Weekday()) - int(time.Monday) + 7) % 7))
+}
+
+// holidaySets are the national public holidays --holidays knows about.
+var holidaySets = map[string][]holiday{
+	"US": {
+		fixed(time.January, 1),
+		nthWeekday(time.January, time.Monday, 3),  // Martin Luther King Jr. Day
+		nthWeekday(time.February, time.Monday, 3), // Presidents' Day
+		nthWeekday(time.May, time.Monday, -1),     // Memorial Day
+		fixed(time.June, 19),
+		fixed(time.July, 4),
+		nthWeekday(time.September, time.Monday, 1), // Labor Day
+		nthWeekday(time.October, time.Monday, 2),   // Columbus Day
+		fixed(time.November, 11),
+		nthWeekday(time.November, time.Thursday, 4), // Thanksgiving
+		fixed(time.December, 25),
+	},
+	"GB": {
+		fixed(time.January, 1),
+		easter(-2), easter(1),
+		nthWeekday(time.May, time.Monday, 1),
+		nthWeekday(time.May, time.Monday, -1),
+		nthWeekday(time.August, time.Monday, -1),
+		fixed(time.December, 25),
+		fixed(time.December, 26),
+	},
+	"DE": {
+		fixed(time.January, 1),
+		easter(-2), easter(1), easter(39), easter(50),
+		fixed(time.May, 1),
+		fixed(time.October, 3),
+		fixed(time.December, 25),
+		fixed(time.December, 26),
+	},
+	"FR": {
+		fixed(time.January, 1),
+		easter(1), easter(39), easter(50),
+		fixed(time.May, 1),
+		fixed(time.May, 8),
+		fixed(time.July, 14),
+		fixed(time.August, 15),
+		fixed(time.November, 1),
+		fixed(time.November, 11),
+		fixed(time.December, 25),
+	},
+	"CA": {
+		fixed(time.January, 1),
+		easter(-2),
+		victoriaDay,
+		fixed(time.July, 1),
+		nthWeekday(time.September, time.Monday, 1), // Labour Day
+		nthWeekday(time.October, time.Monday, 2),   // Thanksgiving
+		fixed(time.December, 25),
+		fixed(time.December, 26),
+	},
+}
+
+var holidayAliases = map[string]string{"UK": "GB"}
+
+var (
+	holidayMu      sync.Mutex
+	holidayCountry string
+	holidayYears   map[int]map[string]bool
+)
+
+// HolidayCountries lists the country codes --holidays accepts.
+func HolidayCountries() []string {
+	var codes []string
+	for code := range holidaySets {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+// ParseHolidayCountry normalizes a --holidays value to a known country code.
+// An empty value stays empty.
+func ParseHolidayCountry(country string) (string, error) {
+	country = strings.ToUpper(strings.TrimSpace(country))
+	if alias, ok := holidayAliases[country]; ok {
+		country = alias
+	}
+	if _, ok := holidaySets[country]; !ok && country != "" {
+		return "", fmt.Errorf("unknown --holidays country %q (valid: %s)", country, strings.Join(HolidayCountries(), ", "))
+	}
+	return country, nil
+}
+
+// UseHolidays makes GetTimestampPatterns report the share of commits made on
+// the public holidays of a country from ParseHolidayCountry. An empty country
+// turns it off.
+func UseHolidays(country string) {
+	holidayMu.Lock()
+	defer holidayMu.Unlock()
+	holidayCountry = country
+	holidayYears = make(map[int]map[string]bool)
+}
+
+// isHoliday reports whether t's calendar date is a holiday in the selected
+// country. ok is false when no country is selected.
+func isHoliday(t time.Time) (on, ok bool) {
+	holidayMu.Lock()
+	defer holidayMu.Unlock()
+	if holidayCountry == "" {
+		return false, false
+	}
+
+	days, cached := holidayYears[t.Year()]
+	if !cached {
+		days = make(map[string]bool)
+		for _, h := range holidaySets[holidayCountry] {
+			days[h(t.Year()).Format("01-02")] = true
+		}
+		holidayYears[t.Year()] = days
+	}
+	return days[t.Format("01-02")], true
+}
@@ -0,0 +1,91 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gnomegl/gitslurp/v2/internal/models"
+)
+
+func TestIsHoliday(t *testing.T) {
+	defer UseHolidays("")
+	day := func(y int, m time.Month, d int) time.Time { return time.Date(y, m, d, 12, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		country string
+		date    time.Time
+		want    bool
+	}{
+		{"US", day(2024, time.July, 4), true},
+		{"US", day(2024, time.November, 28), true}, // Thanksgiving
+		{"US", day(2024, time.May, 27), true},      // Memorial Day
+		{"US", day(2024, time.July, 5), false},
+		{"GB", day(2024, time.March, 29), true}, // Good Friday
+		{"GB", day(2024, time.April, 1), true},  // Easter Monday
+		{"GB", day(2024, time.July, 4), false},
+		{"DE", day(2024, time.October, 3), true},
+		{"DE", day(2025, time.May, 29), true}, // Ascension
+		{"FR", day(2024, time.July, 14), true},
+		{"CA", day(2024, time.May, 20), true}, // Victoria Day
+		{"CA", day(2024, time.July, 4), false},
+	}
+	for _, tt := range tests {
+		UseHolidays(tt.country)
+		if got, ok := isHoliday(tt.date); !ok || got != tt.want {
+			t.Errorf("%s %s: holiday = %v (known %v), want %v", tt.country, tt.date.Format("2006-01-02"), got, ok, tt.want)
+		}
+	}
+
+	UseHolidays("")
+	if _, ok := isHoliday(day(2024, time.July, 4)); ok {
+		t.Error("isHoliday known with no --holidays country")
+	}
+}
+
+func TestParseHolidayCountry(t *testing.T) {
+	tests := []struct {
+		in, want string
+		err      bool
+	}{
+		{"us", "US", false},
+		{" uk ", "GB", false},
+		{"", "", false},
+		{"XX", "", true},
+	}
+	for _, tt := range tests {
+		got, err := ParseHolidayCountry(tt.in)
+		if (err != nil) != tt.err || got != tt.want {
+			t.Errorf("ParseHolidayCountry(%q) = %q, %v", tt.in, got, err)
+		}
+	}
+}
+
+func TestHolidayPatterns(t *testing.T) {
+	defer UseHolidays("")
+	var commits []models.CommitInfo
+	for _, d := range []time.Time{
+		time.Date(2024, time.January, 1, 10, 0, 0, 0, time.UTC), // holiday
+		time.Date(2024, time.January, 3, 10, 0, 0, 0, time.UTC),
+		time.Date(2024, time.February, 20, 10, 0, 0, 0, time.UTC), // 48 days later
+		time.Date(2024, time.February, 27, 10, 0, 0, 0, time.UTC),
+	} {
+		commits = append(commits, models.CommitInfo{TimestampAnalysis: AnalyzeTimestamp(d)})
+	}
+
+	UseHolidays("")
+	if _, ok := GetTimestampPatterns(commits)["holiday_percentage"]; ok {
+		t.Error("holiday_percentage reported without --holidays")
+	}
+
+	UseHolidays("US")
+	patterns := GetTimestampPatterns(commits)
+	if got := patterns["holiday_percentage"]; got != 25.0 {
+		t.Errorf("holiday_percentage = %v, want 25", got)
+	}
+	if got := patterns["longest_gap_days"]; got != 48 {
+		t.Errorf("longest_gap_days = %v, want 48", got)
+	}
+	if got := patterns["long_gap_count"]; got != 1 {
+		t.Errorf("long_gap_count = %v, want 1", got)
+	}
+}
@@ -1,6 +1,8 @@
 package utils
 
 import (
+	"math"
+	"sort"
 	"time"
 
 	"github.com/gnomegl/gitslurp/v2/internal/models"
@@ -42,6 +44,10 @@ func GetTimestampPatterns(commits []models.CommitInfo) map[string]interface{} {
 	patterns := make(map[string]interface{})
 	
 	hourDistribution := make(map[int]int)
+	var utcHours [24]int
+	statedOffsets := make(map[int]int)
+	var dates []time.Time
+	holidayCount, holidaysKnown := 0, false
 	dayDistribution := make(map[time.Weekday]int)
 	timezoneDistribution := make(map[string]int)
 	unusualHourCount := 0
@@ -52,6 +58,18 @@ func GetTimestampPatterns(commits []models.CommitInfo) map[string]interface{} {
 	for _, commit := range commits {
 		if commit.TimestampAnalysis != nil {
 			hourDistribution[commit.TimestampAnalysis.LocalHourOfDay]++
+			utcHours[commit.TimestampAnalysis.UTCTime.Hour()]++
+			dates = append(dates, commit.TimestampAnalysis.UTCTime)
+			if holiday, ok := isHoliday(commit.TimestampAnalysis.LocalTime); ok {
+				holidaysKnown = true
+				if holiday {
+					holidayCount++
+				}
+			}
+			if local := commit.TimestampAnalysis.LocalTime; local.Location() != time.UTC {
+				_, seconds := local.Zone()
+				statedOffsets[int(math.Round(float64(seconds)/3600))]++
+			}
 			dayDistribution[commit.TimestampAnalysis.DayOfWeek]++
 			timezoneDistribution[commit.TimestampAnalysis.CommitTimezone]++
 			
@@ -76,6 +94,13 @@ func GetTimestampPatterns(commits []models.CommitInfo) map[string]interface{} {
 		patterns["weekend_percentage"] = float64(weekendCount) / float64(totalCommits) * 100
 		patterns["night_owl_percentage"] = float64(nightOwlCount) / float64(totalCommits) * 100
 		patterns["early_bird_percentage"] = float64(earlyBirdCount) / float64(totalCommits) * 100
+		if holidaysKnown {
+			patterns["holiday_percentage"] = float64(holidayCount) / float64(totalCommits) * 100
+		}
+	}
+	if longest, longGaps, ok := commitGaps(dates); ok {
+		patterns["longest_gap_days"] = longest
+		patterns["long_gap_count"] = longGaps
 	}
 
 	patterns["hour_distribution"] = hourDistribution
@@ -90,10 +115,122 @@ func GetTimestampPatterns(commits []models.CommitInfo) map[string]interface{} {
 	patterns["most_active_hour"] = mostActiveHour
 	patterns["most_active_day"] = mostActiveDay
 	patterns["most_active_timezone"] = mostActiveTimezone
+	inferred, inferredOK := InferUTCOffset(utcHours)
+	if inferredOK {
+		patterns["inferred_utc_offset"] = inferred
+	}
+	if stated, ok := mostCommonOffset(statedOffsets); ok {
+		patterns["stated_utc_offset"] = stated
+		if inferredOK && TimezoneMismatch(stated, inferred) {
+			patterns["timezone_mismatch"] = true
+		}
+	}
 
 	return patterns
 }
 
+// minCommitsForInference is how many commits InferUTCOffset needs before the
+// quiet hours stand out from noise.
+const minCommitsForInference = 20
+
+// InferUTCOffset estimates a committer's UTC offset in hours from when they
+// commit rather than from the offsets git recorded, which are easy to fake.
+// It finds the quietest six hours of the UTC day and assumes they are the
+// local night, 01:00 to 07:00. ok is false with too few commits.
+func InferUTCOffset(utcHours [24]int) (offset int, ok bool) {
+	total := 0
+	for _, n := range utcHours {
+		total += n
+	}
+	if total < minCommitsForInference {
+		return 0, false
+	}
+
+	const nightStart, nightHours = 1, 6
+	// commits in the window starting at start, widened by pad hours each side
+	window := func(start, pad int) int {
+		count := 0
+		for h := -pad; h < nightHours+pad; h++ {
+			count += utcHours[((start+h)%24+24)%24]
+		}
+		return count
+	}
+
+	// ties, as in a long quiet stretch, go to the window whose surroundings
+	// are quieter, which centers it on the stretch
+	quietest := 0
+	for start := 1; start < 24; start++ {
+		for pad := 0; pad <= 8; pad += 2 {
+			a, b := window(start, pad), window(quietest, pad)
+			if a != b {
+				if a < b {
+					quietest = start
+				}
+				break
+			}
+		}
+	}
+
+	// local = UTC + offset, kept within -11..+12
+	offset = ((nightStart-quietest)%24+24+11)%24 - 11
+	return offset, true
+}
+
+// longGapDays is the break between commits above which involvement looks
+// part-time rather than continuous.
+const longGapDays = 14
+
+// commitGaps returns the longest break between consecutive commits, in whole
+// days, and how many breaks are longer than longGapDays. ok is false with
+// fewer than two commits.
+func commitGaps(dates []time.Time) (longest, longGaps int, ok bool) {
+	if len(dates) < 2 {
+		return 0, 0, false
+	}
+	sort.Slice(dates, func(i, j int) bool { return dates[i].Before(dates[j]) })
+	for i := 1; i < len(dates); i++ {
+		days := int(dates[i].Sub(dates[i-1]).Hours() / 24)
+		if days > longest {
+			longest = days
+		}
+		if days > longGapDays {
+			longGaps++
+		}
+	}
+	return longest, longGaps, true
+}
+
+// maxOffsetDrift is how many hours the stated and inferred offsets may differ
+// before TimezoneMismatch flags them; the night window is only an estimate.
+const maxOffsetDrift = 4
+
+// TimezoneMismatch reports whether the offset git recorded is more than four
+// hours from the one inferred from commit hours, a sign the stated timezone
+// may be faked.
+func TimezoneMismatch(stated, inferred int) bool {
+	diff := (stated - inferred) % 24
+	if diff < 0 {
+		diff += 24
+	}
+	if diff > 12 {
+		diff = 24 - diff
+	}
+	return diff > maxOffsetDrift
+}
+
+// mostCommonOffset picks the stated offset most commits carry. Commits the
+// API normalized to UTC are not counted, since they say nothing about where
+// the author was.
+func mostCommonOffset(offsets map[int]int) (int, bool) {
+	best, bestCount := 0, 0
+	for offset, n := range offsets {
+		if n > bestCount || (n == bestCount && offset < best) {
+			best, bestCount = offset, n
+		}
+	}
+	return best, bestCount > 0
+}
+
 func findMostActiveHour(hourDist map[int]int) int {
 	maxCount := 0
 	mostActive := 0
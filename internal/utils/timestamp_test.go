@@ -0,0 +1,108 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gnomegl/gitslurp/v2/internal/models"
+)
+
+// localDay is a typical day of commits by local hour: quiet from 01:00 to
+// 07:00, busiest in the afternoon.
+var localDay = [24]int{1, 0, 0, 0, 0, 0, 0, 1, 2, 4, 5, 6, 4, 5, 6, 6, 5, 4, 3, 3, 4, 3, 2, 2}
+
+// workdayHours returns days of localDay for a committer at UTC+offset, as
+// counts per UTC hour.
+func workdayHours(offset, days int) [24]int {
+	var hours [24]int
+	for local, n := range localDay {
+		hours[((local-offset)%24+24)%24] += n * days
+	}
+	return hours
+}
+
+func TestInferUTCOffset(t *testing.T) {
+	tests := []struct {
+		name   string
+		hours  [24]int
+		want   int
+		wantOK bool
+	}{
+		{"UTC", workdayHours(0, 2), 0, true},
+		{"UTC+1", workdayHours(1, 2), 1, true},
+		{"UTC-7", workdayHours(-7, 2), -7, true},
+		{"UTC+5", workdayHours(5, 2), 5, true},
+		{"UTC+9", workdayHours(9, 2), 9, true},
+		{"UTC+12", workdayHours(12, 2), 12, true},
+		{"UTC-11", workdayHours(-11, 2), -11, true},
+		{"too few commits", [24]int{10: 5, 14: 6}, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := InferUTCOffset(tt.hours)
+			if ok != tt.wantOK || (ok && got != tt.want) {
+				t.Errorf("InferUTCOffset = %d, %v; want %d, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestTimezoneMismatch(t *testing.T) {
+	tests := []struct {
+		stated, inferred int
+		want             bool
+	}{
+		{1, 1, false},
+		{1, -3, false},
+		{1, -7, true},
+		{-7, 1, true},
+		{12, -11, false}, // an hour apart across the date line
+		{10, -8, true},
+		{0, 12, true},
+	}
+	for _, tt := range tests {
+		if got := TimezoneMismatch(tt.stated, tt.inferred); got != tt.want {
+			t.Errorf("TimezoneMismatch(%d, %d) = %v, want %v", tt.stated, tt.inferred, got, tt.want)
+		}
+	}
+}
+
+// commitsAt builds commits following localDay for a committer at
+// UTC+actual whose git records UTC+stated.
+func commitsAt(actual, stated int) []models.CommitInfo {
+	zone := time.FixedZone("stated", stated*3600)
+	var commits []models.CommitInfo
+	for local, n := range localDay {
+		for i := 0; i < n*2; i++ {
+			at := time.Date(2024, 3, 4+i, local, 30, 0, 0, time.FixedZone("actual", actual*3600)).In(zone)
+			commits = append(commits, models.CommitInfo{TimestampAnalysis: AnalyzeTimestamp(at)})
+		}
+	}
+	return commits
+}
+
+func TestTimestampPatternsMismatch(t *testing.T) {
+	tests := []struct {
+		name           string
+		actual, stated int
+		mismatch       bool
+	}{
+		{"stated zone matches activity", -7, -7, false},
+		{"close enough", 2, 1, false},
+		{"stated UTC+1, active at UTC-7", -7, 1, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			patterns := GetTimestampPatterns(commitsAt(tt.actual, tt.stated))
+			if got := patterns["inferred_utc_offset"]; got != tt.actual {
+				t.Errorf("inferred_utc_offset = %v, want %d", got, tt.actual)
+			}
+			if got := patterns["stated_utc_offset"]; got != tt.stated {
+				t.Errorf("stated_utc_offset = %v, want %d", got, tt.stated)
+			}
+			if got := patterns["timezone_mismatch"] == true; got != tt.mismatch {
+				t.Errorf("timezone_mismatch = %v, want %v", got, tt.mismatch)
+			}
+		})
+	}
+}
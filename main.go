@@ -1,39 +1,104 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"os"
+	"os/signal"
 	"strings"
+	"syscall"
 
 	"github.com/fatih/color"
 	"github.com/gnomegl/gitslurp/v2/internal/auth"
 	cliPkg "github.com/gnomegl/gitslurp/v2/internal/cli"
 	"github.com/gnomegl/gitslurp/v2/internal/config"
+	"github.com/gnomegl/gitslurp/v2/internal/github"
 	"github.com/gnomegl/gitslurp/v2/internal/service"
+	"github.com/gnomegl/gitslurp/v2/internal/status"
 	"github.com/urfave/cli/v2"
 )
 
+// hasStructuredOutputFlag reports whether JSON, CSV or Markdown goes to stdout, in which
+// case everything else is silenced. With --output-file it goes to the file.
 func hasStructuredOutputFlag() bool {
-	for _, arg := range os.Args[1:] {
-		if arg == "--json" || arg == "--csv" {
-			return true
+	structured := false
+	args := os.Args[1:]
+	for i, arg := range args {
+		if arg == "--output-file" || strings.HasPrefix(arg, "--output-file=") {
+			return false
+		}
+		if arg == "--json" || arg == "--csv" || arg == "--markdown" {
+			structured = true
+		}
+		if name, value, ok := strings.Cut(arg, "="); ok && (name == "-o" || name == "--output-format") {
+			structured = strings.ToLower(value) != "text"
+			continue
+		}
+		if (arg == "-o" || arg == "--output-format") && i+1 < len(args) {
+			structured = strings.ToLower(args[i+1]) != "text"
+			continue
 		}
 		if arg == "--" {
-			return false
+			break
 		}
 		if strings.HasPrefix(arg, "-") && !strings.HasPrefix(arg, "--") {
 			if strings.ContainsRune(arg, 'j') {
-				return true
+				structured = true
 			}
 		}
 	}
+	return structured
+}
+
+// hasFlag is checked for --no-color and --quiet before the app runs, so they
+// apply to the logo too.
+func hasFlag(name string) bool {
+	for _, arg := range os.Args[1:] {
+		if arg == "--" {
+			break
+		}
+		if arg == name {
+			return true
+		}
+	}
 	return false
 }
 
+// interruptContext is cancelled by the first Ctrl-C, so the run stops crawling
+// and still prints what it found. A second Ctrl-C exits immediately, unless a
+// temporary spoofing repository is still being deleted; exiting then would
+// leave it on the account.
+func interruptContext(stderr io.Writer) context.Context {
+	ctx, cancel := context.WithCancel(context.Background())
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-sigs
+		fmt.Fprintln(stderr, color.YellowString("\n[!] Interrupted, finishing with partial results (Ctrl-C again to quit)"))
+		cancel()
+		for range sigs {
+			pending := github.PendingCleanups()
+			if len(pending) == 0 {
+				os.Exit(130)
+			}
+			for _, repo := range pending {
+				fmt.Fprintln(stderr, color.YellowString("[!] Still deleting temporary repository %s; not quitting until it is gone (or delete it at %s)", repo, github.SpoofRepoSettingsURL(repo)))
+			}
+		}
+	}()
+	return ctx
+}
+
 func main() {
 	config.NormalizeArgs()
 
+	// color already honors NO_COLOR and disables itself when stdout is not a terminal
+	if hasFlag("--no-color") {
+		color.NoColor = true
+	}
+	status.Quiet = hasFlag("--quiet")
+
 	realStdout := os.Stdout
 	realStderr := os.Stderr
 
@@ -45,6 +110,20 @@ func main() {
 		color.Output = io.Discard
 	}
 
+	spiderAction := func(c *cli.Context) error {
+		appConfig, err := config.ParseSpiderCommand(c)
+		if err != nil {
+			return err
+		}
+		status.Quiet = appConfig.Quiet
+
+		pool, err := auth.SetupClientPool(c, c.Context, appConfig)
+		if err != nil {
+			return err
+		}
+		return service.NewOrchestrator(pool, appConfig, realStdout).Run(c.Context)
+	}
+
 	app := cliPkg.NewApp(func(c *cli.Context) error {
 		appConfig, err := config.ParseConfig(c)
 		if err != nil {
@@ -54,10 +133,11 @@ func main() {
 		if appConfig == nil {
 			return nil
 		}
+		status.Quiet = appConfig.Quiet
 
 		ctx := c.Context
 		plat := strings.ToLower(appConfig.Platform)
-		if plat == "gitlab" || plat == "codeberg" {
+		if plat == "gitlab" || plat == "codeberg" || appConfig.LocalPath != "" {
 			orchestrator := service.NewOrchestrator(nil, appConfig, realStdout)
 			return orchestrator.Run(ctx)
 		}
@@ -69,9 +149,9 @@ func main() {
 
 		orchestrator := service.NewOrchestrator(pool, appConfig, realStdout)
 		return orchestrator.Run(ctx)
-	})
+	}, spiderAction)
 
-	if err := app.Run(os.Args); err != nil {
+	if err := app.RunContext(interruptContext(realStderr), os.Args); err != nil {
 		fmt.Fprintln(realStderr, err)
 		os.Exit(1)
 	}